@@ -21,11 +21,16 @@ package driver
 import (
 	"context"
 	goerrors "errors"
+	"fmt"
 	"log"
+	"runtime"
+	"sync"
+	"time"
 
 	"kythe.io/kythe/go/platform/analysis"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	apb "kythe.io/kythe/proto/analysis_go_proto"
 )
@@ -66,13 +71,13 @@ type Context interface {
 	// the error returned by the analyzer itself.
 	//
 	// If AnalysisError returns the special value ErrRetry, the analysis is
-	// retried immediately.
+	// retried as permitted by the driver's RetryPolicy.
 	AnalysisError(context.Context, Compilation, error) error
 }
 
 var (
 	// ErrRetry can be returned from a Driver's AnalysisError function to signal
-	// that the driver should retry the analysis immediately.
+	// that the driver should retry the analysis.
 	ErrRetry = goerrors.New("retry analysis")
 
 	// ErrEndOfQueue can be returned from a Queue to signal there are no
@@ -80,12 +85,58 @@ var (
 	ErrEndOfQueue = goerrors.New("end of queue")
 )
 
+// A RetryPolicy controls how many times, and how quickly, a Driver retries an
+// analysis for which the Context's AnalysisError hook reports ErrRetry.
+type RetryPolicy struct {
+	// The maximum number of times a compilation is analyzed, including the
+	// first attempt.  If MaxAttempts <= 0, the number of attempts is unlimited.
+	MaxAttempts int
+
+	// The delay before the first retry.  Each subsequent delay is doubled, up
+	// to MaxBackoff (if MaxBackoff > 0).
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// delay returns the delay before the given retry, where retry 1 follows the
+// initial attempt.
+func (r *RetryPolicy) delay(retry int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < retry && d > 0; i++ {
+		if r.MaxBackoff > 0 && d >= r.MaxBackoff {
+			break
+		}
+		d *= 2
+	}
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+	return d
+}
+
 // Driver sends compilations sequentially from a queue to an analyzer.
 type Driver struct {
 	Analyzer        analysis.CompilationAnalyzer
 	FileDataService string
 	Context         Context             // if nil, callbacks are no-ops
 	WriteOutput     analysis.OutputFunc // if nil, output is discarded
+
+	// Retry controls how analyses are retried after the AnalysisError hook
+	// reports ErrRetry.  If nil, analyses are retried immediately and without
+	// limit.  Outputs are not buffered between attempts: WriteOutput receives
+	// the outputs of every attempt, including those that failed.
+	Retry *RetryPolicy
+
+	// If Timeout > 0, the setup and analysis of each compilation, including
+	// any retries, must complete within this duration.  The deadline is
+	// applied through the context passed to the Analyzer, which must honour
+	// its cancellation.
+	Timeout time.Duration
+
+	// If non-nil, compilations whose analysis fails are recorded in Failures
+	// and the driver continues with the next compilation.  Otherwise, the
+	// first failure stops the driver.
+	Failures *FailureReport
 }
 
 func (d *Driver) writeOutput(ctx context.Context, out *apb.AnalysisOutput) error {
@@ -126,25 +177,7 @@ func (d *Driver) Run(ctx context.Context, queue Queue) error {
 
 	for {
 		if err := queue.Next(ctx, func(ctx context.Context, cu Compilation) error {
-			if err := d.setup(ctx, cu); err != nil {
-				return errors.WithMessage(err, "driver: analysis setup")
-			}
-			err := ErrRetry
-			for err == ErrRetry {
-				err = d.analysisError(ctx, cu, d.Analyzer.Analyze(ctx, &apb.AnalysisRequest{
-					Compilation:     cu.Unit,
-					FileDataService: d.FileDataService,
-					Revision:        cu.Revision,
-					BuildId:         cu.BuildID,
-				}, d.writeOutput))
-			}
-			if terr := d.teardown(ctx, cu); terr != nil {
-				if err == nil {
-					return errors.WithMessage(terr, "driver: analysis teardown")
-				}
-				log.Printf("WARNING: analysis teardown failed: %v (analysis error: %v)", terr, err)
-			}
-			return err
+			return d.analyze(ctx, cu, d.writeOutput)
 		}); err == ErrEndOfQueue {
 			return nil
 		} else if err != nil {
@@ -152,3 +185,226 @@ func (d *Driver) Run(ctx context.Context, queue Queue) error {
 		}
 	}
 }
+
+// analyze sends a single compilation to the driver's Analyzer, invoking the
+// Context callbacks around it and passing each output to out.
+func (d *Driver) analyze(ctx context.Context, cu Compilation, out analysis.OutputFunc) error {
+	start := time.Now()
+	actx := ctx
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		actx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+
+	if err := d.setup(actx, cu); err != nil {
+		return d.record(ctx, cu, start, 0, errors.WithMessage(err, "driver: analysis setup"))
+	}
+	attempts, err := d.attempt(actx, cu, out)
+	if terr := d.teardown(ctx, cu); terr != nil {
+		if err == nil {
+			err = errors.WithMessage(terr, "driver: analysis teardown")
+		} else {
+			log.Printf("WARNING: analysis teardown failed: %v (analysis error: %v)", terr, err)
+		}
+	}
+	return d.record(ctx, cu, start, attempts, err)
+}
+
+// record adds a failed analysis to the driver's FailureReport, if it has one,
+// and returns the error (if any) that should stop the driver.  Failures are not
+// recorded once ctx has ended, since the driver itself is shutting down.
+func (d *Driver) record(ctx context.Context, cu Compilation, start time.Time, attempts int, err error) error {
+	if err == nil || d.Failures == nil || ctx.Err() != nil {
+		return err
+	}
+	d.Failures.Add(&Failure{
+		UnitDigest: cu.UnitDigest,
+		VName:      cu.Unit.GetVName(),
+		Error:      err.Error(),
+		Attempts:   attempts,
+		Duration:   time.Since(start),
+	})
+	return nil
+}
+
+// attempt analyzes cu, retrying according to the driver's RetryPolicy.  It
+// returns the number of analysis attempts made.  Outputs are passed to out as
+// they are produced, so those of failed attempts have already been delivered
+// when the analysis is retried.
+func (d *Driver) attempt(ctx context.Context, cu Compilation, out analysis.OutputFunc) (int, error) {
+	// Once the deadline has passed, refuse further outputs so that analyzers
+	// writing many outputs stop promptly.
+	write := func(octx context.Context, o *apb.AnalysisOutput) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return out(octx, o)
+	}
+
+	for attempts := 1; ; attempts++ {
+		aerr := d.Analyzer.Analyze(ctx, &apb.AnalysisRequest{
+			Compilation:     cu.Unit,
+			FileDataService: d.FileDataService,
+			Revision:        cu.Revision,
+			BuildId:         cu.BuildID,
+		}, write)
+		if aerr != nil && ctx.Err() == context.DeadlineExceeded {
+			aerr = errors.WithMessage(ctx.Err(), fmt.Sprintf("driver: analysis timed out after %v", d.Timeout))
+		}
+		err := d.analysisError(ctx, cu, aerr)
+		if err != ErrRetry {
+			return attempts, err
+		} else if ctx.Err() != nil {
+			return attempts, aerr // there is no time left to retry
+		}
+
+		r := d.Retry
+		if r == nil {
+			continue
+		} else if r.MaxAttempts > 0 && attempts >= r.MaxAttempts {
+			return attempts, errors.WithMessage(aerr, fmt.Sprintf("driver: analysis failed after %d attempts", attempts))
+		}
+		if delay := r.delay(attempts); delay > 0 {
+			t := time.NewTimer(delay)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return attempts, errors.WithMessage(ctx.Err(), "driver: waiting to retry analysis")
+			}
+		}
+	}
+}
+
+// A ParallelDriver sends compilations from a queue to an analyzer using
+// multiple concurrent workers.
+//
+// The Queue is read from a single goroutine, but compilations are analyzed
+// after Next has returned, so a Queue used with a ParallelDriver must not
+// invalidate its compilations (or any associated analysis.Fetcher) on
+// subsequent calls to Next. The Analyzer and the Context callbacks must be
+// safe for concurrent use. WriteOutput is never invoked concurrently.
+type ParallelDriver struct {
+	Driver
+
+	// The number of compilations to analyze concurrently.  If Workers <= 0,
+	// runtime.NumCPU() workers are used.
+	Workers int
+
+	// If true, the outputs of each compilation are buffered in memory and
+	// delivered to WriteOutput in the order the compilations were read from
+	// the queue.  Otherwise outputs are delivered as soon as they are
+	// produced, and outputs of different compilations may be interleaved.
+	Ordered bool
+}
+
+func (p *ParallelDriver) workers() int {
+	if p.Workers <= 0 {
+		return runtime.NumCPU()
+	}
+	return p.Workers
+}
+
+// A task is a compilation in flight in a ParallelDriver.
+type task struct {
+	cu   Compilation
+	done chan []*apb.AnalysisOutput // buffered outputs, if ordered
+}
+
+// Run sends each compilation received from the queue to the driver's
+// Analyzer, analyzing up to p.Workers compilations concurrently.  Run returns
+// once the queue is exhausted and all analyses have completed, or after the
+// first error, in which case the context passed to the remaining analyses is
+// cancelled.
+func (p *ParallelDriver) Run(ctx context.Context, queue Queue) error {
+	if p.Analyzer == nil {
+		return errors.New("no analyzer has been specified")
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	tasks := make(chan *task)
+	var pending chan *task // tasks awaiting ordered delivery of their outputs
+	if p.Ordered {
+		pending = make(chan *task, p.workers())
+	}
+
+	// Read compilations from the queue and hand them off to the workers.
+	g.Go(func() error {
+		defer close(tasks)
+		if pending != nil {
+			defer close(pending)
+		}
+		for {
+			if err := queue.Next(ctx, func(ctx context.Context, cu Compilation) error {
+				t := &task{cu: cu, done: make(chan []*apb.AnalysisOutput, 1)}
+				if pending != nil {
+					select {
+					case pending <- t:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				select {
+				case tasks <- t:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}); err == ErrEndOfQueue {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+	})
+
+	var mu sync.Mutex // serializes calls to WriteOutput when unordered
+	for i := 0; i < p.workers(); i++ {
+		g.Go(func() error {
+			for t := range tasks {
+				if pending == nil {
+					if err := p.analyze(ctx, t.cu, func(ctx context.Context, out *apb.AnalysisOutput) error {
+						mu.Lock()
+						defer mu.Unlock()
+						return p.writeOutput(ctx, out)
+					}); err != nil {
+						return err
+					}
+					continue
+				}
+
+				var outs []*apb.AnalysisOutput
+				if err := p.analyze(ctx, t.cu, func(_ context.Context, out *apb.AnalysisOutput) error {
+					outs = append(outs, out)
+					return nil
+				}); err != nil {
+					return err
+				}
+				t.done <- outs
+			}
+			return nil
+		})
+	}
+
+	// Deliver buffered outputs in queue order.
+	if pending != nil {
+		g.Go(func() error {
+			for t := range pending {
+				select {
+				case outs := <-t.done:
+					for _, out := range outs {
+						if err := p.writeOutput(ctx, out); err != nil {
+							return err
+						}
+					}
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
@@ -19,10 +19,14 @@ package driver
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"kythe.io/kythe/go/platform/analysis"
 	"kythe.io/kythe/go/test/testutil"
@@ -293,6 +297,170 @@ func TestDriverTeardown(t *testing.T) {
 	}
 }
 
+func TestDriverRetryPolicy(t *testing.T) {
+	m := &mock{
+		t:            t,
+		Outputs:      outs("a"),
+		Compilations: comps("target1"),
+		AnalyzeError: errFromAnalysis,
+	}
+	var retries int
+	d := &Driver{
+		Analyzer:    m,
+		WriteOutput: m.out(),
+		Context: testContext{
+			analysisError: func(_ context.Context, _ Compilation, err error) error {
+				retries++
+				return ErrRetry
+			},
+		},
+		Retry: &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+	}
+	if err := d.Run(context.Background(), m); err == nil {
+		t.Error("Expected an error after exhausting retries")
+	} else if !strings.Contains(err.Error(), "failed after 3 attempts") {
+		t.Errorf("Run: unexpected error: %v", err)
+	}
+	if len(m.Requests) != 3 {
+		t.Errorf("Expected %d AnalysisRequests; found %d", 3, len(m.Requests))
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	r := &RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for retry, want := range []time.Duration{0, 1, 2, 4, 5, 5} {
+		if retry == 0 {
+			continue
+		}
+		if got := r.delay(retry); got != want*time.Second {
+			t.Errorf("delay(%d): got %v, want %v", retry, got, want*time.Second)
+		}
+	}
+}
+
+// blockingAnalyzer waits for its context to end.
+type blockingAnalyzer struct{}
+
+func (blockingAnalyzer) Analyze(ctx context.Context, _ *apb.AnalysisRequest, _ analysis.OutputFunc) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestDriverTimeout(t *testing.T) {
+	m := &mock{t: t, Compilations: comps("target1")}
+	d := &Driver{
+		Analyzer: blockingAnalyzer{},
+		Timeout:  10 * time.Millisecond,
+	}
+	if err := d.Run(context.Background(), m); err == nil {
+		t.Error("Expected a timeout error")
+	} else if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout error; found: %v", err)
+	}
+}
+
+func TestDriverFailureReport(t *testing.T) {
+	m := &mock{t: t, Compilations: comps("target1", "target2", "target3")}
+	d := &Driver{
+		Analyzer: echoAnalyzer{suffixes: []string{":a"}, fail: "target2"},
+		Failures: new(FailureReport),
+	}
+	testutil.FatalOnErrT(t, "Driver error: %v", d.Run(context.Background(), m))
+
+	fs := d.Failures.Failures()
+	if len(fs) != 1 {
+		t.Fatalf("Expected 1 failure; found %d: %v", len(fs), fs)
+	}
+	if got := fs[0]; got.UnitDigest != "digest:target2" || got.Error != errFromAnalysis.Error() || got.Attempts != 1 {
+		t.Errorf("Unexpected failure: %+v", got)
+	}
+
+	var buf bytes.Buffer
+	testutil.FatalOnErrT(t, "WriteJSON: %v", d.Failures.WriteJSON(&buf))
+	var report struct {
+		Failures []struct {
+			UnitDigest string `json:"unit_digest"`
+			Error      string `json:"error"`
+			Duration   string `json:"duration"`
+		} `json:"failures"`
+	}
+	testutil.FatalOnErrT(t, "Unmarshal: %v", json.Unmarshal(buf.Bytes(), &report))
+	if len(report.Failures) != 1 || report.Failures[0].UnitDigest != "digest:target2" || report.Failures[0].Duration == "" {
+		t.Errorf("Unexpected JSON report: %s", buf.String())
+	}
+}
+
+// echoAnalyzer is a concurrency-safe analyzer that emits one output for each
+// of its suffixes, prefixed by the signature of the compilation.
+type echoAnalyzer struct {
+	suffixes []string
+	fail     string // if set, analysis of this signature fails
+}
+
+// Analyze implements the analysis.CompilationAnalyzer interface.
+func (e echoAnalyzer) Analyze(ctx context.Context, req *apb.AnalysisRequest, out analysis.OutputFunc) error {
+	sig := req.Compilation.VName.Signature
+	if sig == e.fail {
+		return errFromAnalysis
+	}
+	// Make the earlier compilations finish last, to exercise ordering.
+	n, _ := strconv.Atoi(strings.TrimPrefix(sig, "target"))
+	time.Sleep(time.Duration(10-n) * time.Millisecond)
+	for _, suffix := range e.suffixes {
+		if err := out(ctx, &apb.AnalysisOutput{Value: []byte(sig + suffix)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestParallelDriver(t *testing.T) {
+	targets := []string{"target1", "target2", "target3", "target4", "target5"}
+	var want []string
+	for _, target := range targets {
+		want = append(want, target+":a", target+":b")
+	}
+
+	for _, ordered := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ordered=%v", ordered), func(t *testing.T) {
+			m := &mock{t: t, Compilations: comps(targets...)}
+			var got []string
+			d := &ParallelDriver{
+				Driver: Driver{
+					Analyzer: echoAnalyzer{suffixes: []string{":a", ":b"}},
+					WriteOutput: func(_ context.Context, out *apb.AnalysisOutput) error {
+						got = append(got, string(out.Value))
+						return nil
+					},
+				},
+				Workers: 3,
+				Ordered: ordered,
+			}
+			testutil.FatalOnErrT(t, "Driver error: %v", d.Run(context.Background(), m))
+			if !ordered {
+				sort.Strings(got)
+			}
+			if err := testutil.DeepEqual(want, got); err != nil {
+				t.Errorf("Outputs: %v", err)
+			}
+		})
+	}
+}
+
+func TestParallelDriverError(t *testing.T) {
+	m := &mock{t: t, Compilations: comps("target1", "target2", "target3")}
+	d := &ParallelDriver{
+		Driver: Driver{
+			Analyzer: echoAnalyzer{suffixes: []string{":a"}, fail: "target2"},
+		},
+		Workers: 2,
+		Ordered: true,
+	}
+	if err := d.Run(context.Background(), m); err != errFromAnalysis {
+		t.Errorf("Expected AnalysisError: %v; found: %v", errFromAnalysis, err)
+	}
+}
+
 func outs(vals ...string) (as []*apb.AnalysisOutput) {
 	for _, val := range vals {
 		as = append(as, &apb.AnalysisOutput{Value: []byte(val)})
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package driver
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// A Failure records a compilation whose analysis failed.
+type Failure struct {
+	UnitDigest string        // unit digest identifying the compilation
+	VName      *spb.VName    // the compilation's VName, if known
+	Error      string        // the final error reported for the analysis
+	Attempts   int           // the number of analysis attempts made
+	Duration   time.Duration // total time spent on the compilation
+}
+
+// failureJSON is the JSON encoding of a Failure.
+type failureJSON struct {
+	UnitDigest string     `json:"unit_digest,omitempty"`
+	VName      *spb.VName `json:"v_name,omitempty"`
+	Error      string     `json:"error"`
+	Attempts   int        `json:"attempts"`
+	Duration   string     `json:"duration"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.  The duration is
+// encoded as a string, e.g., "1.5s".
+func (f *Failure) MarshalJSON() ([]byte, error) {
+	return json.Marshal(failureJSON{
+		UnitDigest: f.UnitDigest,
+		VName:      f.VName,
+		Error:      f.Error,
+		Attempts:   f.Attempts,
+		Duration:   f.Duration.String(),
+	})
+}
+
+// A FailureReport collects the failed compilations of a Driver.  It is safe
+// for concurrent use by multiple goroutines.
+type FailureReport struct {
+	mu       sync.Mutex
+	failures []*Failure
+}
+
+// Add records f in the report.
+func (r *FailureReport) Add(f *Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, f)
+}
+
+// Len returns the number of failures recorded.
+func (r *FailureReport) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.failures)
+}
+
+// Failures returns a copy of the failures recorded, in the order they were
+// added.
+func (r *FailureReport) Failures() []*Failure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Failure(nil), r.failures...)
+}
+
+// WriteJSON writes a JSON summary of the report to w, of the form
+//
+//   {"failures": [{"unit_digest": "...", "error": "...", ...}, ...]}
+func (r *FailureReport) WriteJSON(w io.Writer) error {
+	fs := r.Failures()
+	if fs == nil {
+		fs = []*Failure{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Failures []*Failure `json:"failures"`
+	}{fs})
+}
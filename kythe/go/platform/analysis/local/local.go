@@ -24,6 +24,8 @@ import (
 	"io"
 	"log"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"kythe.io/kythe/go/platform/analysis"
 	"kythe.io/kythe/go/platform/analysis/driver"
@@ -140,3 +142,135 @@ type kzipFetcher struct{ r *kzip.Reader }
 
 // Fetch implements the required method of analysis.Fetcher.
 func (k kzipFetcher) Fetch(_, digest string) ([]byte, error) { return k.r.ReadAll(digest) }
+
+// NewKZipFetcher returns an analysis.Fetcher that reads file contents from r
+// by digest. The resulting Fetcher is safe for concurrent use.
+func NewKZipFetcher(r *kzip.Reader) analysis.Fetcher { return kzipFetcher{r} }
+
+// errQueueClosed is reported by Scan when a KZipQueue is closed before it has
+// delivered every compilation.
+var errQueueClosed = errors.New("queue closed")
+
+// A KZipQueue is a driver.Queue reading each compilation from a single kzip
+// archive, in the order reported by its Scan method.  A KZipQueue also
+// implements analysis.Fetcher over the files stored in the archive.
+//
+// Unlike a FileQueue, the compilations and Fetcher of a KZipQueue remain valid
+// after subsequent calls to Next, so a KZipQueue may be used with a
+// driver.ParallelDriver.
+type KZipQueue struct {
+	analysis.Fetcher
+
+	r        *kzip.Reader
+	revision string
+
+	units chan *kzip.Unit // compilations delivered by the scanner
+	done  chan struct{}   // closed when the queue is closed
+	err   error           // the error reported by Scan; valid after units is closed
+	once  sync.Once
+}
+
+// NewKZipQueue returns a new KZipQueue over the compilations in r.  The caller
+// must close the queue if it is not consumed to the end.
+func NewKZipQueue(r *kzip.Reader, opts *Options) *KZipQueue {
+	return &KZipQueue{
+		Fetcher:  kzipFetcher{r},
+		r:        r,
+		revision: opts.revision(),
+		done:     make(chan struct{}),
+	}
+}
+
+// start begins scanning the archive in a separate goroutine.
+func (q *KZipQueue) start() {
+	q.units = make(chan *kzip.Unit)
+	go func() {
+		defer close(q.units)
+		q.err = q.r.Scan(func(unit *kzip.Unit) error {
+			select {
+			case q.units <- unit:
+				return nil
+			case <-q.done:
+				return errQueueClosed
+			}
+		})
+	}()
+}
+
+// Next implements the driver.Queue interface.  If the queue was not given a
+// revision marker, the first revision recorded in the index of each
+// compilation is used.
+func (q *KZipQueue) Next(ctx context.Context, f driver.CompilationFunc) error {
+	if q.units == nil {
+		q.start()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case unit, ok := <-q.units:
+		if !ok {
+			if q.err != nil && q.err != errQueueClosed {
+				return fmt.Errorf("scanning kzip: %v", q.err)
+			}
+			return driver.ErrEndOfQueue
+		}
+		revision := q.revision
+		if revs := unit.Index.GetRevisions(); revision == "" && len(revs) != 0 {
+			revision = revs[0]
+		}
+		return f(ctx, driver.Compilation{
+			Unit:       unit.Proto,
+			Revision:   revision,
+			UnitDigest: unit.Digest,
+		})
+	}
+}
+
+// Close stops the scan of the archive.  It is safe to call Close multiple
+// times; it does not close the underlying archive.
+func (q *KZipQueue) Close() error {
+	q.once.Do(func() { close(q.done) })
+	return nil
+}
+
+// KZipOptions control the behaviour of AnalyzeKZip.
+type KZipOptions struct {
+	Options
+
+	// The number of compilations to analyze concurrently.  If Workers <= 0,
+	// runtime.NumCPU() workers are used.
+	Workers int
+
+	// If true, outputs are delivered in the order their compilations appear
+	// in the archive.
+	Ordered bool
+
+	// Retry policy, per-compilation timeout and failure report for the
+	// driver; see driver.Driver.
+	Retry    *driver.RetryPolicy
+	Timeout  time.Duration
+	Failures *driver.FailureReport
+}
+
+// AnalyzeKZip sends every compilation in r to analyzer, analyzing multiple
+// compilations concurrently, and passes each output to out.  The analyzer may
+// use NewKZipFetcher(r) to fetch the required inputs of each compilation.
+func AnalyzeKZip(ctx context.Context, r *kzip.Reader, analyzer analysis.CompilationAnalyzer, out analysis.OutputFunc, opts *KZipOptions) error {
+	if opts == nil {
+		opts = new(KZipOptions)
+	}
+	queue := NewKZipQueue(r, &opts.Options)
+	defer queue.Close()
+	d := &driver.ParallelDriver{
+		Driver: driver.Driver{
+			Analyzer:    analyzer,
+			WriteOutput: out,
+			Retry:       opts.Retry,
+			Timeout:     opts.Timeout,
+			Failures:    opts.Failures,
+		},
+		Workers: opts.Workers,
+		Ordered: opts.Ordered,
+	}
+	return d.Run(ctx, queue)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/analysis"
+	"kythe.io/kythe/go/platform/analysis/driver"
+	"kythe.io/kythe/go/platform/kzip"
+	"kythe.io/kythe/go/test/testutil"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// testKZip returns a reader for a kzip holding one compilation per signature,
+// each of which requires a single file whose contents are the signature.
+func testKZip(t *testing.T, sigs ...string) *kzip.Reader {
+	buf := bytes.NewBuffer(nil)
+	w, err := kzip.NewWriter(buf)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	for _, sig := range sigs {
+		digest, err := w.AddFile(strings.NewReader(sig))
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+		if _, err := w.AddUnit(&apb.CompilationUnit{
+			VName: &spb.VName{Signature: sig},
+			RequiredInput: []*apb.CompilationUnit_FileInput{{
+				Info: &apb.FileInfo{Path: sig, Digest: digest},
+			}},
+		}, &apb.IndexedCompilation_Index{
+			Revisions: []string{"rev-" + sig},
+		}); err != nil {
+			t.Fatalf("AddUnit: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	r, err := kzip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	return r
+}
+
+func TestKZipQueue(t *testing.T) {
+	ctx := context.Background()
+	q := NewKZipQueue(testKZip(t, "a", "b"), nil)
+	defer q.Close()
+
+	var got []string
+	for {
+		err := q.Next(ctx, func(_ context.Context, cu driver.Compilation) error {
+			sig := cu.Unit.VName.Signature
+			if cu.Revision != "rev-"+sig {
+				t.Errorf("Revision for %q: got %q, want %q", sig, cu.Revision, "rev-"+sig)
+			}
+			if cu.UnitDigest == "" {
+				t.Errorf("Missing unit digest for %q", sig)
+			}
+			ri := cu.Unit.RequiredInput[0].Info
+			data, err := q.Fetch(ri.Path, ri.Digest)
+			if err != nil {
+				return err
+			}
+			got = append(got, string(data))
+			return nil
+		})
+		if err == driver.ErrEndOfQueue {
+			break
+		}
+		testutil.FatalOnErrT(t, "Next: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Got %d compilations %q, want 2", len(got), got)
+	}
+}
+
+func TestKZipQueueClose(t *testing.T) {
+	q := NewKZipQueue(testKZip(t, "a", "b", "c"), nil)
+	if err := q.Next(context.Background(), func(context.Context, driver.Compilation) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	// Closing a partially-consumed queue must not block.
+	testutil.FatalOnErrT(t, "Close: %v", q.Close())
+	testutil.FatalOnErrT(t, "Close (again): %v", q.Close())
+}
+
+// fetchAnalyzer emits the contents of the required inputs of each compilation.
+type fetchAnalyzer struct{ f analysis.Fetcher }
+
+func (a fetchAnalyzer) Analyze(ctx context.Context, req *apb.AnalysisRequest, out analysis.OutputFunc) error {
+	for _, ri := range req.Compilation.RequiredInput {
+		data, err := a.f.Fetch(ri.Info.Path, ri.Info.Digest)
+		if err != nil {
+			return err
+		}
+		if err := out(ctx, &apb.AnalysisOutput{Value: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeKZip(t *testing.T) {
+	sigs := []string{"a", "b", "c", "d"}
+	r := testKZip(t, sigs...)
+
+	var got []string
+	if err := AnalyzeKZip(context.Background(), r, fetchAnalyzer{NewKZipFetcher(r)},
+		func(_ context.Context, out *apb.AnalysisOutput) error {
+			got = append(got, string(out.Value))
+			return nil
+		}, &KZipOptions{Workers: 2, Ordered: true}); err != nil {
+		t.Fatalf("AnalyzeKZip: %v", err)
+	}
+
+	// Outputs are ordered like the compilations in the archive.
+	var want []string
+	if err := r.Scan(func(unit *kzip.Unit) error {
+		want = append(want, unit.Proto.VName.Signature)
+		return nil
+	}); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if err := testutil.DeepEqual(want, got); err != nil {
+		t.Errorf("Outputs: %v", err)
+	}
+}
@@ -36,7 +36,7 @@ import (
 // hashing each and checking against a set of known record hashes.  This is a
 // quick-and-dirty method of removing duplicates; it will not be perfect.
 type Reader struct {
-	r *delimited.Reader
+	r delimited.Source
 	d *dedup.Deduper
 }
 
@@ -50,6 +50,16 @@ func NewReader(r io.Reader, maxSize int) (*Reader, error) {
 	return &Reader{delimited.NewReader(r), d}, nil
 }
 
+// NewSourceReader returns a reader that consumes records from src, using a
+// cache of up to maxSize bytes for known record hashes.
+func NewSourceReader(src delimited.Source, maxSize int) (*Reader, error) {
+	d, err := dedup.New(maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{src, d}, nil
+}
+
 // Next returns the next length-delimited record from the input, or io.EOF if
 // there are no more records available.  Returns io.ErrUnexpectedEOF if a short
 // record is found, with a length of n but fewer than n bytes of data.  Because
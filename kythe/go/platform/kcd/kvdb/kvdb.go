@@ -0,0 +1,560 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kvdb implements kcd.ReadWriter and kcd.Deleter on top of a
+// keyvalue.DB, suitable for a persistent, mutable compilation database.
+//
+// The database uses the following key layout, where "·" denotes a NUL byte:
+//
+//   rev:<corpus>·<revision>·<timestamp>    → ""            revision markers
+//   unit:<digest>                          → <key><data>   compilation records
+//   file:<digest>                          → <content>     file contents
+//   idx:<term>·<value>·<digest>            → ""            index terms
+//   uidx:<digest>·<term>·<value>           → ""            index terms by unit
+//
+// Revision markers are grouped by corpus, so filtering revisions by corpus is
+// a prefix scan.  Index terms are stored in both directions: the forward index
+// supports Find on exact values and literal prefixes of regular expressions,
+// and the reverse index supports checking the remaining filter terms of a
+// candidate and deleting a unit without scanning the whole index.
+package kvdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/storage/keyvalue"
+)
+
+// String tags for index terms matching the fields of a kcd.FindFilter.
+const (
+	RevisionKey = "revision"
+	CorpusKey   = "corpus"
+	OutputKey   = "output"
+	LanguageKey = "language"
+	TargetKey   = "target"
+	SourceKey   = "source"
+)
+
+const (
+	revPrefix       = "rev:"
+	unitPrefix      = "unit:"
+	filePrefix      = "file:"
+	indexPrefix     = "idx:"
+	unitIndexPrefix = "uidx:"
+
+	sep = "\x00" // separates the components of a key
+)
+
+// DB implements kcd.ReadWriter and kcd.Deleter using a keyvalue.DB.  It is
+// safe for concurrent use by multiple goroutines.
+type DB struct {
+	db keyvalue.DB
+
+	mu sync.Mutex // serializes read-modify-write operations
+}
+
+// New returns a compilation database stored in db.  The caller should not
+// write to db other than through the returned DB.
+func New(db keyvalue.DB) *DB { return &DB{db: db} }
+
+// Close closes the underlying keyvalue.DB.
+func (db *DB) Close() error { return db.db.Close() }
+
+// Revisions implements a method of kcd.Reader.
+func (db *DB) Revisions(_ context.Context, want *kcd.RevisionsFilter, f func(kcd.Revision) error) error {
+	revisionMatches, err := want.Compile()
+	if err != nil {
+		return err
+	}
+	prefix := revPrefix
+	if want != nil && want.Corpus != "" {
+		prefix += want.Corpus + sep
+	}
+	return db.scan(prefix, func(key, _ []byte) error {
+		rev, err := decodeRevision(key)
+		if err != nil {
+			return err
+		} else if revisionMatches(rev) {
+			return f(rev)
+		}
+		return nil
+	})
+}
+
+// Find implements a method of kcd.Reader.  If any term of the filter can be
+// answered from the forward index, only the compilations it selects are
+// examined; otherwise every compilation is examined.
+func (db *DB) Find(_ context.Context, filter *kcd.FindFilter, f func(string) error) error {
+	cf, err := filter.Compile()
+	if err != nil {
+		return err
+	} else if cf == nil {
+		return nil
+	}
+
+	matches := func(idx map[string][]string) bool {
+		return cf.RevisionMatches(idx[RevisionKey]...) &&
+			cf.CorpusMatches(idx[CorpusKey]...) &&
+			cf.LanguageMatches(idx[LanguageKey]...) &&
+			cf.TargetMatches(idx[TargetKey]...) &&
+			cf.OutputMatches(idx[OutputKey]...) &&
+			cf.SourcesMatch(idx[SourceKey]...)
+	}
+
+	term, prefixes := indexPrefixes(filter)
+	if term == "" {
+		// No term is usable as an index; examine each compilation in turn.
+		var cur string
+		var idx map[string][]string
+		flush := func() error {
+			if cur != "" && matches(idx) {
+				return f(cur)
+			}
+			return nil
+		}
+		if err := db.scan(unitIndexPrefix, func(key, _ []byte) error {
+			parts := strings.SplitN(string(key[len(unitIndexPrefix):]), sep, 3)
+			if len(parts) != 3 {
+				return fmt.Errorf("invalid unit index key %q", key)
+			}
+			if parts[0] != cur {
+				if err := flush(); err != nil {
+					return err
+				}
+				cur, idx = parts[0], make(map[string][]string)
+			}
+			idx[parts[1]] = append(idx[parts[1]], parts[2])
+			return nil
+		}); err != nil {
+			return err
+		}
+		return flush()
+	}
+
+	// Gather candidate digests from the forward index for the chosen term.
+	candidates := make(map[string]bool)
+	for _, prefix := range prefixes {
+		if err := db.scan(indexPrefix+term+sep+prefix, func(key, _ []byte) error {
+			if i := bytes.LastIndex(key, []byte(sep)); i >= 0 {
+				candidates[string(key[i+1:])] = true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	digests := make([]string, 0, len(candidates))
+	for digest := range candidates {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+
+	for _, digest := range digests {
+		idx, err := db.unitIndex(digest)
+		if err != nil {
+			return err
+		} else if matches(idx) {
+			if err := f(digest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexPrefixes selects a term of filter that can be answered by the forward
+// index, returning the name of the term and the value prefixes to scan.  It
+// returns "" if no term is usable.  Terms are preferred in order of their
+// expected selectivity.
+func indexPrefixes(filter *kcd.FindFilter) (string, []string) {
+	if ps := regexpPrefixes(filter.Outputs); ps != nil {
+		return OutputKey, ps
+	} else if ps := regexpPrefixes(filter.Targets); ps != nil {
+		return TargetKey, ps
+	} else if ps := regexpPrefixes(filter.Sources); ps != nil {
+		return SourceKey, ps
+	} else if len(filter.Revisions) != 0 {
+		return RevisionKey, exactPrefixes(filter.Revisions)
+	} else if len(filter.Corpus) != 0 {
+		return CorpusKey, exactPrefixes(filter.Corpus)
+	} else if len(filter.Languages) != 0 {
+		return LanguageKey, exactPrefixes(filter.Languages)
+	}
+	return "", nil
+}
+
+// exactPrefixes returns index value prefixes matching exactly the given values.
+func exactPrefixes(values []string) []string {
+	ps := make([]string, len(values))
+	for i, v := range values {
+		ps[i] = v + sep
+	}
+	return ps
+}
+
+// regexpPrefixes returns index value prefixes covering every value matched by
+// the given (implicitly anchored) expressions, or nil if any of them lacks a
+// literal prefix.
+func regexpPrefixes(res []*regexp.Regexp) []string {
+	var ps []string
+	for _, re := range res {
+		// Filter expressions are implicitly anchored at both ends.
+		anchored, err := regexp.Compile(`^(?:` + re.String() + `)$`)
+		if err != nil {
+			return nil
+		}
+		prefix, complete := anchored.LiteralPrefix()
+		if complete {
+			prefix += sep
+		} else if prefix == "" {
+			return nil
+		}
+		ps = append(ps, prefix)
+	}
+	return ps
+}
+
+// unitIndex returns the index terms recorded for the given unit digest.
+func (db *DB) unitIndex(digest string) (map[string][]string, error) {
+	prefix := unitIndexPrefix + digest + sep
+	idx := make(map[string][]string)
+	err := db.scan(prefix, func(key, _ []byte) error {
+		parts := strings.SplitN(string(key[len(prefix):]), sep, 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid unit index key %q", key)
+		}
+		idx[parts[0]] = append(idx[parts[0]], parts[1])
+		return nil
+	})
+	return idx, err
+}
+
+// Units implements a method of kcd.Reader.
+func (db *DB) Units(_ context.Context, unitDigests []string, f func(digest, key string, data []byte) error) error {
+	for _, ud := range unitDigests {
+		val, err := db.get(unitPrefix + ud)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		key, data, err := decodeUnit(val)
+		if err != nil {
+			return fmt.Errorf("invalid unit %q: %v", ud, err)
+		}
+		if err := f(ud, key, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files implements a method of kcd.Reader.
+func (db *DB) Files(_ context.Context, fileDigests []string, f func(string, []byte) error) error {
+	for _, fd := range fileDigests {
+		data, err := db.get(filePrefix + fd)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := f(fd, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesExist implements a method of kcd.Reader.
+func (db *DB) FilesExist(_ context.Context, fileDigests []string, f func(string) error) error {
+	for _, fd := range fileDigests {
+		if _, err := db.get(filePrefix + fd); err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := f(fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRevision implements a method of kcd.Writer.
+func (db *DB) WriteRevision(_ context.Context, rev kcd.Revision, replace bool) error {
+	if rev.Revision == "" {
+		return errors.New("missing revision marker")
+	} else if rev.Corpus == "" {
+		return errors.New("missing corpus label")
+	} else if strings.Contains(rev.Revision, sep) || strings.Contains(rev.Corpus, sep) {
+		return fmt.Errorf("invalid revision %v", rev)
+	}
+	if rev.Timestamp.IsZero() {
+		rev.Timestamp = time.Now()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.write(func(wr keyvalue.Writer) error {
+		if replace {
+			if err := db.deleteRevision(wr, rev.Revision, rev.Corpus); err != nil {
+				return err
+			}
+		}
+		return wr.Write(encodeRevision(rev), nil)
+	})
+}
+
+// WriteUnit implements a method of kcd.Writer.  On success, the returned
+// digest is the kcd.UnitDigest of the canonicalized unit.
+func (db *DB) WriteUnit(_ context.Context, revision, corpus, formatKey string, unit kcd.Unit) (string, error) {
+	if revision == "" {
+		return "", errors.New("empty revision marker")
+	}
+	unit.Canonicalize()
+	bits, err := unit.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	digest := kcd.UnitDigest(unit)
+
+	terms := map[string][]string{RevisionKey: {revision}}
+	if corpus != "" {
+		terms[CorpusKey] = []string{corpus}
+	}
+	idx := unit.Index()
+	if idx.Language != "" {
+		terms[LanguageKey] = []string{idx.Language}
+	}
+	if idx.Output != "" {
+		terms[OutputKey] = []string{idx.Output}
+	}
+	if idx.Target != "" {
+		terms[TargetKey] = []string{idx.Target}
+	}
+	terms[SourceKey] = idx.Sources
+	for key, vals := range terms {
+		for _, val := range vals {
+			if strings.Contains(val, sep) {
+				return "", fmt.Errorf("invalid %s index term %q", key, val)
+			}
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.write(func(wr keyvalue.Writer) error {
+		if err := wr.Write([]byte(unitPrefix+digest), encodeUnit(formatKey, bits)); err != nil {
+			return err
+		}
+		for key, vals := range terms {
+			for _, val := range vals {
+				if err := wr.Write([]byte(indexPrefix+key+sep+val+sep+digest), nil); err != nil {
+					return err
+				}
+				if err := wr.Write([]byte(unitIndexPrefix+digest+sep+key+sep+val), nil); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// WriteFile implements a method of kcd.Writer.
+func (db *DB) WriteFile(_ context.Context, r io.Reader) (string, error) {
+	bits, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	digest := kcd.HexDigest(bits)
+	if err := db.write(func(wr keyvalue.Writer) error {
+		return wr.Write([]byte(filePrefix+digest), bits)
+	}); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// DeleteUnit implements a method of kcd.Deleter.
+func (db *DB) DeleteUnit(_ context.Context, unitDigest string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, err := db.get(unitPrefix + unitDigest); err == io.EOF {
+		return os.ErrNotExist
+	} else if err != nil {
+		return err
+	}
+	idx, err := db.unitIndex(unitDigest)
+	if err != nil {
+		return err
+	}
+	return db.write(func(wr keyvalue.Writer) error {
+		for key, vals := range idx {
+			for _, val := range vals {
+				if err := wr.Delete([]byte(indexPrefix + key + sep + val + sep + unitDigest)); err != nil {
+					return err
+				}
+				if err := wr.Delete([]byte(unitIndexPrefix + unitDigest + sep + key + sep + val)); err != nil {
+					return err
+				}
+			}
+		}
+		return wr.Delete([]byte(unitPrefix + unitDigest))
+	})
+}
+
+// DeleteFile implements a method of kcd.Deleter.
+func (db *DB) DeleteFile(_ context.Context, fileDigest string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, err := db.get(filePrefix + fileDigest); err == io.EOF {
+		return os.ErrNotExist
+	} else if err != nil {
+		return err
+	}
+	return db.write(func(wr keyvalue.Writer) error {
+		return wr.Delete([]byte(filePrefix + fileDigest))
+	})
+}
+
+// DeleteRevision implements a method of kcd.Deleter.
+func (db *DB) DeleteRevision(_ context.Context, revision, corpus string) error {
+	rev := kcd.Revision{Revision: revision, Corpus: corpus}
+	if err := rev.IsValid(); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	found := false
+	if err := db.scan(revPrefix+corpus+sep+revision+sep, func([]byte, []byte) error {
+		found = true
+		return io.EOF // one is enough
+	}); err != nil {
+		return err
+	} else if !found {
+		return os.ErrNotExist
+	}
+	return db.write(func(wr keyvalue.Writer) error {
+		return db.deleteRevision(wr, revision, corpus)
+	})
+}
+
+// deleteRevision deletes all timestamps of the given revision marker using wr.
+func (db *DB) deleteRevision(wr keyvalue.Writer, revision, corpus string) error {
+	return db.scan(revPrefix+corpus+sep+revision+sep, func(key, _ []byte) error {
+		return wr.Delete(key)
+	})
+}
+
+// get returns the value of key, or io.EOF if key is not in the database.
+func (db *DB) get(key string) ([]byte, error) { return db.db.Get([]byte(key), nil) }
+
+// scan calls f with each key-value pair whose key has the given prefix.  If f
+// returns io.EOF, the scan stops without error.
+func (db *DB) scan(prefix string, f func(key, val []byte) error) error {
+	iter, err := db.db.ScanPrefix([]byte(prefix), nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for {
+		key, val, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(key, val); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// write calls f with a new writer, and closes the writer to commit its writes.
+func (db *DB) write(f func(keyvalue.Writer) error) error {
+	wr, err := db.db.Writer()
+	if err != nil {
+		return err
+	}
+	if err := f(wr); err != nil {
+		wr.Close()
+		return err
+	}
+	return wr.Close()
+}
+
+// encodeRevision returns the key for the given revision marker.  Timestamps
+// are encoded so that their keys sort chronologically.
+func encodeRevision(rev kcd.Revision) []byte {
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(rev.Timestamp.UnixNano())^(1<<63))
+	return append([]byte(revPrefix+rev.Corpus+sep+rev.Revision+sep), ts[:]...)
+}
+
+// decodeRevision decodes a key written by encodeRevision.
+func decodeRevision(key []byte) (kcd.Revision, error) {
+	rest := key[len(revPrefix):]
+	if len(rest) < 8+2 {
+		return kcd.Revision{}, fmt.Errorf("invalid revision key %q", key)
+	}
+	ts := int64(binary.BigEndian.Uint64(rest[len(rest)-8:]) ^ (1 << 63))
+	parts := strings.SplitN(string(rest[:len(rest)-8]), sep, 3)
+	if len(parts) != 3 || parts[2] != "" {
+		return kcd.Revision{}, fmt.Errorf("invalid revision key %q", key)
+	}
+	return kcd.Revision{
+		Corpus:    parts[0],
+		Revision:  parts[1],
+		Timestamp: time.Unix(0, ts).In(time.UTC),
+	}, nil
+}
+
+// encodeUnit encodes a unit's format key and data as a length-prefixed key
+// followed by the data.
+func encodeUnit(formatKey string, data []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(formatKey)+len(data))
+	n := binary.PutUvarint(buf, uint64(len(formatKey)))
+	return append(append(buf[:n], formatKey...), data...)
+}
+
+// decodeUnit decodes a value written by encodeUnit.
+func decodeUnit(val []byte) (string, []byte, error) {
+	n, w := binary.Uvarint(val)
+	if w <= 0 || uint64(len(val)-w) < n {
+		return "", nil, errors.New("malformed unit record")
+	}
+	return string(val[w : w+int(n)]), val[w+int(n):], nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kvdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/testutil"
+	"kythe.io/kythe/go/storage/leveldb"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func tempDB(t *testing.T) (*DB, func()) {
+	path, err := ioutil.TempDir("", "kvdb")
+	if err != nil {
+		t.Fatalf("Creating temp directory: %v", err)
+	}
+	db, err := leveldb.Open(path, nil)
+	if err != nil {
+		os.RemoveAll(path)
+		t.Fatalf("Opening LevelDB: %v", err)
+	}
+	kdb := New(db)
+	return kdb, func() {
+		kdb.Close()
+		os.RemoveAll(path)
+	}
+}
+
+func TestKVDB(t *testing.T) {
+	db, cleanup := tempDB(t)
+	defer cleanup()
+	for _, err := range testutil.Run(context.Background(), db) {
+		t.Error(err)
+	}
+}
+
+func TestFindIndex(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := tempDB(t)
+	defer cleanup()
+
+	digests := make(map[string]string) // :: output → digest
+	for _, output := range []string{"a.o", "ab.o", "b.o"} {
+		digest, err := db.WriteUnit(ctx, "rev", "corpus", kythe.Format, kythe.Unit{Proto: &apb.CompilationUnit{
+			VName:      &spb.VName{Signature: "//target:" + output, Language: "c++"},
+			SourceFile: []string{output + ".cc"},
+			OutputKey:  output,
+		}})
+		if err != nil {
+			t.Fatalf("WriteUnit: %v", err)
+		}
+		digests[output] = digest
+	}
+
+	tests := []struct {
+		filter *kcd.FindFilter
+		want   []string
+	}{
+		{&kcd.FindFilter{Outputs: []*regexp.Regexp{regexp.MustCompile(`a\.o`)}}, []string{"a.o"}},
+		{&kcd.FindFilter{Outputs: []*regexp.Regexp{regexp.MustCompile(`a.*`)}}, []string{"a.o", "ab.o"}},
+		{&kcd.FindFilter{Outputs: []*regexp.Regexp{regexp.MustCompile(`.*b.*`)}}, []string{"ab.o", "b.o"}},
+		{&kcd.FindFilter{Targets: []*regexp.Regexp{regexp.MustCompile(`//target:b.*`)}}, []string{"b.o"}},
+		{&kcd.FindFilter{
+			Revisions: []string{"rev"},
+			Sources:   []*regexp.Regexp{regexp.MustCompile(`a.*\.cc`)},
+		}, []string{"a.o", "ab.o"}},
+		{&kcd.FindFilter{Languages: []string{"c++"}, Outputs: []*regexp.Regexp{regexp.MustCompile(`b.o`)}}, []string{"b.o"}},
+		{&kcd.FindFilter{Languages: []string{"go"}}, nil},
+		{&kcd.FindFilter{Corpus: []string{"corpus"}}, []string{"a.o", "ab.o", "b.o"}},
+	}
+	for _, test := range tests {
+		got := make(map[string]bool)
+		if err := db.Find(ctx, test.filter, func(digest string) error {
+			got[digest] = true
+			return nil
+		}); err != nil {
+			t.Errorf("Find %+v: %v", test.filter, err)
+			continue
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("Find %+v: got %d results, want %d", test.filter, len(got), len(test.want))
+		}
+		for _, output := range test.want {
+			if !got[digests[output]] {
+				t.Errorf("Find %+v: missing result for %q", test.filter, output)
+			}
+		}
+	}
+
+	// Deleting a unit removes it from the index.
+	if err := db.DeleteUnit(ctx, digests["a.o"]); err != nil {
+		t.Fatalf("DeleteUnit: %v", err)
+	}
+	if err := db.Find(ctx, &kcd.FindFilter{Corpus: []string{"corpus"}}, func(digest string) error {
+		if digest == digests["a.o"] {
+			t.Errorf("Find returned deleted unit %q", digest)
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("Find: %v", err)
+	}
+	if idx, err := db.unitIndex(digests["a.o"]); err != nil {
+		t.Errorf("unitIndex: %v", err)
+	} else if len(idx) != 0 {
+		t.Errorf("Deleted unit still has index terms: %v", idx)
+	}
+}
@@ -0,0 +1,520 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webdb exposes a kcd.ReadWriter as a JSON HTTP service, and provides
+// a client implementation of kcd.ReadWriter for that service.  This allows a
+// single compilation database to be shared by extractors that upload to it
+// and indexers that read from it.
+package webdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/services/web"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+)
+
+// Method paths, relative to the root of the service.
+const (
+	revisionsPath     = "/kcd/revisions"
+	findPath          = "/kcd/find"
+	unitsPath         = "/kcd/units"
+	filesPath         = "/kcd/files"
+	filesExistPath    = "/kcd/files_exist"
+	writeRevisionPath = "/kcd/write_revision"
+	writeUnitPath     = "/kcd/write_unit"
+	writeFilePath     = "/kcd/write_file"
+)
+
+// RevisionsRequest is the JSON encoding of a kcd.RevisionsFilter.
+type RevisionsRequest struct {
+	Revision string    `json:"revision,omitempty"`
+	Corpus   string    `json:"corpus,omitempty"`
+	Until    time.Time `json:"until"`
+	Since    time.Time `json:"since"`
+}
+
+// Revision is the JSON encoding of a kcd.Revision.
+type Revision struct {
+	Revision  string    `json:"revision"`
+	Corpus    string    `json:"corpus"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// RevisionsReply is the response to a RevisionsRequest.
+type RevisionsReply struct {
+	Revisions []*Revision `json:"revisions,omitempty"`
+}
+
+// FindRequest is the JSON encoding of a kcd.FindFilter.  Targets, sources,
+// and outputs are RE2 regular expressions.
+type FindRequest struct {
+	Revisions []string `json:"revisions,omitempty"`
+	Languages []string `json:"languages,omitempty"`
+	Corpus    []string `json:"corpus,omitempty"`
+	Targets   []string `json:"targets,omitempty"`
+	Sources   []string `json:"sources,omitempty"`
+	Outputs   []string `json:"outputs,omitempty"`
+}
+
+// DigestsMessage carries a list of unit or file digests.  It is used as the
+// request for the units, files, and files_exist methods, and as the response
+// to the find and files_exist methods.
+type DigestsMessage struct {
+	Digests []string `json:"digests,omitempty"`
+}
+
+// Unit is a stored compilation record.
+type Unit struct {
+	Digest    string `json:"digest"`
+	FormatKey string `json:"format_key"`
+	Data      []byte `json:"data"`
+}
+
+// UnitsReply is the response to a units request.
+type UnitsReply struct {
+	Units []*Unit `json:"units,omitempty"`
+}
+
+// File is a stored file.
+type File struct {
+	Digest string `json:"digest"`
+	Data   []byte `json:"data"`
+}
+
+// FilesReply is the response to a files request.
+type FilesReply struct {
+	Files []*File `json:"files,omitempty"`
+}
+
+// WriteRevisionRequest is the request for the write_revision method.
+type WriteRevisionRequest struct {
+	Revision
+	Replace bool `json:"replace,omitempty"`
+}
+
+// WriteUnitRequest is the request for the write_unit method.  Data is the
+// binary encoding of a kythe.proto.CompilationUnit; the service decodes it to
+// compute the unit's index terms and digest itself.
+type WriteUnitRequest struct {
+	Revision  string `json:"revision"`
+	Corpus    string `json:"corpus,omitempty"`
+	FormatKey string `json:"format_key"`
+	Data      []byte `json:"data"`
+}
+
+// DigestReply is the response to the write_unit and write_file methods.
+type DigestReply struct {
+	Digest string `json:"digest"`
+}
+
+// RegisterHTTPHandlers registers JSON HTTP handlers with mux using the given
+// compilation database.  The following methods will be exposed:
+//
+//   POST /kcd/revisions
+//     Request: JSON encoded RevisionsRequest
+//     Response: JSON encoded RevisionsReply
+//   POST /kcd/find
+//     Request: JSON encoded FindRequest
+//     Response: JSON encoded DigestsMessage
+//   POST /kcd/units
+//     Request: JSON encoded DigestsMessage
+//     Response: JSON encoded UnitsReply
+//   POST /kcd/files
+//     Request: JSON encoded DigestsMessage
+//     Response: JSON encoded FilesReply
+//   POST /kcd/files_exist
+//     Request: JSON encoded DigestsMessage
+//     Response: JSON encoded DigestsMessage
+//   POST /kcd/write_revision
+//     Request: JSON encoded WriteRevisionRequest
+//     Response: empty JSON object
+//   POST /kcd/write_unit
+//     Request: JSON encoded WriteUnitRequest
+//     Response: JSON encoded DigestReply
+//   POST /kcd/write_file
+//     Request: JSON encoded File (the digest is ignored)
+//     Response: JSON encoded DigestReply
+func RegisterHTTPHandlers(ctx context.Context, db kcd.ReadWriter, mux *http.ServeMux) {
+	mux.HandleFunc(revisionsPath, handler("kcd.Revisions", func(r *http.Request) (interface{}, error) {
+		var req RevisionsRequest
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		var reply RevisionsReply
+		err := db.Revisions(ctx, &kcd.RevisionsFilter{
+			Revision: req.Revision,
+			Corpus:   req.Corpus,
+			Until:    req.Until,
+			Since:    req.Since,
+		}, func(rev kcd.Revision) error {
+			reply.Revisions = append(reply.Revisions, &Revision{
+				Revision:  rev.Revision,
+				Corpus:    rev.Corpus,
+				Timestamp: rev.Timestamp,
+			})
+			return nil
+		})
+		return &reply, err
+	}))
+	mux.HandleFunc(findPath, handler("kcd.Find", func(r *http.Request) (interface{}, error) {
+		var req FindRequest
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		filter, err := req.filter()
+		if err != nil {
+			return nil, badRequest{err}
+		}
+		var reply DigestsMessage
+		err = db.Find(ctx, filter, func(digest string) error {
+			reply.Digests = append(reply.Digests, digest)
+			return nil
+		})
+		return &reply, err
+	}))
+	mux.HandleFunc(unitsPath, handler("kcd.Units", func(r *http.Request) (interface{}, error) {
+		var req DigestsMessage
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		var reply UnitsReply
+		err := db.Units(ctx, req.Digests, func(digest, key string, data []byte) error {
+			reply.Units = append(reply.Units, &Unit{Digest: digest, FormatKey: key, Data: data})
+			return nil
+		})
+		return &reply, err
+	}))
+	mux.HandleFunc(filesPath, handler("kcd.Files", func(r *http.Request) (interface{}, error) {
+		var req DigestsMessage
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		var reply FilesReply
+		err := db.Files(ctx, req.Digests, func(digest string, data []byte) error {
+			reply.Files = append(reply.Files, &File{Digest: digest, Data: data})
+			return nil
+		})
+		return &reply, err
+	}))
+	mux.HandleFunc(filesExistPath, handler("kcd.FilesExist", func(r *http.Request) (interface{}, error) {
+		var req DigestsMessage
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		var reply DigestsMessage
+		err := db.FilesExist(ctx, req.Digests, func(digest string) error {
+			reply.Digests = append(reply.Digests, digest)
+			return nil
+		})
+		return &reply, err
+	}))
+	mux.HandleFunc(writeRevisionPath, handler("kcd.WriteRevision", func(r *http.Request) (interface{}, error) {
+		var req WriteRevisionRequest
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		err := db.WriteRevision(ctx, kcd.Revision{
+			Revision:  req.Revision.Revision,
+			Corpus:    req.Corpus,
+			Timestamp: req.Timestamp,
+		}, req.Replace)
+		return struct{}{}, err
+	}))
+	mux.HandleFunc(writeUnitPath, handler("kcd.WriteUnit", func(r *http.Request) (interface{}, error) {
+		var req WriteUnitRequest
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		var unit apb.CompilationUnit
+		if err := proto.Unmarshal(req.Data, &unit); err != nil {
+			return nil, badRequest{fmt.Errorf("invalid compilation unit: %v", err)}
+		}
+		digest, err := db.WriteUnit(ctx, req.Revision, req.Corpus, req.FormatKey, kythe.Unit{Proto: &unit})
+		return &DigestReply{Digest: digest}, err
+	}))
+	mux.HandleFunc(writeFilePath, handler("kcd.WriteFile", func(r *http.Request) (interface{}, error) {
+		var req File
+		if err := readJSONBody(r, &req); err != nil {
+			return nil, err
+		}
+		digest, err := db.WriteFile(ctx, bytes.NewReader(req.Data))
+		return &DigestReply{Digest: digest}, err
+	}))
+}
+
+// badRequest marks an error caused by an invalid request.
+type badRequest struct{ error }
+
+// handler returns an HTTP handler that invokes call and writes its result to
+// the response as JSON.
+func handler(name string, call func(*http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("%s:\t%s", name, time.Since(start))
+		}()
+
+		reply, err := call(r)
+		if _, ok := err.(badRequest); ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := web.WriteJSONResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// readJSONBody unmarshals the JSON body of r into v.  An empty body leaves v
+// unchanged.
+func readJSONBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		return badRequest{fmt.Errorf("invalid request: %v", err)}
+	}
+	return nil
+}
+
+// filter converts a FindRequest to a kcd.FindFilter.
+func (req *FindRequest) filter() (*kcd.FindFilter, error) {
+	compile := func(exprs []string) ([]*regexp.Regexp, error) {
+		var res []*regexp.Regexp
+		for _, expr := range exprs {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, re)
+		}
+		return res, nil
+	}
+	filter := &kcd.FindFilter{
+		Revisions: req.Revisions,
+		Languages: req.Languages,
+		Corpus:    req.Corpus,
+	}
+	var err error
+	if filter.Targets, err = compile(req.Targets); err != nil {
+		return nil, err
+	} else if filter.Sources, err = compile(req.Sources); err != nil {
+		return nil, err
+	} else if filter.Outputs, err = compile(req.Outputs); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// WebClient returns a kcd.ReadWriter that forwards calls to the service at
+// addr, as exported by RegisterHTTPHandlers.  Only Kythe compilation units
+// (kythe.Unit) may be written through the client.
+func WebClient(addr string) kcd.ReadWriter {
+	return &webClient{addr: strings.TrimSuffix(addr, "/")}
+}
+
+type webClient struct{ addr string }
+
+// Revisions implements a method of kcd.Reader.
+func (c *webClient) Revisions(ctx context.Context, filter *kcd.RevisionsFilter, f func(kcd.Revision) error) error {
+	var req RevisionsRequest
+	if filter != nil {
+		req = RevisionsRequest{
+			Revision: filter.Revision,
+			Corpus:   filter.Corpus,
+			Until:    filter.Until,
+			Since:    filter.Since,
+		}
+	}
+	var reply RevisionsReply
+	if err := c.call(ctx, revisionsPath, &req, &reply); err != nil {
+		return err
+	}
+	for _, rev := range reply.Revisions {
+		if err := f(kcd.Revision{
+			Revision:  rev.Revision,
+			Corpus:    rev.Corpus,
+			Timestamp: rev.Timestamp.In(time.UTC),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find implements a method of kcd.Reader.
+func (c *webClient) Find(ctx context.Context, filter *kcd.FindFilter, f func(string) error) error {
+	if filter.IsEmpty() {
+		return nil
+	}
+	exprs := func(res []*regexp.Regexp) []string {
+		var ss []string
+		for _, re := range res {
+			ss = append(ss, re.String())
+		}
+		return ss
+	}
+	var reply DigestsMessage
+	if err := c.call(ctx, findPath, &FindRequest{
+		Revisions: filter.Revisions,
+		Languages: filter.Languages,
+		Corpus:    filter.Corpus,
+		Targets:   exprs(filter.Targets),
+		Sources:   exprs(filter.Sources),
+		Outputs:   exprs(filter.Outputs),
+	}, &reply); err != nil {
+		return err
+	}
+	return forEach(reply.Digests, f)
+}
+
+// Units implements a method of kcd.Reader.
+func (c *webClient) Units(ctx context.Context, unitDigests []string, f func(digest, key string, data []byte) error) error {
+	var reply UnitsReply
+	if err := c.call(ctx, unitsPath, &DigestsMessage{Digests: unitDigests}, &reply); err != nil {
+		return err
+	}
+	for _, u := range reply.Units {
+		if err := f(u.Digest, u.FormatKey, u.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files implements a method of kcd.Reader.
+func (c *webClient) Files(ctx context.Context, fileDigests []string, f func(string, []byte) error) error {
+	var reply FilesReply
+	if err := c.call(ctx, filesPath, &DigestsMessage{Digests: fileDigests}, &reply); err != nil {
+		return err
+	}
+	for _, file := range reply.Files {
+		if err := f(file.Digest, file.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesExist implements a method of kcd.Reader.
+func (c *webClient) FilesExist(ctx context.Context, fileDigests []string, f func(string) error) error {
+	var reply DigestsMessage
+	if err := c.call(ctx, filesExistPath, &DigestsMessage{Digests: fileDigests}, &reply); err != nil {
+		return err
+	}
+	return forEach(reply.Digests, f)
+}
+
+// WriteRevision implements a method of kcd.Writer.
+func (c *webClient) WriteRevision(ctx context.Context, rev kcd.Revision, replace bool) error {
+	return c.call(ctx, writeRevisionPath, &WriteRevisionRequest{
+		Revision: Revision{
+			Revision:  rev.Revision,
+			Corpus:    rev.Corpus,
+			Timestamp: rev.Timestamp,
+		},
+		Replace: replace,
+	}, &struct{}{})
+}
+
+// WriteUnit implements a method of kcd.Writer.
+func (c *webClient) WriteUnit(ctx context.Context, revision, corpus, formatKey string, unit kcd.Unit) (string, error) {
+	if _, ok := unit.(kythe.Unit); !ok {
+		return "", fmt.Errorf("webdb: unsupported unit type %T", unit)
+	}
+	data, err := unit.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	var reply DigestReply
+	if err := c.call(ctx, writeUnitPath, &WriteUnitRequest{
+		Revision:  revision,
+		Corpus:    corpus,
+		FormatKey: formatKey,
+		Data:      data,
+	}, &reply); err != nil {
+		return "", err
+	}
+	return reply.Digest, nil
+}
+
+// WriteFile implements a method of kcd.Writer.
+func (c *webClient) WriteFile(ctx context.Context, r io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	var reply DigestReply
+	if err := c.call(ctx, writeFilePath, &File{Data: data}, &reply); err != nil {
+		return "", err
+	}
+	return reply.Digest, nil
+}
+
+// call sends req to the given method as a JSON-encoded body and unmarshals the
+// response body as JSON into reply.  The call is abandoned if ctx ends before
+// it completes.
+func (c *webClient) call(ctx context.Context, method string, req, reply interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error marshaling %T: %v", req, err)
+	}
+	hreq, err := http.NewRequest("POST", c.addr+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http error: %v", err)
+	}
+	hreq.Header.Set("Content-Type", "application/json; charset=utf-8")
+	resp, err := http.DefaultClient.Do(hreq.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("http error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("remote method error (code %d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(reply); err != nil {
+		return fmt.Errorf("error unmarshaling %T: %v", reply, err)
+	}
+	return nil
+}
+
+// forEach calls f with each of the given values, stopping at the first error.
+func forEach(values []string, f func(string) error) error {
+	for _, v := range values {
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/memdb"
+	"kythe.io/kythe/go/platform/kcd/testutil"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func newServer(ctx context.Context, db kcd.ReadWriter) *httptest.Server {
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(ctx, db, mux)
+	return httptest.NewServer(mux)
+}
+
+func TestWebDB(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(ctx, new(memdb.DB))
+	defer srv.Close()
+
+	for _, err := range testutil.Run(ctx, WebClient(srv.URL)) {
+		t.Error(err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(ctx, new(memdb.DB))
+	defer srv.Close()
+	db := WebClient(srv.URL)
+
+	// Errors from the underlying database are reported by the client.
+	if _, err := db.WriteUnit(ctx, "", "corpus", "format", kythe.Unit{Proto: new(apb.CompilationUnit)}); err == nil {
+		t.Error("WriteUnit: got nil error for empty revision")
+	} else if !strings.Contains(err.Error(), "empty revision marker") {
+		t.Errorf("WriteUnit: got error %v, want the database error", err)
+	}
+
+	// Only Kythe compilation units can be written.
+	if _, err := db.WriteUnit(ctx, "rev", "corpus", "format", unit{}); err == nil {
+		t.Error("WriteUnit: got nil error for an unsupported unit")
+	}
+
+	// Errors from the callback stop iteration and are returned unchanged.
+	const digest = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got, err := db.WriteFile(ctx, strings.NewReader("abc")); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	} else if got != digest {
+		t.Fatalf("WriteFile: got digest %q, want %q", got, digest)
+	}
+	errStop := context.Canceled
+	if err := db.FilesExist(ctx, []string{digest}, func(string) error { return errStop }); err != errStop {
+		t.Errorf("FilesExist: got error %v, want %v", err, errStop)
+	}
+
+	// Invalid requests are rejected by the server.
+	resp, err := http.Post(srv.URL+findPath, "application/json", strings.NewReader(`{"targets":["("]}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Invalid find request: got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	// An empty filter matches nothing, without a round trip.
+	if err := db.Find(ctx, &kcd.FindFilter{}, func(d string) error {
+		t.Errorf("Find: unexpected digest %q", d)
+		return nil
+	}); err != nil {
+		t.Errorf("Find: %v", err)
+	}
+	if err := db.Find(ctx, &kcd.FindFilter{Targets: []*regexp.Regexp{regexp.MustCompile(".*")}}, func(d string) error {
+		t.Errorf("Find: unexpected digest %q", d)
+		return nil
+	}); err != nil {
+		t.Errorf("Find: %v", err)
+	}
+}
+
+func TestWriteUnitDigest(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(ctx, new(memdb.DB))
+	defer srv.Close()
+
+	// The service computes the digest from the unit itself, regardless of how
+	// the client canonicalized it.
+	cu := &apb.CompilationUnit{
+		VName:      &spb.VName{Signature: "unit", Language: "go"},
+		SourceFile: []string{"b.go", "a.go"},
+	}
+	data, err := proto.Marshal(cu)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	c := &webClient{addr: srv.URL}
+	var reply DigestReply
+	if err := c.call(ctx, writeUnitPath, &WriteUnitRequest{
+		Revision:  "rev",
+		FormatKey: kythe.Format,
+		Data:      data,
+	}, &reply); err != nil {
+		t.Fatalf("write_unit: %v", err)
+	}
+	want, err := new(memdb.DB).WriteUnit(ctx, "rev", "", kythe.Format, kythe.Unit{Proto: cu})
+	if err != nil {
+		t.Fatalf("WriteUnit: %v", err)
+	}
+	if reply.Digest != want {
+		t.Errorf("write_unit: got digest %q, want %q", reply.Digest, want)
+	}
+
+	// Data that is not a compilation unit is rejected.
+	if err := c.call(ctx, writeUnitPath, &WriteUnitRequest{
+		Revision: "rev",
+		Data:     []byte("\xff"),
+	}, &reply); err == nil || !strings.Contains(err.Error(), "code 400") {
+		t.Errorf("write_unit: got error %v, want a bad request", err)
+	}
+}
+
+func TestCallCanceled(t *testing.T) {
+	// The handler does not reply until the test is over.
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var reply DigestReply
+	if err := (&webClient{addr: srv.URL}).call(ctx, writeFilePath, &File{}, &reply); err == nil {
+		t.Error("call: unexpected success after the context ended")
+	}
+}
+
+// unit is a trivial implementation of kcd.Unit.
+type unit struct{}
+
+func (unit) MarshalBinary() ([]byte, error) { return nil, nil }
+func (unit) MarshalJSON() ([]byte, error)   { return []byte("{}"), nil }
+func (unit) Index() kcd.Index               { return kcd.Index{} }
+func (unit) Canonicalize()                  {}
+func (unit) Digest(w io.Writer)             {}
@@ -59,6 +59,16 @@
 //   fdigest, err := w.AddFile(file)
 //   ...
 //
+// By default compilation records are stored as JSON in the "units" directory.
+// A writer can instead (or additionally) store them as binary protobuf
+// messages in the "pbunits" directory:
+//
+//   w, err := kzip.NewWriter(file, kzip.WithEncoding(kzip.EncodingAll))
+//   ...
+//
+// A Reader accepts archives in either encoding. If both are present, the proto
+// encoding is preferred.
+//
 package kzip
 
 import (
@@ -80,6 +90,7 @@ import (
 	"kythe.io/kythe/go/platform/kcd/kythe"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 
 	apb "kythe.io/kythe/proto/analysis_go_proto"
 
@@ -91,6 +102,52 @@ import (
 	_ "kythe.io/kythe/proto/java_go_proto"
 )
 
+// Encoding describes how compilation records are encoded in a kzip archive.
+type Encoding int
+
+// The supported encodings for compilation records. These may be combined as
+// a bit mask to request that a Writer emit more than one encoding.
+const (
+	EncodingJSON  Encoding = 1 << iota // JSON IndexedCompilation in "units"
+	EncodingProto                      // binary IndexedCompilation in "pbunits"
+
+	// EncodingAll writes compilation records in every supported encoding.
+	EncodingAll = EncodingJSON | EncodingProto
+)
+
+// Directory names for each encoding of compilation records.
+const (
+	prefixJSON  = "units"
+	prefixProto = "pbunits"
+)
+
+// String returns a human-readable name for the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingJSON:
+		return "JSON"
+	case EncodingProto:
+		return "PROTO"
+	case EncodingAll:
+		return "ALL"
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+// EncodingFor returns the Encoding named by s, which is one of "JSON", "PROTO"
+// or "ALL" (case-insensitive).
+func EncodingFor(s string) (Encoding, error) {
+	switch strings.ToUpper(s) {
+	case "JSON":
+		return EncodingJSON, nil
+	case "PROTO":
+		return EncodingProto, nil
+	case "ALL":
+		return EncodingAll, nil
+	}
+	return 0, fmt.Errorf("unknown kzip encoding %q", s)
+}
+
 // A Reader permits reading and scanning compilation records and file contents
 // stored in a .kzip archive. The Lookup and Scan methods are mutually safe for
 // concurrent use by multiple goroutines.
@@ -101,6 +158,9 @@ type Reader struct {
 	// directory, but it's not required by the spec. Use whatever name the
 	// archive actually specifies in the leading directory.
 	root string
+
+	// The encoding of the compilation records read from the archive.
+	encoding Encoding
 }
 
 // NewReader constructs a new Reader that consumes zip data from r, whose total
@@ -121,13 +181,37 @@ func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
 		return nil, errors.New("archive root is not a directory")
 	}
 
-	return &Reader{
-		zip:  archive,
-		root: archive.File[0].Name,
-	}, nil
+	kr := &Reader{
+		zip:      archive,
+		root:     archive.File[0].Name,
+		encoding: EncodingJSON,
+	}
+	// Prefer proto-encoded units if the archive has any.  An empty "pbunits"
+	// directory entry does not count.
+	pbunits := path.Join(kr.root, prefixProto) + "/"
+	if pos := kr.firstIndex(pbunits); pos >= 0 {
+		for _, f := range archive.File[pos:] {
+			if !strings.HasPrefix(f.Name, pbunits) {
+				break
+			} else if !f.FileInfo().IsDir() {
+				kr.encoding = EncodingProto
+				break
+			}
+		}
+	}
+	return kr, nil
+}
+
+// Encoding reports the encoding of the compilation records read by r.
+func (r *Reader) Encoding() Encoding { return r.encoding }
+
+func (r *Reader) unitPath(digest string) string {
+	if r.encoding == EncodingProto {
+		return path.Join(r.root, prefixProto, digest)
+	}
+	return path.Join(r.root, prefixJSON, digest)
 }
 
-func (r *Reader) unitPath(digest string) string { return path.Join(r.root, "units", digest) }
 func (r *Reader) filePath(digest string) string { return path.Join(r.root, "files", digest) }
 
 // ErrDigestNotFound is returned when a requested compilation unit or file
@@ -138,7 +222,7 @@ var ErrDigestNotFound = errors.New("digest not found")
 // multiple times.
 var ErrUnitExists = errors.New("unit already exists")
 
-func readUnit(digest string, f *zip.File) (*Unit, error) {
+func (r *Reader) readUnit(digest string, f *zip.File) (*Unit, error) {
 	rc, err := f.Open()
 	if err != nil {
 		return nil, err
@@ -146,7 +230,14 @@ func readUnit(digest string, f *zip.File) (*Unit, error) {
 	defer rc.Close()
 
 	var msg apb.IndexedCompilation
-	if err := jsonpb.Unmarshal(rc, &msg); err != nil {
+	if r.encoding == EncodingProto {
+		bits, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		} else if err := proto.Unmarshal(bits, &msg); err != nil {
+			return nil, err
+		}
+	} else if err := jsonpb.Unmarshal(rc, &msg); err != nil {
 		return nil, err
 	}
 	return &Unit{
@@ -175,7 +266,7 @@ func (r *Reader) Lookup(unitDigest string) (*Unit, error) {
 	needle := r.unitPath(unitDigest)
 	if pos := r.firstIndex(needle); pos >= 0 {
 		if f := r.zip.File[pos]; f.Name == needle {
-			return readUnit(unitDigest, f)
+			return r.readUnit(unitDigest, f)
 		}
 	}
 	return nil, ErrDigestNotFound
@@ -198,7 +289,7 @@ func (r *Reader) Scan(f func(*Unit) error) error {
 		if digest == "" {
 			continue // tolerate an empty units directory entry
 		}
-		unit, err := readUnit(digest, file)
+		unit, err := r.readUnit(digest, file)
 		if err != nil {
 			return err
 		}
@@ -247,11 +338,22 @@ type Writer struct {
 	fd  map[string]bool // file digests already written
 	ud  map[string]bool // unit digests already written
 	c   io.Closer       // a closer for the underlying writer (may be nil)
+
+	encoding Encoding // encoding(s) used for compilation records
+}
+
+// A WriterOption configures optional settings of a Writer.
+type WriterOption func(*Writer)
+
+// WithEncoding sets the encoding(s) used by a Writer for compilation records.
+// The default is EncodingJSON.
+func WithEncoding(e Encoding) WriterOption {
+	return func(w *Writer) { w.encoding = e }
 }
 
 // NewWriter constructs a new empty Writer that delivers output to w.  The
 // AddUnit and AddFile methods are safe for use by concurrent goroutines.
-func NewWriter(w io.Writer) (*Writer, error) {
+func NewWriter(w io.Writer, opts ...WriterOption) (*Writer, error) {
 	archive := zip.NewWriter(w)
 	// Create an entry for the root directory, which must be first.
 	root := &zip.FileHeader{
@@ -265,17 +367,25 @@ func NewWriter(w io.Writer) (*Writer, error) {
 	}
 	archive.SetComment("Kythe kzip archive")
 
-	return &Writer{
-		zip: archive,
-		fd:  make(map[string]bool),
-		ud:  make(map[string]bool),
-	}, nil
+	kw := &Writer{
+		zip:      archive,
+		fd:       make(map[string]bool),
+		ud:       make(map[string]bool),
+		encoding: EncodingJSON,
+	}
+	for _, opt := range opts {
+		opt(kw)
+	}
+	if kw.encoding&EncodingAll == 0 || kw.encoding&^EncodingAll != 0 {
+		return nil, fmt.Errorf("invalid kzip encoding %v", kw.encoding)
+	}
+	return kw, nil
 }
 
 // NewWriteCloser behaves as NewWriter, but arranges that when the *Writer is
 // closed it also closes wc.
-func NewWriteCloser(wc io.WriteCloser) (*Writer, error) {
-	w, err := NewWriter(wc)
+func NewWriteCloser(wc io.WriteCloser, opts ...WriterOption) (*Writer, error) {
+	w, err := NewWriter(wc, opts...)
 	if err == nil {
 		w.c = wc
 	}
@@ -305,15 +415,31 @@ func (w *Writer) AddUnit(cu *apb.CompilationUnit, index *apb.IndexedCompilation_
 		return digest, ErrUnitExists
 	}
 
-	f, err := w.zip.CreateHeader(newFileHeader("root", "units", digest))
-	if err != nil {
-		return "", err
-	}
-	if err := toJSON.Marshal(f, &apb.IndexedCompilation{
+	msg := &apb.IndexedCompilation{
 		Unit:  unit.Proto,
 		Index: index,
-	}); err != nil {
-		return "", err
+	}
+	if w.encoding&EncodingJSON != 0 {
+		f, err := w.zip.CreateHeader(newFileHeader("root", prefixJSON, digest))
+		if err != nil {
+			return "", err
+		}
+		if err := toJSON.Marshal(f, msg); err != nil {
+			return "", err
+		}
+	}
+	if w.encoding&EncodingProto != 0 {
+		bits, err := proto.Marshal(msg)
+		if err != nil {
+			return "", err
+		}
+		f, err := w.zip.CreateHeader(newFileHeader("root", prefixProto, digest))
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(bits); err != nil {
+			return "", err
+		}
 	}
 	w.ud[digest] = true
 	return digest, nil
@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -138,6 +139,162 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestEncodings(t *testing.T) {
+	unitIn := &apb.CompilationUnit{
+		VName:      &spb.VName{Corpus: "foo", Language: "bar"},
+		SourceFile: []string{"blodgit"},
+	}
+	indexIn := &apb.IndexedCompilation_Index{
+		Revisions: []string{"a", "b", "c"},
+	}
+	tests := []struct {
+		encoding kzip.Encoding
+		want     kzip.Encoding // the encoding seen by the reader
+		dirs     []string      // unit directories expected in the archive
+	}{
+		{kzip.EncodingJSON, kzip.EncodingJSON, []string{"root/units/"}},
+		{kzip.EncodingProto, kzip.EncodingProto, []string{"root/pbunits/"}},
+		{kzip.EncodingAll, kzip.EncodingProto, []string{"root/units/", "root/pbunits/"}},
+	}
+	for _, test := range tests {
+		t.Run(test.encoding.String(), func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			w, err := kzip.NewWriter(buf, kzip.WithEncoding(test.encoding))
+			if err != nil {
+				t.Fatalf("NewWriter: unexpected error: %v", err)
+			}
+			udigest, err := w.AddUnit(unitIn, indexIn)
+			if err != nil {
+				t.Fatalf("AddUnit: unexpected error: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Writer.Close: unexpected error: %v", err)
+			}
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("Opening zip: unexpected error: %v", err)
+			}
+			for _, dir := range test.dirs {
+				needle := dir + udigest
+				found := false
+				for _, f := range zr.File {
+					found = found || f.Name == needle
+				}
+				if !found {
+					t.Errorf("Archive is missing unit %q", needle)
+				}
+			}
+
+			r, err := kzip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("NewReader: unexpected error: %v", err)
+			}
+			if got := r.Encoding(); got != test.want {
+				t.Errorf("Reader encoding: got %v, want %v", got, test.want)
+			}
+			if u, err := r.Lookup(udigest); err != nil {
+				t.Errorf("Lookup %q: unexpected error: %v", udigest, err)
+			} else if !proto.Equal(u.Proto, unitIn) || !proto.Equal(u.Index, indexIn) {
+				t.Errorf("Lookup: got %+v, want unit %+v and index %+v", u, unitIn, indexIn)
+			}
+			var numUnits int
+			if err := r.Scan(func(*kzip.Unit) error {
+				numUnits++
+				return nil
+			}); err != nil {
+				t.Errorf("Scan failed: %v", err)
+			} else if numUnits != 1 {
+				t.Errorf("Scan found %d units, want 1", numUnits)
+			}
+		})
+	}
+
+	// An empty encoding set is not valid.
+	if w, err := kzip.NewWriter(bytes.NewBuffer(nil), kzip.WithEncoding(0)); err == nil {
+		t.Errorf("NewWriter (invalid encoding): got %+v, want error", w)
+	}
+}
+
+func TestEmptyProtoDirectory(t *testing.T) {
+	unitIn := &apb.CompilationUnit{VName: &spb.VName{Corpus: "foo", Language: "bar"}}
+	buf := bytes.NewBuffer(nil)
+	w, err := kzip.NewWriter(buf)
+	if err != nil {
+		t.Fatalf("NewWriter: unexpected error: %v", err)
+	}
+	udigest, err := w.AddUnit(unitIn, nil)
+	if err != nil {
+		t.Fatalf("AddUnit: unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Writer.Close: unexpected error: %v", err)
+	}
+
+	// Copy the JSON-encoded archive, adding an empty "pbunits" directory.
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Opening zip: unexpected error: %v", err)
+	}
+	out := bytes.NewBuffer(nil)
+	zw := zip.NewWriter(out)
+	if _, err := zw.Create("root/pbunits/"); err != nil {
+		t.Fatalf("Creating directory: %v", err)
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Opening %q: %v", f.Name, err)
+		}
+		fw, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatalf("Creating %q: %v", f.Name, err)
+		}
+		if _, err := io.Copy(fw, rc); err != nil {
+			t.Fatalf("Copying %q: %v", f.Name, err)
+		}
+		rc.Close()
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Closing zip: %v", err)
+	}
+
+	r, err := kzip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: unexpected error: %v", err)
+	}
+	if got := r.Encoding(); got != kzip.EncodingJSON {
+		t.Errorf("Reader encoding: got %v, want %v", got, kzip.EncodingJSON)
+	}
+	if u, err := r.Lookup(udigest); err != nil {
+		t.Errorf("Lookup %q: unexpected error: %v", udigest, err)
+	} else if !proto.Equal(u.Proto, unitIn) {
+		t.Errorf("Lookup: got %+v, want unit %+v", u, unitIn)
+	}
+}
+
+func TestEncodingFor(t *testing.T) {
+	tests := []struct {
+		input string
+		want  kzip.Encoding
+	}{
+		{"json", kzip.EncodingJSON},
+		{"JSON", kzip.EncodingJSON},
+		{"proto", kzip.EncodingProto},
+		{"All", kzip.EncodingAll},
+	}
+	for _, test := range tests {
+		if got, err := kzip.EncodingFor(test.input); err != nil {
+			t.Errorf("EncodingFor(%q): unexpected error: %v", test.input, err)
+		} else if got != test.want {
+			t.Errorf("EncodingFor(%q): got %v, want %v", test.input, got, test.want)
+		}
+	}
+	if got, err := kzip.EncodingFor("bogus"); err == nil {
+		t.Errorf("EncodingFor(bogus): got %v, want error", got)
+	}
+}
+
 // bufferStub implements io.WriteCloser and records when it has been closed to
 // verify that closes are propagated correctly.
 type bufferStub struct {
@@ -17,6 +17,9 @@
 // Binary dedup_stream reads a delimited stream from stdin and writes a delimited stream to stdout.
 // Each record in the stream will be hashed, and if that hash value has already been seen, the
 // record will not be emitted.
+//
+// The input may also be a JSON or Riegeli entry stream and may be gzip-compressed (see
+// stream.NewReader); the output is always a delimited stream.
 package main
 
 import (
@@ -26,6 +29,7 @@ import (
 
 	"kythe.io/kythe/go/platform/delimited"
 	"kythe.io/kythe/go/platform/delimited/dedup"
+	"kythe.io/kythe/go/storage/stream"
 	"kythe.io/kythe/go/util/datasize"
 	"kythe.io/kythe/go/util/flagutil"
 )
@@ -42,7 +46,11 @@ func main() {
 		flagutil.UsageErrorf("unknown arguments: %v", flag.Args())
 	}
 
-	rd, err := dedup.NewReader(os.Stdin, int(cacheSize.Bytes()))
+	src, err := stream.NewRecordReader(os.Stdin)
+	if err != nil {
+		log.Fatalf("Error reading stream: %v", err)
+	}
+	rd, err := dedup.NewSourceReader(src, int(cacheSize.Bytes()))
 	if err != nil {
 		log.Fatalf("Error creating UniqReader: %v", err)
 	}
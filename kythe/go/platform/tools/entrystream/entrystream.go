@@ -27,6 +27,14 @@
 //
 //   $ ... | entrystream --write_format=riegeli # Writes entry stream as a Riegeli file
 //   $ ... | entrystream --read_format=riegeli  # Reads the entry stream from a Riegeli file
+//   $ ... | entrystream --write_format=riegeli --riegeli_writer_options=zstd,transpose
+//
+//   $ ... | entrystream --source_path='\.go$' --edge_kind=/kythe/edge/ref  # Selects ref edges from Go files
+//   $ ... | entrystream --node_kind=function --write_format=json          # Prints the entries of function nodes
+//   $ ... | entrystream --neighborhood=kythe://corpus?path=file#sig --depth=2
+//
+// Riegeli chunks are encoded and decoded concurrently using all available CPUs
+// unless the --riegeli_writer_options specify a parallelism.
 package main
 
 import (
@@ -34,9 +42,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"runtime"
 	"strings"
 
 	"kythe.io/kythe/go/platform/delimited"
@@ -49,6 +57,7 @@ import (
 	"github.com/golang/protobuf/proto"
 
 	spb "kythe.io/kythe/proto/storage_go_proto"
+	rmpb "kythe.io/third_party/riegeli/records_metadata_go_proto"
 )
 
 type entrySet struct {
@@ -73,16 +82,30 @@ var (
 	readFormat  = flag.String("read_format", delimitedFormat, "Format of the input stream (accepted formats: {delimited,json,riegeli})")
 	writeFormat = flag.String("write_format", delimitedFormat, "Format of the output stream (accepted formats: {delimited,json,riegeli})")
 
+	riegeliOptions = flag.String("riegeli_writer_options", "", "Riegeli writer options (e.g. \"zstd,transpose\") used for --write_format=riegeli")
+
 	sortStream      = flag.Bool("sort", false, "Sort entry stream into GraphStore order")
 	uniqEntries     = flag.Bool("unique", false, "Print only unique entries (implies --sort)")
 	entrySets       = flag.Bool("entrysets", false, "Print Entry protos as JSON EntrySets (implies --sort and --write_format=json)")
 	countOnly       = flag.Bool("count", false, "Only print the count of protos streamed")
 	structuredFacts = flag.Bool("structured_facts", false, "Encode and/or decode the fact_value for marked source facts")
+
+	sourceCorpus    = flag.String("source_corpus", "", "Only pass entries whose source VName has the given corpus")
+	sourcePath      = flag.String("source_path", "", "Only pass entries whose source VName path matches the given regexp")
+	sourceSignature = flag.String("source_signature", "", "Only pass entries whose source VName has the given signature")
+	targetCorpus    = flag.String("target_corpus", "", "Only pass edges whose target VName has the given corpus")
+	targetPath      = flag.String("target_path", "", "Only pass edges whose target VName path matches the given regexp")
+	targetSignature = flag.String("target_signature", "", "Only pass edges whose target VName has the given signature")
+	factName        = flag.String("fact_name", "", "Only pass facts with the given name (e.g. /kythe/node/kind)")
+	edgeKind        = flag.String("edge_kind", "", "Only pass edges of the given kind (e.g. /kythe/edge/defines/binding)")
+	nodeKind        = flag.String("node_kind", "", "Only pass entries whose source node has the given kind (implies --sort)")
+	neighborTicket  = flag.String("neighborhood", "", "Only pass entries of nodes within --depth edges of the node with the given ticket")
+	neighborDepth   = flag.Int("depth", 1, "Maximum number of edges from the --neighborhood node")
 )
 
 func init() {
 	flag.Usage = flagutil.SimpleUsage("Manipulate a stream of Entry messages",
-		"[--read_format=<format>] [--unique] ([--write_format=<format>] [--sort] | [--entrysets] | [--count])")
+		"[--read_format=<format>] [--unique] [filters] ([--write_format=<format>] [--riegeli_writer_options=<opts>] [--sort] | [--entrysets] | [--count])")
 }
 
 func main() {
@@ -104,6 +127,24 @@ func main() {
 		*writeFormat = jsonFormat
 	}
 
+	riegeliOpts, err := riegeli.ParseOptions(*riegeliOptions)
+	if err != nil {
+		flagutil.UsageErrorf("Invalid --riegeli_writer_options: %v", err)
+	}
+
+	sourceFilter, err := vnameFilter(entrySource, *sourceCorpus, *sourcePath, *sourceSignature)
+	if err != nil {
+		flagutil.UsageErrorf("Invalid source filter: %v", err)
+	}
+	targetFilter, err := vnameFilter(entryTarget, *targetCorpus, *targetPath, *targetSignature)
+	if err != nil {
+		flagutil.UsageErrorf("Invalid target filter: %v", err)
+	}
+	filter := allFilters(sourceFilter, targetFilter, factFilter(*factName), edgeFilter(*edgeKind))
+	if *neighborDepth < 0 {
+		flagutil.UsageErrorf("Invalid --depth %d (must be ≥ 0)", *neighborDepth)
+	}
+
 	in := bufio.NewReaderSize(os.Stdin, 2*4096)
 	out := bufio.NewWriter(os.Stdout)
 
@@ -116,31 +157,29 @@ func main() {
 			rd = stream.NewJSONReader(in)
 		}
 	case riegeliFormat:
-		rd = func(emit func(*spb.Entry) error) error {
-			r := riegeli.NewReader(in)
-			for {
-				rec, err := r.Next()
-				if err == io.EOF {
-					return nil
-				} else if err != nil {
-					return err
-				}
-				var e spb.Entry
-				if err := proto.Unmarshal(rec, &e); err != nil {
-					return err
-				} else if err := emit(&e); err != nil {
-					return err
-				}
-			}
-		}
+		rd = stream.NewRiegeliReader(in)
 	case delimitedFormat:
-		rd = stream.NewReader(in)
+		rd = stream.NewDelimitedReader(in)
 	default:
 		log.Fatalf("Unsupported --read_format=%s", *readFormat)
 	}
 
-	if *sortStream || *entrySets || *uniqEntries {
-		var err error
+	if *neighborTicket != "" {
+		rd, err = neighborhood(rd, *neighborTicket, *neighborDepth)
+		failOnErr(err)
+	}
+
+	// Filter the stream before sorting it so that only the selected entries
+	// are sorted.  The node kind facts are kept until the nodes are selected.
+	if filter != nil {
+		if *nodeKind != "" {
+			rd = filterEntries(rd, withNodeKinds(filter))
+		} else {
+			rd = filterEntries(rd, filter)
+		}
+	}
+
+	if *sortStream || *entrySets || *uniqEntries || *nodeKind != "" {
 		rd, err = sortEntries(rd)
 		failOnErr(err)
 	}
@@ -149,6 +188,13 @@ func main() {
 		rd = dedupEntries(rd)
 	}
 
+	if *nodeKind != "" {
+		rd = filterNodeKind(rd, *nodeKind)
+		if filter != nil {
+			rd = filterEntries(rd, filter)
+		}
+	}
+
 	switch {
 	case *countOnly:
 		var count int
@@ -194,7 +240,16 @@ func main() {
 				return encoder.Encode(entry)
 			}))
 		case riegeliFormat:
-			wr := riegeli.NewWriter(out, nil)
+			riegeliOpts.Metadata = &rmpb.RecordsMetadata{
+				RecordTypeName: proto.String(proto.MessageName((*spb.Entry)(nil))),
+				// Record the options before defaulting the parallelism, which does
+				// not affect the file's encoding.
+				RecordWriterOptions: proto.String(riegeliOpts.String()),
+			}
+			if riegeliOpts.Parallelism == 0 {
+				riegeliOpts.Parallelism = runtime.NumCPU()
+			}
+			wr := riegeli.NewWriter(out, riegeliOpts)
 			failOnErr(rd(func(entry *spb.Entry) error {
 				rec, err := proto.Marshal(entry)
 				if err != nil {
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/storage/stream"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/facts"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// An entryFilter reports whether an entry should be passed through the stream.
+type entryFilter func(*spb.Entry) bool
+
+// allFilters returns an entryFilter passing entries that pass each of the given
+// filters.  nil filters are ignored.  If there are no filters, nil is returned.
+func allFilters(filters ...entryFilter) entryFilter {
+	var fs []entryFilter
+	for _, f := range filters {
+		if f != nil {
+			fs = append(fs, f)
+		}
+	}
+	if len(fs) == 0 {
+		return nil
+	}
+	return func(e *spb.Entry) bool {
+		for _, f := range fs {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// vnameFilter returns an entryFilter for the VName selected by get.  Empty
+// corpus, path or signature values match any VName.  Entries without a VName
+// never match a non-empty value.
+func vnameFilter(get func(*spb.Entry) *spb.VName, corpus, path, signature string) (entryFilter, error) {
+	if corpus == "" && path == "" && signature == "" {
+		return nil, nil
+	}
+	var pathRE *regexp.Regexp
+	if path != "" {
+		var err error
+		pathRE, err = regexp.Compile(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path regexp %q: %v", path, err)
+		}
+	}
+	return func(e *spb.Entry) bool {
+		v := get(e)
+		if v == nil {
+			return false
+		}
+		return (corpus == "" || v.Corpus == corpus) &&
+			(pathRE == nil || pathRE.MatchString(v.Path)) &&
+			(signature == "" || v.Signature == signature)
+	}, nil
+}
+
+func entrySource(e *spb.Entry) *spb.VName { return e.Source }
+func entryTarget(e *spb.Entry) *spb.VName { return e.Target }
+
+// factFilter returns an entryFilter passing only facts with the given name.
+func factFilter(name string) entryFilter {
+	if name == "" {
+		return nil
+	}
+	return func(e *spb.Entry) bool { return e.EdgeKind == "" && e.FactName == name }
+}
+
+// edgeFilter returns an entryFilter passing only edges of the given kind.
+func edgeFilter(kind string) entryFilter {
+	if kind == "" {
+		return nil
+	}
+	return func(e *spb.Entry) bool { return e.EdgeKind == kind }
+}
+
+// withNodeKinds returns an entryFilter passing the entries that pass filter
+// along with every node kind fact, which filterNodeKind needs in order to
+// select nodes.
+func withNodeKinds(filter entryFilter) entryFilter {
+	return func(e *spb.Entry) bool {
+		return filter(e) || (e.EdgeKind == "" && e.FactName == facts.NodeKind)
+	}
+}
+
+// filterEntries returns an EntryReader passing only the entries of rd that
+// pass the given filter.
+func filterEntries(rd stream.EntryReader, filter entryFilter) stream.EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		return rd(func(e *spb.Entry) error {
+			if !filter(e) {
+				return nil
+			}
+			return f(e)
+		})
+	}
+}
+
+// filterNodeKind returns an EntryReader passing only the entries whose source
+// node has the given kind.  rd must be in GraphStore order so that each node's
+// facts precede its edges.
+func filterNodeKind(rd stream.EntryReader, kind string) stream.EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		var (
+			source *spb.VName
+			known  bool // whether the kind of source is known
+			match  bool // whether source has the given kind
+			buffer []*spb.Entry
+		)
+		return rd(func(e *spb.Entry) error {
+			if !compare.VNamesEqual(source, e.Source) {
+				source, known, match, buffer = e.Source, false, false, nil
+			}
+			if known {
+				if match {
+					return f(e)
+				}
+				return nil
+			}
+
+			if e.EdgeKind == "" && e.FactName == facts.NodeKind {
+				known, match = true, string(e.FactValue) == kind
+			} else if e.EdgeKind != "" {
+				// The node's facts have all been seen without its kind.
+				known = true
+			}
+			if !known {
+				buffer = append(buffer, e)
+				return nil
+			} else if !match {
+				buffer = nil
+				return nil
+			}
+			for _, b := range buffer {
+				if err := f(b); err != nil {
+					return err
+				}
+			}
+			buffer = nil
+			return f(e)
+		})
+	}
+}
+
+// neighborhood returns an EntryReader passing only the entries of nodes within
+// depth edges of the node with the given ticket, following edges in either
+// direction.  The stream is spooled to a temporary file in order to make
+// multiple passes over it; the file is removed once the returned EntryReader
+// is finished, or immediately if an error is returned.
+func neighborhood(rd stream.EntryReader, ticket string, depth int) (_ stream.EntryReader, err error) {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket %q: %v", ticket, err)
+	}
+
+	spool, err := ioutil.TempFile("", "entrystream")
+	if err != nil {
+		return nil, fmt.Errorf("error creating spool file: %v", err)
+	}
+	cleanup := func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
+	buf := bufio.NewWriter(spool)
+	wr := delimited.NewWriter(buf)
+	if err := rd(func(e *spb.Entry) error { return wr.PutProto(e) }); err != nil {
+		return nil, fmt.Errorf("error spooling entries: %v", err)
+	} else if err := buf.Flush(); err != nil {
+		return nil, fmt.Errorf("error spooling entries: %v", err)
+	}
+
+	// pass reads the spooled stream from its beginning.
+	pass := func(f func(*spb.Entry) error) error {
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return stream.NewDelimitedReader(bufio.NewReader(spool))(f)
+	}
+
+	nodes := map[string]bool{uri.String(): true}
+	frontier := nodes
+	for i := 0; i < depth && len(frontier) > 0; i++ {
+		next := make(map[string]bool)
+		if err := pass(func(e *spb.Entry) error {
+			if e.EdgeKind == "" {
+				return nil
+			}
+			src, tgt := kytheuri.ToString(e.Source), kytheuri.ToString(e.Target)
+			if frontier[src] && !nodes[tgt] {
+				next[tgt] = true
+			}
+			if frontier[tgt] && !nodes[src] {
+				next[src] = true
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("error reading spooled entries: %v", err)
+		}
+		for n := range next {
+			nodes[n] = true
+		}
+		frontier = next
+	}
+
+	return func(f func(*spb.Entry) error) error {
+		defer cleanup()
+		return pass(func(e *spb.Entry) error {
+			if !nodes[kytheuri.ToString(e.Source)] {
+				return nil
+			}
+			return f(e)
+		})
+	}, nil
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"kythe.io/kythe/go/storage/stream"
+	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/facts"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+var (
+	fileA = &spb.VName{Corpus: "kythe", Path: "a.go"}
+	fileB = &spb.VName{Corpus: "other", Path: "b.java"}
+	funcF = &spb.VName{Corpus: "kythe", Path: "a.go", Signature: "F"}
+	funcG = &spb.VName{Corpus: "other", Path: "b.java", Signature: "G"}
+	varV  = &spb.VName{Corpus: "kythe", Path: "a.go", Signature: "V"}
+
+	// testEntries are in GraphStore order.
+	testEntries = []*spb.Entry{
+		fact(fileA, facts.NodeKind, "file"),
+		fact(fileB, facts.NodeKind, "file"),
+		fact(funcF, facts.NodeKind, "function"),
+		fact(funcF, facts.Complete, "definition"),
+		edge(funcF, "/kythe/edge/childof", fileA),
+		edge(funcF, "/kythe/edge/ref", funcG),
+		fact(funcG, facts.NodeKind, "function"),
+		edge(funcG, "/kythe/edge/childof", fileB),
+		fact(varV, facts.NodeKind, "variable"),
+		edge(varV, "/kythe/edge/childof", fileA),
+	}
+)
+
+func fact(src *spb.VName, name, value string) *spb.Entry {
+	return &spb.Entry{Source: src, FactName: name, FactValue: []byte(value)}
+}
+
+func edge(src *spb.VName, kind string, tgt *spb.VName) *spb.Entry {
+	return &spb.Entry{Source: src, EdgeKind: kind, Target: tgt, FactName: "/"}
+}
+
+func entryReader(es []*spb.Entry) stream.EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		for _, e := range es {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func readAll(t *testing.T, rd stream.EntryReader) []*spb.Entry {
+	t.Helper()
+	var es []*spb.Entry
+	if err := rd(func(e *spb.Entry) error {
+		es = append(es, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("Error reading entries: %v", err)
+	}
+	return es
+}
+
+func mustVNameFilter(t *testing.T, get func(*spb.Entry) *spb.VName, corpus, path, signature string) entryFilter {
+	t.Helper()
+	f, err := vnameFilter(get, corpus, path, signature)
+	if err != nil {
+		t.Fatalf("vnameFilter error: %v", err)
+	}
+	return f
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter entryFilter
+		want   []*spb.Entry
+	}{{
+		name:   "source_corpus",
+		filter: mustVNameFilter(t, entrySource, "other", "", ""),
+		want:   []*spb.Entry{testEntries[1], testEntries[6], testEntries[7]},
+	}, {
+		name:   "source_path",
+		filter: mustVNameFilter(t, entrySource, "", `\.java$`, ""),
+		want:   []*spb.Entry{testEntries[1], testEntries[6], testEntries[7]},
+	}, {
+		name:   "source_signature",
+		filter: mustVNameFilter(t, entrySource, "", "", "F"),
+		want:   testEntries[2:6],
+	}, {
+		name:   "target_corpus",
+		filter: mustVNameFilter(t, entryTarget, "other", "", ""),
+		want:   []*spb.Entry{testEntries[5], testEntries[7]},
+	}, {
+		name:   "target_path",
+		filter: mustVNameFilter(t, entryTarget, "", `^a\.go$`, ""),
+		want:   []*spb.Entry{testEntries[4], testEntries[9]},
+	}, {
+		name:   "target_signature",
+		filter: mustVNameFilter(t, entryTarget, "", "", "G"),
+		want:   []*spb.Entry{testEntries[5]},
+	}, {
+		name:   "fact_name",
+		filter: factFilter(facts.Complete),
+		want:   []*spb.Entry{testEntries[3]},
+	}, {
+		name:   "edge_kind",
+		filter: edgeFilter("/kythe/edge/ref"),
+		want:   []*spb.Entry{testEntries[5]},
+	}, {
+		name: "combined",
+		filter: allFilters(
+			mustVNameFilter(t, entrySource, "kythe", "", ""),
+			edgeFilter("/kythe/edge/childof"),
+		),
+		want: []*spb.Entry{testEntries[4], testEntries[9]},
+	}}
+	for _, test := range tests {
+		got := readAll(t, filterEntries(entryReader(testEntries), test.filter))
+		if err := testutil.DeepEqual(test.want, got); err != nil {
+			t.Errorf("--%s: %v", test.name, err)
+		}
+	}
+
+	if f := allFilters(factFilter(""), edgeFilter("")); f != nil {
+		t.Error("allFilters: expected nil filter without any filters")
+	}
+	if _, err := vnameFilter(entrySource, "", "(", ""); err == nil {
+		t.Error("vnameFilter: expected error for invalid path regexp")
+	}
+}
+
+func TestFilterNodeKind(t *testing.T) {
+	got := readAll(t, filterNodeKind(entryReader(testEntries), "function"))
+	if err := testutil.DeepEqual(testEntries[2:8], got); err != nil {
+		t.Errorf("--node_kind: %v", err)
+	}
+
+	// Entry filters applied before --node_kind retain the node kind facts,
+	// which are removed once the nodes have been selected.
+	filter := edgeFilter("/kythe/edge/childof")
+	rd := filterEntries(entryReader(testEntries), withNodeKinds(filter))
+	rd = filterEntries(filterNodeKind(rd, "function"), filter)
+	got = readAll(t, rd)
+	if err := testutil.DeepEqual([]*spb.Entry{testEntries[4], testEntries[7]}, got); err != nil {
+		t.Errorf("--node_kind with --edge_kind: %v", err)
+	}
+}
+
+func TestNeighborhood(t *testing.T) {
+	dir, err := ioutil.TempDir("", "entrystream_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", dir)
+
+	ticket := kytheuri.ToString(funcG)
+	tests := []struct {
+		depth int
+		want  []*spb.Entry
+	}{
+		{0, testEntries[6:8]},
+		{1, testEntries[1:8]},
+		{2, testEntries[:8]},
+		{3, testEntries},
+	}
+	for _, test := range tests {
+		rd, err := neighborhood(entryReader(testEntries), ticket, test.depth)
+		if err != nil {
+			t.Fatalf("neighborhood error: %v", err)
+		}
+		got := readAll(t, rd)
+		if err := testutil.DeepEqual(test.want, got); err != nil {
+			t.Errorf("--depth=%d: %v", test.depth, err)
+		}
+		if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+			t.Errorf("--depth=%d: spool file was not removed", test.depth)
+		}
+	}
+
+	if _, err := neighborhood(entryReader(testEntries), "bogus:ticket", 1); err == nil {
+		t.Error("neighborhood: expected error for invalid ticket")
+	}
+	failing := func(f func(*spb.Entry) error) error { return os.ErrInvalid }
+	if _, err := neighborhood(failing, ticket, 1); err == nil {
+		t.Error("neighborhood: expected error from failing reader")
+	} else if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Error("neighborhood: spool file was not removed after an error")
+	}
+}
@@ -43,6 +43,7 @@ var (
 	kzipPath = flag.String("output", "", "Output kzip filename")
 	packPath = flag.String("input", "", "Input indexpack directory or zip path")
 	revision = flag.String("revision", "", "Add this revision marker")
+	encoding = flag.String("encoding", "JSON", "Encoding of compilation units in the kzip (JSON, PROTO or ALL)")
 )
 
 func init() {
@@ -66,6 +67,10 @@ func main() {
 	} else if _, err := os.Stat(*kzipPath); err == nil {
 		log.Fatalf("Output file %q already exists", *kzipPath)
 	}
+	enc, err := kzip.EncodingFor(*encoding)
+	if err != nil {
+		log.Fatalf("Invalid --encoding: %v", err)
+	}
 
 	ctx := context.Background()
 	pack, err := openPack(ctx, *packPath)
@@ -76,7 +81,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Creating kzip file: %v", err)
 	}
-	kw, err := kzip.NewWriter(f)
+	kw, err := kzip.NewWriter(f, kzip.WithEncoding(enc))
 	if err != nil {
 		log.Fatalf("Creating kzip writer: %v", err)
 	}
@@ -28,9 +28,11 @@ import (
 
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/link"
 	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/services/xrefs"
 	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/status"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/subcommands"
@@ -58,6 +60,8 @@ type API struct {
 	GraphService      graph.Service
 	FileTreeService   filetree.Service
 	IdentifierService identifiers.Service
+	StatusService     status.Service
+	LinkService       link.Service
 }
 
 // Execute registers all Kythe CLI commands to subcommands.DefaultCommander and
@@ -73,7 +77,9 @@ func Execute(ctx context.Context, api API) subcommands.ExitStatus {
 	RegisterCommand(&edgesCommand{}, "graph")
 
 	RegisterCommand(&identCommand{}, "")
+	RegisterCommand(&linkCommand{}, "")
 	RegisterCommand(&lsCommand{}, "")
+	RegisterCommand(&statusCommand{}, "")
 
 	RegisterCommand(&decorCommand{}, "xrefs")
 	RegisterCommand(&diagnosticsCommand{}, "xrefs")
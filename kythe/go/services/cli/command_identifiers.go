@@ -28,6 +28,8 @@ import (
 
 type identCommand struct {
 	corpora, languages string
+	fuzzy              bool
+	pageSize           int
 }
 
 func (identCommand) Name() string     { return "identifier" }
@@ -36,6 +38,8 @@ func (identCommand) Usage() string    { return "<identifier>" }
 func (c *identCommand) SetFlags(flag *flag.FlagSet) {
 	flag.StringVar(&c.corpora, "corpora", "", "Comma-separated list of corpora with which to restrict matches")
 	flag.StringVar(&c.languages, "languages", "", "Comma-separated list of languages with which to restrict matches")
+	flag.BoolVar(&c.fuzzy, "fuzzy", false, `Treat the identifier as a search query (e.g. "kzip newwri") matching prefixes, substrings and camel-hump abbreviations case-insensitively`)
+	flag.IntVar(&c.pageSize, "page_size", 0, "If positive, the maximum number of matches to display")
 }
 func (c identCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) error {
 	if flag.NArg() == 0 {
@@ -46,6 +50,8 @@ func (c identCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 
 	req := &ipb.FindRequest{
 		Identifier: flag.Arg(0),
+		Fuzzy:      c.fuzzy,
+		PageSize:   int32(c.pageSize),
 	}
 	if c.corpora != "" {
 		req.Corpus = strings.Split(c.corpora, ",")
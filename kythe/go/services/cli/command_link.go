@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	linkpb "kythe.io/kythe/proto/link_go_proto"
+)
+
+type linkCommand struct {
+	corpora, languages, kinds string
+	includePath, excludePath  string
+	definitionKind            string
+	params                    int
+	includeNodes              bool
+}
+
+func (linkCommand) Name() string     { return "link" }
+func (linkCommand) Synopsis() string { return "list definition locations for an identifier" }
+func (linkCommand) Usage() string    { return "<identifier>" }
+func (c *linkCommand) SetFlags(flag *flag.FlagSet) {
+	flag.StringVar(&c.corpora, "corpora", "", "Comma-separated list of corpora with which to restrict matches")
+	flag.StringVar(&c.languages, "languages", "", "Comma-separated list of languages with which to restrict matches")
+	flag.StringVar(&c.kinds, "kinds", "", `Comma-separated list of node kinds (e.g. "record" or "function/constructor") with which to restrict matches`)
+	flag.StringVar(&c.includePath, "include_path", "", "If set, only definitions in files whose path matches this RE2 regexp are returned")
+	flag.StringVar(&c.excludePath, "exclude_path", "", "If set, definitions in files whose path matches this RE2 regexp are not returned")
+	flag.StringVar(&c.definitionKind, "definition_kind", "binding", "Kind of definition span to return (binding, full, or any)")
+	flag.IntVar(&c.params, "params", -1, "If non-negative, only definitions with this number of parameters are returned")
+	flag.BoolVar(&c.includeNodes, "nodes", false, "Display the semantic nodes defined at each location")
+}
+func (c linkCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) error {
+	if flag.NArg() == 0 {
+		return errors.New("identifier missing")
+	} else if flag.NArg() > 1 {
+		return fmt.Errorf("only 1 identifier may be given; found: %v", flag.Args())
+	}
+
+	kind, ok := linkpb.LinkRequest_DefinitionKind_value[strings.ToUpper(c.definitionKind)]
+	if !ok {
+		return fmt.Errorf("unknown --definition_kind %q", c.definitionKind)
+	}
+	req := &linkpb.LinkRequest{
+		Identifier:     flag.Arg(0),
+		DefinitionKind: linkpb.LinkRequest_DefinitionKind(kind),
+		IncludeNodes:   c.includeNodes,
+	}
+	if c.corpora != "" {
+		req.Corpus = strings.Split(c.corpora, ",")
+	}
+	if c.languages != "" {
+		req.Language = strings.Split(c.languages, ",")
+	}
+	if c.kinds != "" {
+		req.NodeKind = strings.Split(c.kinds, ",")
+	}
+	if c.includePath != "" {
+		req.Include = []*linkpb.LinkRequest_Location{{Path: c.includePath}}
+	}
+	if c.excludePath != "" {
+		req.Exclude = []*linkpb.LinkRequest_Location{{Path: c.excludePath}}
+	}
+	if c.params >= 0 {
+		req.Params = &linkpb.LinkRequest_Params{Count: int32(c.params)}
+	}
+
+	LogRequest(req)
+	reply, err := api.LinkService.Resolve(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.displayLinks(reply)
+}
+
+func (c linkCommand) displayLinks(reply *linkpb.LinkReply) error {
+	if DisplayJSON {
+		return PrintJSONMessage(reply)
+	}
+
+	for _, l := range reply.Links {
+		start, end := l.Span.GetStart(), l.Span.GetEnd()
+		if _, err := fmt.Fprintf(out, "%s [%d:%d-%d:%d]\n", l.FileTicket,
+			start.GetLineNumber(), start.GetColumnOffset(), end.GetLineNumber(), end.GetColumnOffset()); err != nil {
+			return err
+		}
+		for _, n := range l.Nodes {
+			if _, err := fmt.Fprintf(out, "  %s [identifier: %s]\n", n.Ticket, n.Identifier); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+)
+
+type statusCommand struct{}
+
+func (statusCommand) Name() string           { return "status" }
+func (statusCommand) Synopsis() string       { return "display the status of the served index" }
+func (statusCommand) Usage() string          { return "" }
+func (statusCommand) SetFlags(*flag.FlagSet) {}
+func (c statusCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) error {
+	if flag.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flag.Args())
+	}
+
+	req := &stpb.StatusRequest{}
+	LogRequest(req)
+	reply, err := api.StatusService.Status(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.displayStatus(reply)
+}
+
+func (statusCommand) displayStatus(reply *stpb.StatusReply) error {
+	if DisplayJSON {
+		return PrintJSONMessage(reply)
+	}
+
+	if _, err := fmt.Fprintf(out, "Index version: %s\n", reply.IndexVersion); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, "Origins:"); err != nil {
+		return err
+	}
+	for _, o := range reply.Origins {
+		origin := o.Corpus
+		if o.Revision != "" {
+			origin += " @ " + o.Revision
+		}
+		if _, err := fmt.Fprintf(out, "  %s\n", origin); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(out, "Languages:"); err != nil {
+		return err
+	}
+	for _, l := range reply.Languages {
+		if _, err := fmt.Fprintf(out, "  %s [%s]\n", l.Name, strings.ToLower(l.Support.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -45,6 +45,8 @@ type xrefsCommand struct {
 	relatedNodes    bool
 	nodeDefinitions bool
 	anchorText      bool
+
+	corpusFilter, rootFilter, pathFilter string
 }
 
 func (xrefsCommand) Name() string     { return "xrefs" }
@@ -59,6 +61,9 @@ func (c *xrefsCommand) SetFlags(flag *flag.FlagSet) {
 	flag.StringVar(&c.nodeFilters, "filters", "", "Comma-separated list of additional fact filters to use when requesting related nodes")
 	flag.BoolVar(&c.nodeDefinitions, "node_definitions", false, "Whether to request definition locations for related nodes")
 	flag.BoolVar(&c.anchorText, "anchor_text", false, "Whether to request text for anchors")
+	flag.StringVar(&c.corpusFilter, "corpus_filter", "", "If set, only return anchors in files whose corpus matches this RE2 pattern")
+	flag.StringVar(&c.rootFilter, "root_filter", "", "If set, only return anchors in files whose root matches this RE2 pattern")
+	flag.StringVar(&c.pathFilter, "path_filter", "", "If set, only return anchors in files whose path matches this RE2 pattern")
 
 	flag.StringVar(&c.pageToken, "page_token", "", "CrossReferences page token")
 	flag.IntVar(&c.pageSize, "page_size", 0, "Maximum number of cross-references returned (0 lets the service use a sensible default)")
@@ -73,6 +78,13 @@ func (c xrefsCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 		AnchorText:      c.anchorText,
 		NodeDefinitions: c.nodeDefinitions,
 	}
+	if c.corpusFilter != "" || c.rootFilter != "" || c.pathFilter != "" {
+		req.CorpusPathFilter = &xpb.CrossReferencesRequest_CorpusPathFilter{
+			Corpus: c.corpusFilter,
+			Root:   c.rootFilter,
+			Path:   c.pathFilter,
+		}
+	}
 	if c.relatedNodes {
 		req.Filter = []string{facts.NodeKind, facts.Subkind}
 		if c.nodeFilters != "" {
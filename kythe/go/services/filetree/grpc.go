@@ -0,0 +1,82 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filetree
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/filetree.proto.
+const ServiceName = "kythe.proto.FileTreeService"
+
+var (
+	corpusRootsMethod = grpcutil.MethodName(ServiceName, "CorpusRoots")
+	directoryMethod   = grpcutil.MethodName(ServiceName, "Directory")
+)
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "CorpusRoots",
+			func() interface{} { return new(ftpb.CorpusRootsRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).CorpusRoots(ctx, req.(*ftpb.CorpusRootsRequest))
+			}),
+		grpcutil.UnaryMethod(ServiceName, "Directory",
+			func() interface{} { return new(ftpb.DirectoryRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Directory(ctx, req.(*ftpb.DirectoryRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/filetree.proto",
+}
+
+// RegisterGRPC registers ft with s as the kythe.proto.FileTreeService service.
+func RegisterGRPC(s *grpc.Server, ft Service) { s.RegisterService(&serviceDesc, ft) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// CorpusRoots implements part of the Service interface.
+func (c *grpcClient) CorpusRoots(ctx context.Context, req *ftpb.CorpusRootsRequest) (*ftpb.CorpusRootsReply, error) {
+	reply := new(ftpb.CorpusRootsReply)
+	if err := c.cc.Invoke(ctx, corpusRootsMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Directory implements part of the Service interface.
+func (c *grpcClient) Directory(ctx context.Context, req *ftpb.DirectoryRequest) (*ftpb.DirectoryReply, error) {
+	reply := new(ftpb.DirectoryReply)
+	if err := c.cc.Invoke(ctx, directoryMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns a filetree Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/graph.proto.
+const ServiceName = "kythe.proto.GraphService"
+
+var (
+	nodesMethod = grpcutil.MethodName(ServiceName, "Nodes")
+	edgesMethod = grpcutil.MethodName(ServiceName, "Edges")
+)
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "Nodes",
+			func() interface{} { return new(gpb.NodesRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Nodes(ctx, req.(*gpb.NodesRequest))
+			}),
+		grpcutil.UnaryMethod(ServiceName, "Edges",
+			func() interface{} { return new(gpb.EdgesRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Edges(ctx, req.(*gpb.EdgesRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/graph.proto",
+}
+
+// RegisterGRPC registers gs with s as the kythe.proto.GraphService service.
+func RegisterGRPC(s *grpc.Server, gs Service) { s.RegisterService(&serviceDesc, gs) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// Nodes implements part of the Service interface.
+func (c *grpcClient) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	reply := new(gpb.NodesReply)
+	if err := c.cc.Invoke(ctx, nodesMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Edges implements part of the Service interface.
+func (c *grpcClient) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	reply := new(gpb.EdgesReply)
+	if err := c.cc.Invoke(ctx, edgesMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns a graph Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
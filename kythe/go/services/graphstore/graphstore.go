@@ -14,8 +14,8 @@
  * limitations under the License.
  */
 
-// Package graphstore defines the Service and Sharded interfaces, and provides
-// some useful utility functions.
+// Package graphstore defines the Service interface and its extensions, and
+// provides some useful utility functions.
 package graphstore
 
 import (
@@ -86,6 +86,61 @@ type Sharded interface {
 	Shard(ctx context.Context, req *spb.ShardRequest, f EntryFunc) error
 }
 
+// Partitioner is a Sharded store that can describe its shards by explicit key
+// boundaries.  Passing each of the returned requests to Shard visits every
+// entry in the store exactly once, even if the store is written to in the
+// meantime, so the requests may be handed out to independent readers.
+type Partitioner interface {
+	Sharded
+
+	// Shards returns a ShardRequest, with boundaries, for each of the given
+	// number of shards.
+	Shards(ctx context.Context, shards int64) ([]*spb.ShardRequest, error)
+}
+
+// Deleter represents a store from which entries can be removed.
+type Deleter interface {
+	Service
+
+	// Delete removes each entry that req would write, matching entries by
+	// their source, edge kind, target, and fact name; fact values are ignored.
+	// Entries that are not in the store are skipped.
+	Delete(ctx context.Context, req *spb.WriteRequest) error
+
+	// DeletePrefix removes every entry whose source VName matches prefix, as
+	// determined by VNameHasPrefix.
+	DeletePrefix(ctx context.Context, prefix *spb.VName) error
+}
+
+// UnitReplacer represents a store that records which compilation unit (or
+// other unit of analysis, such as a file) wrote each of its entries, so that
+// a unit's output can be replaced when it is re-analyzed.  Entries written by
+// more than one unit remain until none of their units write them.
+type UnitReplacer interface {
+	Service
+
+	// ReplaceUnit atomically replaces the entries last written for the given
+	// unit with the entries in reqs.  Entries no longer written by any unit are
+	// removed.  reqs is drained even if an error occurs.
+	ReplaceUnit(ctx context.Context, unit string, reqs <-chan *spb.WriteRequest) error
+
+	// DeleteUnit atomically removes the entries last written for the given
+	// unit, as if it were replaced by no entries.
+	DeleteUnit(ctx context.Context, unit string) error
+}
+
+// VNameHasPrefix reports whether v matches prefix.  The signature and path of
+// prefix must be string prefixes of those of v, so that (for example) all the
+// anchors in a file or all the files in a directory can be selected.  Every
+// other field of prefix must either be empty or equal to that of v.
+func VNameHasPrefix(v, prefix *spb.VName) bool {
+	return strings.HasPrefix(v.GetSignature(), prefix.GetSignature()) &&
+		strings.HasPrefix(v.GetPath(), prefix.GetPath()) &&
+		(prefix.GetCorpus() == "" || v.GetCorpus() == prefix.GetCorpus()) &&
+		(prefix.GetRoot() == "" || v.GetRoot() == prefix.GetRoot()) &&
+		(prefix.GetLanguage() == "" || v.GetLanguage() == prefix.GetLanguage())
+}
+
 // EntryMatchesScan reports whether entry belongs in the result set for req.
 func EntryMatchesScan(req *spb.ScanRequest, entry *spb.Entry) bool {
 	return (req.GetTarget() == nil || compare.VNamesEqual(entry.Target, req.Target)) &&
@@ -58,7 +58,9 @@ type proxyService struct {
 }
 
 // New returns a graphstore.Service that forwards Reads, Writes, and Scans to a
-// set of stores in parallel, and merges their results.
+// set of stores in parallel, and merges their results.  The returned service
+// also implements graphstore.Deleter, forwarding deletions to every store; it
+// is an error to delete unless all the stores implement graphstore.Deleter.
 func New(stores ...graphstore.Service) graphstore.Service { return &proxyService{stores} }
 
 // Read implements graphstore.Service and forwards the request to the proxied stores.
@@ -84,6 +86,36 @@ func (p *proxyService) Write(ctx context.Context, req *spb.WriteRequest) error {
 	}))
 }
 
+// Delete implements part of graphstore.Deleter by forwarding the request to
+// the proxied stores.
+func (p *proxyService) Delete(ctx context.Context, req *spb.WriteRequest) error {
+	return p.foreachDeleter(func(d graphstore.Deleter) error {
+		return d.Delete(ctx, req)
+	})
+}
+
+// DeletePrefix implements part of graphstore.Deleter by forwarding the request
+// to the proxied stores.
+func (p *proxyService) DeletePrefix(ctx context.Context, prefix *spb.VName) error {
+	return p.foreachDeleter(func(d graphstore.Deleter) error {
+		return d.DeletePrefix(ctx, prefix)
+	})
+}
+
+// foreachDeleter concurrently invokes f for each proxied store and returns the
+// first error (if any).  No store is invoked unless all of them implement
+// graphstore.Deleter.
+func (p *proxyService) foreachDeleter(f func(graphstore.Deleter) error) error {
+	for _, s := range p.stores {
+		if _, ok := s.(graphstore.Deleter); !ok {
+			return fmt.Errorf("proxied GraphStore does not support deletion: %T", s)
+		}
+	}
+	return waitErr(p.foreach(func(i int, s graphstore.Service) error {
+		return f(s.(graphstore.Deleter))
+	}))
+}
+
 // Close implements part of graphstore.Service by calling Close on each proxied
 // store.  All the stores are given an opportunity to close, even in case of
 // error, but only one error is returned.
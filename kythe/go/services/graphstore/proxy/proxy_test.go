@@ -154,6 +154,45 @@ func TestProxy(t *testing.T) {
 	}
 }
 
+func TestDelete(t *testing.T) {
+	testError := errors.New("test")
+	mocks := []*mockDeleter{
+		{mockGraphStore: &mockGraphStore{}},
+		{mockGraphStore: &mockGraphStore{Error: testError}},
+	}
+	proxy := New(mocks[0], mocks[1]).(graphstore.Deleter)
+
+	deleteReq := new(spb.WriteRequest)
+	if err := proxy.Delete(ctx, deleteReq); err != testError {
+		t.Errorf("Incorrect Delete error: %v", err)
+	}
+	for idx, mock := range mocks {
+		if mock.LastReq != deleteReq {
+			t.Errorf("Delete request was not sent to service %d", idx)
+		}
+	}
+
+	prefix := &spb.VName{Corpus: "kythe"}
+	if err := proxy.DeletePrefix(ctx, prefix); err != testError {
+		t.Errorf("Incorrect DeletePrefix error: %v", err)
+	}
+	for idx, mock := range mocks {
+		if mock.LastReq != prefix {
+			t.Errorf("DeletePrefix request was not sent to service %d", idx)
+		}
+	}
+
+	// Deletions must not reach any store unless all of them support it.
+	readOnly := &mockGraphStore{}
+	mixed := New(&mockDeleter{mockGraphStore: readOnly}, &mockGraphStore{}).(graphstore.Deleter)
+	if err := mixed.Delete(ctx, deleteReq); err == nil {
+		t.Error("Delete with a non-Deleter store: unexpected success")
+	}
+	if readOnly.LastReq != nil {
+		t.Errorf("Delete request was sent to a store: %v", readOnly.LastReq)
+	}
+}
+
 // Verify that a proxy store behaves sensibly if an operation fails.
 func TestCancellation(t *testing.T) {
 	bomb := entry{K: "bomb", F: "die", V: "horrible catastrophe"}
@@ -265,6 +304,18 @@ func (m *mockGraphStore) Write(ctx context.Context, req *spb.WriteRequest) error
 
 func (m *mockGraphStore) Close(ctx context.Context) error { return m.Error }
 
+type mockDeleter struct{ *mockGraphStore }
+
+func (m *mockDeleter) Delete(ctx context.Context, req *spb.WriteRequest) error {
+	m.LastReq = req
+	return m.Error
+}
+
+func (m *mockDeleter) DeletePrefix(ctx context.Context, prefix *spb.VName) error {
+	m.LastReq = prefix
+	return m.Error
+}
+
 // checkResults starts a goroutine that consumes entries from results and
 // compares them to corresponding members of want.  If the corresponding values
 // are unequal or if there are more or fewer results than wanted, errors are
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote exposes a graphstore.Service over gRPC, implementing the
+// kythe.proto.GraphStore and kythe.proto.ShardedGraphStore services defined in
+// kythe/proto/storage_service.proto, and provides a matching client.
+//
+// Importing this package registers a "grpc" gsutil handler, so that a remote
+// GraphStore may be named by a specification like "grpc:localhost:8080".
+package remote
+
+import (
+	"context"
+	"io"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func init() {
+	gsutil.Register("grpc", func(spec string) (graphstore.Service, error) { return Dial(spec) })
+}
+
+// Fully-qualified names of the gRPC services, as declared in
+// kythe/proto/storage_service.proto.
+const (
+	GraphStoreName        = "kythe.proto.GraphStore"
+	ShardedGraphStoreName = "kythe.proto.ShardedGraphStore"
+)
+
+var (
+	readMethod  = grpcutil.MethodName(GraphStoreName, "Read")
+	scanMethod  = grpcutil.MethodName(GraphStoreName, "Scan")
+	writeMethod = grpcutil.MethodName(GraphStoreName, "Write")
+	countMethod = grpcutil.MethodName(ShardedGraphStoreName, "Count")
+	shardMethod = grpcutil.MethodName(ShardedGraphStoreName, "Shard")
+
+	readStream  = grpc.StreamDesc{StreamName: "Read", ServerStreams: true}
+	scanStream  = grpc.StreamDesc{StreamName: "Scan", ServerStreams: true}
+	shardStream = grpc.StreamDesc{StreamName: "Shard", ServerStreams: true}
+)
+
+// Register registers gs with s as the kythe.proto.GraphStore service.  If gs
+// also implements graphstore.Sharded, it is registered as the
+// kythe.proto.ShardedGraphStore service as well.
+func Register(s *grpc.Server, gs graphstore.Service) {
+	s.RegisterService(&graphStoreDesc, &server{gs})
+	if sharded, ok := gs.(graphstore.Sharded); ok {
+		s.RegisterService(&shardedGraphStoreDesc, &shardedServer{sharded})
+	}
+}
+
+// graphStoreServer is the handler type for the kythe.proto.GraphStore service.
+type graphStoreServer interface {
+	read(*spb.ReadRequest, grpc.ServerStream) error
+	scan(*spb.ScanRequest, grpc.ServerStream) error
+	write(context.Context, *spb.WriteRequest) (*spb.WriteReply, error)
+}
+
+// shardedGraphStoreServer is the handler type for the
+// kythe.proto.ShardedGraphStore service.
+type shardedGraphStoreServer interface {
+	count(context.Context, *spb.CountRequest) (*spb.CountReply, error)
+	shard(*spb.ShardRequest, grpc.ServerStream) error
+}
+
+var graphStoreDesc = grpc.ServiceDesc{
+	ServiceName: GraphStoreName,
+	HandlerType: (*graphStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(GraphStoreName, "Write",
+			func() interface{} { return new(spb.WriteRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(graphStoreServer).write(ctx, req.(*spb.WriteRequest))
+			}),
+	},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    readStream.StreamName,
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			req := new(spb.ReadRequest)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			return srv.(graphStoreServer).read(req, stream)
+		},
+	}, {
+		StreamName:    scanStream.StreamName,
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			req := new(spb.ScanRequest)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			return srv.(graphStoreServer).scan(req, stream)
+		},
+	}},
+	Metadata: "kythe/proto/storage_service.proto",
+}
+
+var shardedGraphStoreDesc = grpc.ServiceDesc{
+	ServiceName: ShardedGraphStoreName,
+	HandlerType: (*shardedGraphStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ShardedGraphStoreName, "Count",
+			func() interface{} { return new(spb.CountRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(shardedGraphStoreServer).count(ctx, req.(*spb.CountRequest))
+			}),
+	},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    shardStream.StreamName,
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			req := new(spb.ShardRequest)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			return srv.(shardedGraphStoreServer).shard(req, stream)
+		},
+	}},
+	Metadata: "kythe/proto/storage_service.proto",
+}
+
+type server struct{ gs graphstore.Service }
+
+func (s *server) read(req *spb.ReadRequest, stream grpc.ServerStream) error {
+	return s.gs.Read(stream.Context(), req, sendEntry(stream))
+}
+
+func (s *server) scan(req *spb.ScanRequest, stream grpc.ServerStream) error {
+	return s.gs.Scan(stream.Context(), req, sendEntry(stream))
+}
+
+func (s *server) write(ctx context.Context, req *spb.WriteRequest) (*spb.WriteReply, error) {
+	if err := s.gs.Write(ctx, req); err != nil {
+		return nil, err
+	}
+	return new(spb.WriteReply), nil
+}
+
+type shardedServer struct{ gs graphstore.Sharded }
+
+func (s *shardedServer) count(ctx context.Context, req *spb.CountRequest) (*spb.CountReply, error) {
+	n, err := s.gs.Count(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &spb.CountReply{Entries: n}, nil
+}
+
+func (s *shardedServer) shard(req *spb.ShardRequest, stream grpc.ServerStream) error {
+	return s.gs.Shard(stream.Context(), req, sendEntry(stream))
+}
+
+// sendEntry returns an EntryFunc that sends each entry to stream.
+func sendEntry(stream grpc.ServerStream) graphstore.EntryFunc {
+	return func(e *spb.Entry) error { return stream.SendMsg(e) }
+}
+
+// Client is a graphstore.Sharded implementation that forwards its requests to
+// a remote GraphStore service.  The Count and Shard methods will fail unless
+// the remote store also supports sharding.
+type Client struct {
+	cc    *grpc.ClientConn
+	owned bool // whether Close should close cc
+}
+
+// New returns a Client that sends requests over cc.  Closing the Client does
+// not close cc.
+func New(cc *grpc.ClientConn) *Client { return &Client{cc: cc} }
+
+// Dial returns a Client connected to the GraphStore service at addr.  Unless
+// other options are given, the connection is insecure.  Closing the Client
+// closes the connection.
+func Dial(addr string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	cc, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{cc: cc, owned: true}, nil
+}
+
+// Read implements part of the graphstore.Service interface.
+func (c *Client) Read(ctx context.Context, req *spb.ReadRequest, f graphstore.EntryFunc) error {
+	return c.stream(ctx, &readStream, readMethod, req, f)
+}
+
+// Scan implements part of the graphstore.Service interface.
+func (c *Client) Scan(ctx context.Context, req *spb.ScanRequest, f graphstore.EntryFunc) error {
+	return c.stream(ctx, &scanStream, scanMethod, req, f)
+}
+
+// Write implements part of the graphstore.Service interface.
+func (c *Client) Write(ctx context.Context, req *spb.WriteRequest) error {
+	return c.cc.Invoke(ctx, writeMethod, req, new(spb.WriteReply))
+}
+
+// Count implements part of the graphstore.Sharded interface.
+func (c *Client) Count(ctx context.Context, req *spb.CountRequest) (int64, error) {
+	var reply spb.CountReply
+	if err := c.cc.Invoke(ctx, countMethod, req, &reply); err != nil {
+		return 0, err
+	}
+	return reply.Entries, nil
+}
+
+// Shard implements part of the graphstore.Sharded interface.
+func (c *Client) Shard(ctx context.Context, req *spb.ShardRequest, f graphstore.EntryFunc) error {
+	return c.stream(ctx, &shardStream, shardMethod, req, f)
+}
+
+// Close implements part of the graphstore.Service interface.  It closes the
+// underlying connection if the Client was created by Dial.
+func (c *Client) Close(ctx context.Context) error {
+	if c.owned {
+		return c.cc.Close()
+	}
+	return nil
+}
+
+// stream sends req to the given server-streaming method and calls f with each
+// entry received in response.  If f returns io.EOF, the call is cancelled and
+// stream returns nil.
+func (c *Client) stream(ctx context.Context, desc *grpc.StreamDesc, method string, req interface{}, f graphstore.EntryFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s, err := c.cc.NewStream(ctx, desc, method)
+	if err != nil {
+		return err
+	} else if err := s.SendMsg(req); err != nil {
+		return err
+	} else if err := s.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		entry := new(spb.Entry)
+		if err := s.RecvMsg(entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/storage/inmemory"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+var testEntries = []*spb.Entry{
+	{Source: &spb.VName{Signature: "a"}, FactName: "/kythe/node/kind", FactValue: []byte("record")},
+	{Source: &spb.VName{Signature: "a"}, FactName: "/kythe/text", FactValue: []byte("text")},
+	{Source: &spb.VName{Signature: "a"}, EdgeKind: "/kythe/edge/childof", Target: &spb.VName{Signature: "b"}, FactName: "/"},
+	{Source: &spb.VName{Signature: "b"}, FactName: "/kythe/node/kind", FactValue: []byte("package")},
+}
+
+// sharded adds a trivial single-shard implementation of graphstore.Sharded to
+// a graphstore.Service.
+type sharded struct{ graphstore.Service }
+
+func (s sharded) Count(ctx context.Context, req *spb.CountRequest) (n int64, err error) {
+	err = s.Scan(ctx, new(spb.ScanRequest), func(*spb.Entry) error { n++; return nil })
+	return
+}
+
+func (s sharded) Shard(ctx context.Context, req *spb.ShardRequest, f graphstore.EntryFunc) error {
+	return s.Scan(ctx, new(spb.ScanRequest), f)
+}
+
+// serve starts a gRPC server for gs and returns its address.
+func serve(t *testing.T, gs graphstore.Service) (string, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	Register(srv, gs)
+	go srv.Serve(lis)
+	return lis.Addr().String(), srv.Stop
+}
+
+func writeEntries(ctx context.Context, t *testing.T, gs graphstore.Service) {
+	for req := range graphstore.BatchWrites(entryChan(testEntries), 2) {
+		if err := gs.Write(ctx, req); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+}
+
+func entryChan(entries []*spb.Entry) <-chan *spb.Entry {
+	ch := make(chan *spb.Entry, len(entries))
+	for _, e := range entries {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
+
+func collect(t *testing.T, method string, call func(graphstore.EntryFunc) error) []*spb.Entry {
+	var got []*spb.Entry
+	if err := call(func(e *spb.Entry) error {
+		got = append(got, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	return got
+}
+
+func checkEntries(t *testing.T, method string, got, want []*spb.Entry) {
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d entries, want %d: %v", method, len(got), len(want), got)
+		return
+	}
+	for i, e := range got {
+		if !proto.Equal(e, want[i]) {
+			t.Errorf("%s: entry %d: got %v, want %v", method, i, e, want[i])
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	addr, stop := serve(t, sharded{new(inmemory.GraphStore)})
+	defer stop()
+
+	gs, err := gsutil.ParseGraphStore("grpc:" + addr)
+	if err != nil {
+		t.Fatalf("ParseGraphStore: %v", err)
+	}
+	defer gs.Close(ctx)
+	writeEntries(ctx, t, gs)
+
+	checkEntries(t, "Read", collect(t, "Read", func(f graphstore.EntryFunc) error {
+		return gs.Read(ctx, &spb.ReadRequest{Source: &spb.VName{Signature: "a"}}, f)
+	}), testEntries[:2])
+	checkEntries(t, "Read", collect(t, "Read", func(f graphstore.EntryFunc) error {
+		return gs.Read(ctx, &spb.ReadRequest{Source: &spb.VName{Signature: "a"}, EdgeKind: "*"}, f)
+	}), testEntries[:3])
+	checkEntries(t, "Scan", collect(t, "Scan", func(f graphstore.EntryFunc) error {
+		return gs.Scan(ctx, &spb.ScanRequest{FactPrefix: "/kythe/node/"}, f)
+	}), []*spb.Entry{testEntries[0], testEntries[3]})
+
+	sgs := gs.(graphstore.Sharded)
+	if n, err := sgs.Count(ctx, &spb.CountRequest{Index: 0, Shards: 1}); err != nil {
+		t.Errorf("Count: %v", err)
+	} else if n != int64(len(testEntries)) {
+		t.Errorf("Count: got %d, want %d", n, len(testEntries))
+	}
+	checkEntries(t, "Shard", collect(t, "Shard", func(f graphstore.EntryFunc) error {
+		return sgs.Shard(ctx, &spb.ShardRequest{Index: 0, Shards: 1}, f)
+	}), testEntries)
+
+	// Returning io.EOF from the callback stops the stream without error.
+	var n int
+	if err := gs.Scan(ctx, new(spb.ScanRequest), func(*spb.Entry) error {
+		n++
+		return io.EOF
+	}); err != nil {
+		t.Errorf("Scan: unexpected error: %v", err)
+	} else if n != 1 {
+		t.Errorf("Scan: got %d entries after io.EOF, want 1", n)
+	}
+}
+
+func TestUnsharded(t *testing.T) {
+	ctx := context.Background()
+	addr, stop := serve(t, new(inmemory.GraphStore))
+	defer stop()
+
+	c, err := Dial(addr)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer c.Close(ctx)
+
+	if _, err := c.Count(ctx, &spb.CountRequest{Index: 0, Shards: 1}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Count: got error %v, want code %v", err, codes.Unimplemented)
+	}
+	if err := c.Shard(ctx, &spb.ShardRequest{Index: 0, Shards: 1}, func(*spb.Entry) error {
+		return nil
+	}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Shard: got error %v, want code %v", err, codes.Unimplemented)
+	}
+}
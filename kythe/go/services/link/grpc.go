@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package link
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	linkpb "kythe.io/kythe/proto/link_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/link.proto.
+const ServiceName = "kythe.proto.LinkService"
+
+var resolveMethod = grpcutil.MethodName(ServiceName, "Resolve")
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "Resolve",
+			func() interface{} { return new(linkpb.LinkRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Resolve(ctx, req.(*linkpb.LinkRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/link.proto",
+}
+
+// RegisterGRPC registers ls with s as the kythe.proto.LinkService service.
+func RegisterGRPC(s *grpc.Server, ls Service) { s.RegisterService(&serviceDesc, ls) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// Resolve implements part of the Service interface.
+func (c *grpcClient) Resolve(ctx context.Context, req *linkpb.LinkRequest) (*linkpb.LinkReply, error) {
+	reply := new(linkpb.LinkReply)
+	if err := c.cc.Invoke(ctx, resolveMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns a link Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
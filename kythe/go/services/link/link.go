@@ -21,10 +21,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
 	"sort"
 	"time"
 
+	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
@@ -41,6 +43,12 @@ import (
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
 
+// Service describes the interface of the link resolver service.
+type Service interface {
+	// Resolve returns the links matching the given request.
+	Resolve(context.Context, *linkpb.LinkRequest) (*linkpb.LinkReply, error)
+}
+
 // A Resolver implements the link service resolver by dispatching to a Kythe
 // XRefService and IdentifierService to resolve qualified names.
 type Resolver struct {
@@ -374,3 +382,62 @@ func findAnchors(ticket string, rsp *xpb.CrossReferencesReply, include, exclude
 
 	return result
 }
+
+// RegisterHTTPHandlers registers a JSON HTTP handler with mux using the given
+// link Service.  The following method will be exposed:
+//
+//   GET /link
+//     Request: JSON encoded kythe.proto.LinkRequest
+//     Response: JSON encoded kythe.proto.LinkReply
+//
+// Note: /link will return its response as a serialized protobuf if the "proto"
+// query parameter is set.  Errors reported with a gRPC status are mapped onto
+// the corresponding HTTP status code (e.g. NotFound is reported as 404).
+func RegisterHTTPHandlers(ctx context.Context, s Service, mux *http.ServeMux) {
+	mux.HandleFunc("/link", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("link.Resolve:\t%s", time.Since(start))
+		}()
+		var req linkpb.LinkRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := s.Resolve(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+// httpStatus returns the HTTP status code corresponding to the gRPC status of
+// err.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type webClient struct{ addr string }
+
+// Resolve implements part of the Service interface.
+func (w *webClient) Resolve(ctx context.Context, q *linkpb.LinkRequest) (*linkpb.LinkReply, error) {
+	var reply linkpb.LinkReply
+	return &reply, web.Call(w.addr, "link", q, &reply)
+}
+
+// WebClient returns a link Service based on a remote web server.
+func WebClient(addr string) Service {
+	return &webClient{addr}
+}
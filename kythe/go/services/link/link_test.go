@@ -19,6 +19,9 @@ package link
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -196,3 +199,55 @@ func TestResolve(t *testing.T) {
 		test.check(res.Resolve(ctx, test.req))
 	}
 }
+
+func TestHTTP(t *testing.T) {
+	ctx := context.Background()
+	res := &Resolver{Client: fakeClient{
+		found: &ipb.FindReply{
+			Matches: []*ipb.FindReply_Match{{Ticket: "kythe://test?lang=qq#X"}},
+		},
+		xrefs: &xpb.CrossReferencesReply{
+			CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+				"kythe://test?lang=qq#X": {
+					Ticket: "kythe://test?lang=qq#X",
+					Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{
+						Anchor: &xpb.Anchor{
+							Ticket: "kythe://test?lang=qq?path=foo#blah",
+							Kind:   "/kythe/edge/defines/binding",
+							Parent: "kythe://test?path=foo",
+							Span: &cpb.Span{
+								Start: &cpb.Point{LineNumber: 10},
+								End:   &cpb.Point{LineNumber: 11},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(ctx, res, mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := WebClient(srv.URL)
+
+	req := &linkpb.LinkRequest{Identifier: "baz", IncludeNodes: true}
+	want, err := res.Resolve(ctx, req)
+	if err != nil {
+		t.Fatalf("Resolve(baz): unexpected error: %v", err)
+	}
+	if got, err := client.Resolve(ctx, req); err != nil {
+		t.Errorf("WebClient.Resolve(baz): unexpected error: %v", err)
+	} else if !proto.Equal(got, want) {
+		t.Errorf("WebClient.Resolve(baz):\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// Errors from the resolver should be reported with a matching HTTP status.
+	if _, err := client.Resolve(ctx, new(linkpb.LinkRequest)); err == nil || !strings.Contains(err.Error(), "code 400") {
+		t.Errorf("WebClient.Resolve(): got %v, want a 400 error", err)
+	}
+	req.NodeKind = []string{"nonesuch"}
+	if _, err := client.Resolve(ctx, req); err == nil || !strings.Contains(err.Error(), "code 404") {
+		t.Errorf("WebClient.Resolve(nonesuch): got %v, want a 404 error", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/xref.proto.
+const ServiceName = "kythe.proto.XRefService"
+
+var (
+	decorationsMethod     = grpcutil.MethodName(ServiceName, "Decorations")
+	crossReferencesMethod = grpcutil.MethodName(ServiceName, "CrossReferences")
+	documentationMethod   = grpcutil.MethodName(ServiceName, "Documentation")
+)
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "Decorations",
+			func() interface{} { return new(xpb.DecorationsRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Decorations(ctx, req.(*xpb.DecorationsRequest))
+			}),
+		grpcutil.UnaryMethod(ServiceName, "CrossReferences",
+			func() interface{} { return new(xpb.CrossReferencesRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).CrossReferences(ctx, req.(*xpb.CrossReferencesRequest))
+			}),
+		grpcutil.UnaryMethod(ServiceName, "Documentation",
+			func() interface{} { return new(xpb.DocumentationRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Documentation(ctx, req.(*xpb.DocumentationRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/xref.proto",
+}
+
+// RegisterGRPC registers xs with s as the kythe.proto.XRefService service.
+func RegisterGRPC(s *grpc.Server, xs Service) { s.RegisterService(&serviceDesc, xs) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// Decorations implements part of the Service interface.
+func (c *grpcClient) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	reply := new(xpb.DecorationsReply)
+	if err := c.cc.Invoke(ctx, decorationsMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// CrossReferences implements part of the Service interface.
+func (c *grpcClient) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	reply := new(xpb.CrossReferencesReply)
+	if err := c.cc.Invoke(ctx, crossReferencesMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Documentation implements part of the Service interface.
+func (c *grpcClient) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	reply := new(xpb.DocumentationReply)
+	if err := c.cc.Invoke(ctx, documentationMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns an xrefs Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
@@ -168,6 +168,10 @@ const (
 	internalDeclarationKind    = internalKindPrefix + "ref/declare"
 )
 
+// DirectCallerKind is the kind of a serving table's cross-references group
+// holding the direct callers of a node.
+const DirectCallerKind = internalCallerKindDirect
+
 // IsInternalKind determines whether the given edge kind is an internal variant.
 func IsInternalKind(kind string) bool {
 	return strings.HasPrefix(kind, internalKindPrefix)
@@ -14,8 +14,8 @@
  * limitations under the License.
  */
 
-// Package api provides a union of the filetree, xrefs, and graph interfaces
-// and a command-line flag parser.
+// Package api provides a union of the filetree, xrefs, graph, identifiers,
+// status and link interfaces and a command-line flag parser.
 package api
 
 import (
@@ -28,17 +28,25 @@ import (
 
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/link"
 	"kythe.io/kythe/go/services/xrefs"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
 	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/status"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
 
+	"google.golang.org/grpc"
+
 	ftpb "kythe.io/kythe/proto/filetree_go_proto"
 	gpb "kythe.io/kythe/proto/graph_go_proto"
 	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	linkpb "kythe.io/kythe/proto/link_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
 
@@ -49,6 +57,8 @@ type Interface interface {
 	graph.Service
 	filetree.Service
 	identifiers.Service
+	status.Service
+	link.Service
 }
 
 const (
@@ -56,7 +66,7 @@ const (
 	CommonDefault = "https://xrefs-dot-kythe-repo.appspot.com"
 
 	// CommonFlagUsage is the common Kythe usage description used for Flag
-	CommonFlagUsage = "Backing API specification (e.g. JSON HTTP server: https://xrefs-dot-kythe-repo.appspot.com or local serving table path: /var/kythe_serving)"
+	CommonFlagUsage = "Backing API specification (e.g. JSON HTTP server: https://xrefs-dot-kythe-repo.appspot.com, gRPC server: grpc://localhost:8081, or local serving table path: /var/kythe_serving or goleveldb:/var/kythe_serving)"
 )
 
 // Flag defines an api Interface flag with specified name, default value, and
@@ -73,7 +83,11 @@ func Flag(name, value, usage string) *Interface {
 // API Interface.  The following formats are currently supported:
 //   - http:// URL pointed at a JSON web API
 //   - https:// URL pointed at a JSON web API
+//   - grpc:// followed by the host:port of a gRPC server (e.g. http_server
+//     with --grpc_listen); the connection is insecure
 //   - local path to a LevelDB serving table
+//   - goleveldb: followed by a local path to a LevelDB serving table, which is
+//     opened with the pure-Go LevelDB implementation
 func ParseSpec(apiSpec string) (Interface, error) {
 	api := &apiCloser{}
 	if strings.HasPrefix(apiSpec, "http://") || strings.HasPrefix(apiSpec, "https://") {
@@ -81,24 +95,57 @@ func ParseSpec(apiSpec string) (Interface, error) {
 		api.gs = graph.WebClient(apiSpec)
 		api.ft = filetree.WebClient(apiSpec)
 		api.id = identifiers.WebClient(apiSpec)
+		api.st = status.WebClient(apiSpec)
+		api.ln = link.WebClient(apiSpec)
+	} else if addr := strings.TrimPrefix(apiSpec, grpcPrefix); addr != apiSpec {
+		cc, err := grpc.Dial(addr, grpc.WithInsecure())
+		if err != nil {
+			return nil, fmt.Errorf("error dialing gRPC server at %q: %v", addr, err)
+		}
+		api.closer = cc.Close
+		api.xs = xrefs.GRPCClient(cc)
+		api.gs = graph.GRPCClient(cc)
+		api.ft = filetree.GRPCClient(cc)
+		api.id = identifiers.GRPCClient(cc)
+		api.st = status.GRPCClient(cc)
+		api.ln = link.GRPCClient(cc)
+	} else if path := strings.TrimPrefix(apiSpec, goLevelDBPrefix); path != apiSpec {
+		db, err := goleveldb.Open(path, &goleveldb.Options{MustExist: true})
+		if err != nil {
+			return nil, fmt.Errorf("error opening local DB at %q: %v", path, err)
+		}
+		api.setTable(db)
 	} else if _, err := os.Stat(apiSpec); err == nil {
 		db, err := leveldb.Open(apiSpec, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error opening local DB at %q: %v", apiSpec, err)
 		}
-		api.closer = func() error { return db.Close() }
-
-		tbl := &table.KVProto{db}
-		api.xs = xsrv.NewCombinedTable(tbl)
-		api.gs = gsrv.NewCombinedTable(tbl)
-		api.ft = &ftsrv.Table{tbl, true}
-		api.id = &identifiers.Table{tbl}
+		api.setTable(db)
 	} else {
 		return nil, fmt.Errorf("unknown API spec format: %q", apiSpec)
 	}
 	return api, nil
 }
 
+const (
+	goLevelDBPrefix = "goleveldb:"
+	grpcPrefix      = "grpc://"
+)
+
+// setTable sets each of the services of api to use the serving table in db,
+// and arranges for db to be closed when api is closed.
+func (api *apiCloser) setTable(db keyvalue.DB) {
+	api.closer = func() error { return db.Close() }
+
+	tbl := &table.KVProto{db}
+	api.xs = xsrv.NewCombinedTable(tbl)
+	api.gs = gsrv.NewCombinedTable(tbl)
+	api.ft = &ftsrv.Table{tbl, true}
+	api.id = &identifiers.Table{tbl}
+	api.st = &status.Table{Proto: tbl}
+	api.ln = &link.Resolver{Client: api}
+}
+
 type apiFlag struct {
 	spec string
 	api  Interface
@@ -124,6 +171,8 @@ type apiCloser struct {
 	gs graph.Service
 	ft filetree.Service
 	id identifiers.Service
+	st status.Service
+	ln link.Service
 
 	closer func() error
 }
@@ -175,3 +224,13 @@ func (api apiCloser) CorpusRoots(ctx context.Context, req *ftpb.CorpusRootsReque
 func (api apiCloser) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
 	return api.id.Find(ctx, req)
 }
+
+// Status implements part of the status Service interface.
+func (api apiCloser) Status(ctx context.Context, req *stpb.StatusRequest) (*stpb.StatusReply, error) {
+	return api.st.Status(ctx, req)
+}
+
+// Resolve implements part of the link Service interface.
+func (api apiCloser) Resolve(ctx context.Context, req *linkpb.LinkRequest) (*linkpb.LinkReply, error) {
+	return api.ln.Resolve(ctx, req)
+}
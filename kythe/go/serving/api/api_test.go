@@ -0,0 +1,210 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"kythe.io/kythe/go/services/filetree"
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/link"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/status"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	linkpb "kythe.io/kythe/proto/link_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// fakeService records the last request made to each method and returns canned
+// replies.
+type fakeService struct{ lastRequest proto.Message }
+
+var (
+	testTicket = "kythe://corpus?path=file#sig"
+
+	testNodesReply = &gpb.NodesReply{Nodes: map[string]*cpb.NodeInfo{
+		testTicket: {Facts: map[string][]byte{"/kythe/node/kind": []byte("record")}},
+	}}
+	testEdgesReply = &gpb.EdgesReply{EdgeSets: map[string]*gpb.EdgeSet{
+		testTicket: {Groups: map[string]*gpb.EdgeSet_Group{
+			"/kythe/edge/childof": {Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: testTicket, Ordinal: 1}}},
+		}},
+	}}
+	testDecorationsReply     = &xpb.DecorationsReply{Location: &xpb.Location{Ticket: testTicket}, SourceText: []byte("text")}
+	testCrossReferencesReply = &xpb.CrossReferencesReply{NextPageToken: "next"}
+	testDocumentationReply   = &xpb.DocumentationReply{Document: []*xpb.DocumentationReply_Document{{Ticket: testTicket}}}
+	testCorpusRootsReply     = &ftpb.CorpusRootsReply{Corpus: []*ftpb.CorpusRootsReply_Corpus{{Name: "corpus", Root: []string{"root"}}}}
+	testDirectoryReply       = &ftpb.DirectoryReply{Subdirectory: []string{"dir"}, File: []string{"file"}}
+	testFindReply            = &ipb.FindReply{Matches: []*ipb.FindReply_Match{{Ticket: testTicket, BaseName: "id"}}}
+	testLinkReply            = &linkpb.LinkReply{Links: []*linkpb.Link{{FileTicket: "kythe://corpus?path=file"}}}
+	testStatusReply          = &stpb.StatusReply{Origins: []*stpb.StatusReply_Origin{{Corpus: "corpus", Revision: "rev"}}, IndexVersion: "v1"}
+)
+
+func (f *fakeService) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	f.lastRequest = req
+	return testNodesReply, nil
+}
+
+func (f *fakeService) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	f.lastRequest = req
+	return testEdgesReply, nil
+}
+
+func (f *fakeService) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	f.lastRequest = req
+	return testDecorationsReply, nil
+}
+
+func (f *fakeService) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	f.lastRequest = req
+	return testCrossReferencesReply, nil
+}
+
+func (f *fakeService) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	f.lastRequest = req
+	return testDocumentationReply, nil
+}
+
+func (f *fakeService) CorpusRoots(ctx context.Context, req *ftpb.CorpusRootsRequest) (*ftpb.CorpusRootsReply, error) {
+	f.lastRequest = req
+	return testCorpusRootsReply, nil
+}
+
+func (f *fakeService) Directory(ctx context.Context, req *ftpb.DirectoryRequest) (*ftpb.DirectoryReply, error) {
+	f.lastRequest = req
+	return testDirectoryReply, nil
+}
+
+func (f *fakeService) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	f.lastRequest = req
+	if req.Identifier == "missing" {
+		return nil, grpcstatus.Error(codes.NotFound, "identifier not found")
+	}
+	return testFindReply, nil
+}
+
+func (f *fakeService) Status(ctx context.Context, req *stpb.StatusRequest) (*stpb.StatusReply, error) {
+	f.lastRequest = req
+	return testStatusReply, nil
+}
+
+func (f *fakeService) Resolve(ctx context.Context, req *linkpb.LinkRequest) (*linkpb.LinkReply, error) {
+	f.lastRequest = req
+	return testLinkReply, nil
+}
+
+func TestGRPCSpec(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	fake := &fakeService{}
+	srv := grpc.NewServer()
+	xrefs.RegisterGRPC(srv, fake)
+	graph.RegisterGRPC(srv, fake)
+	filetree.RegisterGRPC(srv, fake)
+	identifiers.RegisterGRPC(srv, fake)
+	status.RegisterGRPC(srv, fake)
+	link.RegisterGRPC(srv, fake)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	api, err := ParseSpec("grpc://" + lis.Addr().String())
+	if err != nil {
+		t.Fatalf("ParseSpec: %v", err)
+	}
+	defer api.Close()
+
+	ctx := context.Background()
+	tests := []struct {
+		req, reply proto.Message
+		call       func() (proto.Message, error)
+	}{{
+		&gpb.NodesRequest{Ticket: []string{testTicket}}, testNodesReply,
+		func() (proto.Message, error) { return api.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{testTicket}}) },
+	}, {
+		&gpb.EdgesRequest{Ticket: []string{testTicket}, PageSize: 10}, testEdgesReply,
+		func() (proto.Message, error) {
+			return api.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{testTicket}, PageSize: 10})
+		},
+	}, {
+		&xpb.DecorationsRequest{Location: &xpb.Location{Ticket: testTicket}, References: true}, testDecorationsReply,
+		func() (proto.Message, error) {
+			return api.Decorations(ctx, &xpb.DecorationsRequest{Location: &xpb.Location{Ticket: testTicket}, References: true})
+		},
+	}, {
+		&xpb.CrossReferencesRequest{Ticket: []string{testTicket}}, testCrossReferencesReply,
+		func() (proto.Message, error) {
+			return api.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{testTicket}})
+		},
+	}, {
+		&xpb.DocumentationRequest{Ticket: []string{testTicket}}, testDocumentationReply,
+		func() (proto.Message, error) {
+			return api.Documentation(ctx, &xpb.DocumentationRequest{Ticket: []string{testTicket}})
+		},
+	}, {
+		&ftpb.CorpusRootsRequest{}, testCorpusRootsReply,
+		func() (proto.Message, error) { return api.CorpusRoots(ctx, &ftpb.CorpusRootsRequest{}) },
+	}, {
+		&ftpb.DirectoryRequest{Corpus: "corpus", Root: "root", Path: "/"}, testDirectoryReply,
+		func() (proto.Message, error) {
+			return api.Directory(ctx, &ftpb.DirectoryRequest{Corpus: "corpus", Root: "root", Path: "/"})
+		},
+	}, {
+		&ipb.FindRequest{Identifier: "id"}, testFindReply,
+		func() (proto.Message, error) { return api.Find(ctx, &ipb.FindRequest{Identifier: "id"}) },
+	}, {
+		&stpb.StatusRequest{}, testStatusReply,
+		func() (proto.Message, error) { return api.Status(ctx, &stpb.StatusRequest{}) },
+	}, {
+		&linkpb.LinkRequest{Identifier: "id", IncludeNodes: true}, testLinkReply,
+		func() (proto.Message, error) {
+			return api.Resolve(ctx, &linkpb.LinkRequest{Identifier: "id", IncludeNodes: true})
+		},
+	}}
+
+	for _, test := range tests {
+		reply, err := test.call()
+		if err != nil {
+			t.Errorf("Error calling with %T: %v", test.req, err)
+			continue
+		}
+		if !proto.Equal(fake.lastRequest, test.req) {
+			t.Errorf("Server received %v; expected %v", fake.lastRequest, test.req)
+		}
+		if !proto.Equal(reply, test.reply) {
+			t.Errorf("Received reply %v; expected %v", reply, test.reply)
+		}
+	}
+
+	if reply, err := api.Find(ctx, &ipb.FindRequest{Identifier: "missing"}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error; found %v %v", reply, err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identifiers
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/identifier.proto.
+const ServiceName = "kythe.proto.IdentifierService"
+
+var findMethod = grpcutil.MethodName(ServiceName, "Find")
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "Find",
+			func() interface{} { return new(ipb.FindRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Find(ctx, req.(*ipb.FindRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/identifier.proto",
+}
+
+// RegisterGRPC registers id with s as the kythe.proto.IdentifierService service.
+func RegisterGRPC(s *grpc.Server, id Service) { s.RegisterService(&serviceDesc, id) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// Find implements part of the Service interface.
+func (c *grpcClient) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	reply := new(ipb.FindReply)
+	if err := c.cc.Invoke(ctx, findMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns an identifiers Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
@@ -18,12 +18,19 @@
 // identifiers.Service.
 // The table is structured as:
 // 		qualifed_name -> IdentifierMatch
+// 		"idgram:" trigram -> IdentifierPostings
+// 		"idabbrev:" abbreviation -> IdentifierPostings
+// 		key "#" shard -> IdentifierPostings
+// where the IdentifierPostings form the search index used by fuzzy requests
+// (see PostingsWriter and IndexBuilder).
 package identifiers
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"kythe.io/kythe/go/services/web"
@@ -48,6 +55,23 @@ type Table struct {
 
 // Find implements the Service interface for Table
 func (it *Table) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	var (
+		reply *ipb.FindReply
+		err   error
+	)
+	if req.GetFuzzy() {
+		reply, err = it.search(ctx, req)
+	} else {
+		reply, err = it.find(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return paginate(reply, req)
+}
+
+// find returns the nodes whose qualified name is exactly req.Identifier.
+func (it *Table) find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
 	var (
 		qname     = req.GetIdentifier()
 		corpora   = req.GetCorpus()
@@ -96,6 +120,49 @@ func validCorpusAndLang(corpora, langs []string, node *srvpb.IdentifierMatch_Nod
 	return true
 }
 
+// defaultFuzzyPageSize is the number of matches returned by a fuzzy request
+// that does not specify a page_size.
+const defaultFuzzyPageSize = 100
+
+// pageRange returns the index of the first match of the page requested by req
+// and the page's size.  A size <= 0 requests all remaining matches.
+func pageRange(req *ipb.FindRequest) (start, pageSize int, err error) {
+	if t := req.GetPageToken(); t != "" {
+		n, err := strconv.Atoi(t)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid page_token: %q", t)
+		}
+		start = n
+	}
+	pageSize = int(req.GetPageSize())
+	if pageSize <= 0 && req.GetFuzzy() {
+		pageSize = defaultFuzzyPageSize
+	}
+	return start, pageSize, nil
+}
+
+// paginate restricts the matches of reply to the page requested by req.
+func paginate(reply *ipb.FindReply, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	start, pageSize, err := pageRange(req)
+	if err != nil {
+		return nil, err
+	}
+	if start == 0 && (pageSize <= 0 || len(reply.Matches) <= pageSize) {
+		return reply, nil
+	}
+
+	if start > len(reply.Matches) {
+		start = len(reply.Matches)
+	}
+	end := len(reply.Matches)
+	if pageSize > 0 && start+pageSize < end {
+		end = start + pageSize
+		reply.NextPageToken = strconv.Itoa(end)
+	}
+	reply.Matches = reply.Matches[start:end]
+	return reply, nil
+}
+
 func contains(haystack []string, needle string) bool {
 	for _, s := range haystack {
 		if s == needle {
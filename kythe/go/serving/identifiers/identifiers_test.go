@@ -18,6 +18,7 @@ package identifiers
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -137,3 +138,167 @@ func (t testProtoTable) Lookup(_ context.Context, key []byte, msg proto.Message)
 func (t testProtoTable) Buffered() table.BufferedProto { panic("UNIMPLEMENTED") }
 
 func (t testProtoTable) Close(_ context.Context) error { return nil }
+
+func TestFindFuzzy(t *testing.T) {
+	ctx := context.Background()
+	tbl := testProtoTable{}
+	var b IndexBuilder
+	for _, m := range []*srvpb.IdentifierMatch{
+		identMatch("kythe.io/kythe/go/platform/kzip.NewWriter", "NewWriter", "kythe://kythe?lang=go#kzip.NewWriter"),
+		identMatch("kythe.io/kythe/go/platform/kzip.NewWriteCloser", "NewWriteCloser", "kythe://kythe?lang=go#kzip.NewWriteCloser"),
+		identMatch("kythe.io/kythe/go/platform/kzip.NewReader", "NewReader", "kythe://kythe?lang=go#kzip.NewReader"),
+		identMatch("kythe.io/kythe/go/util/riegeli.NewWriter", "NewWriter", "kythe://kythe?lang=go#riegeli.NewWriter"),
+		identMatch("java.io.Writer", "Writer", "kythe://jdk?lang=java#java.io.Writer"),
+	} {
+		tbl.Put(ctx, []byte(m.QualifiedName), m)
+		b.Add(m)
+	}
+	if err := b.Write(ctx, tbl); err != nil {
+		t.Fatalf("Error writing index: %v", err)
+	}
+	it := &Table{tbl}
+
+	tests := []struct {
+		req  *ipb.FindRequest
+		want []string
+		next string
+	}{{
+		req: &ipb.FindRequest{Identifier: "kzip newwri"},
+		want: []string{
+			"kythe.io/kythe/go/platform/kzip.NewWriter",
+			"kythe.io/kythe/go/platform/kzip.NewWriteCloser",
+		},
+	}, {
+		// Camel-hump abbreviations are case-insensitive.
+		req: &ipb.FindRequest{Identifier: "NW"},
+		want: []string{
+			"kythe.io/kythe/go/util/riegeli.NewWriter",
+			"kythe.io/kythe/go/platform/kzip.NewWriter",
+			"kythe.io/kythe/go/platform/kzip.NewWriteCloser",
+		},
+	}, {
+		req:  &ipb.FindRequest{Identifier: "kzip NeWrCl"},
+		want: []string{"kythe.io/kythe/go/platform/kzip.NewWriteCloser"},
+	}, {
+		// Exact base names are ranked before base name prefixes.
+		req: &ipb.FindRequest{Identifier: "writer"},
+		want: []string{
+			"java.io.Writer",
+			"kythe.io/kythe/go/util/riegeli.NewWriter",
+			"kythe.io/kythe/go/platform/kzip.NewWriter",
+		},
+	}, {
+		req:  &ipb.FindRequest{Identifier: "KZIP.NEWREADER"},
+		want: []string{"kythe.io/kythe/go/platform/kzip.NewReader"},
+	}, {
+		req:  &ipb.FindRequest{Identifier: "writer", Corpus: []string{"jdk"}},
+		want: []string{"java.io.Writer"},
+	}, {
+		req:  &ipb.FindRequest{Identifier: "writer", PageSize: 2},
+		want: []string{"java.io.Writer", "kythe.io/kythe/go/util/riegeli.NewWriter"},
+		next: "2",
+	}, {
+		req:  &ipb.FindRequest{Identifier: "writer", PageSize: 2, PageToken: "2"},
+		want: []string{"kythe.io/kythe/go/platform/kzip.NewWriter"},
+	}, {
+		req: &ipb.FindRequest{Identifier: "nonesuch"},
+	}, {
+		req: &ipb.FindRequest{Identifier: "n"},
+	}}
+
+	for _, test := range tests {
+		test.req.Fuzzy = true
+		reply, err := it.Find(ctx, test.req)
+		if err != nil {
+			t.Errorf("Find(%v): unexpected error: %v", test.req, err)
+			continue
+		}
+		var got []string
+		for _, m := range reply.Matches {
+			got = append(got, m.QualifiedName)
+		}
+		if err := testutil.DeepEqual(test.want, got); err != nil {
+			t.Errorf("Find(%v): %v", test.req, err)
+		}
+		if reply.NextPageToken != test.next {
+			t.Errorf("Find(%v): next_page_token %q; want %q", test.req, reply.NextPageToken, test.next)
+		}
+	}
+
+	if _, err := it.Find(ctx, &ipb.FindRequest{Identifier: "writer", Fuzzy: true, PageToken: "bogus"}); err == nil {
+		t.Error("Find with invalid page_token: expected error")
+	}
+}
+
+func TestFindFuzzyShardedPostings(t *testing.T) {
+	ctx := context.Background()
+	tbl := testProtoTable{}
+	var b IndexBuilder
+	add := func(m *srvpb.IdentifierMatch) {
+		tbl.Put(ctx, []byte(m.QualifiedName), m)
+		b.Add(m)
+	}
+	// Enough alphabetically-early prefix matches to shard the postings and
+	// exceed maxCandidates, followed by a single exact base name match.
+	for i := 0; i <= maxCandidates; i++ {
+		name := fmt.Sprintf("WriterFactory%05d", i)
+		add(identMatch("a."+name, name, "kythe:#"+name))
+	}
+	add(identMatch("z.Writer", "Writer", "kythe:#Writer"))
+	if err := b.Write(ctx, tbl); err != nil {
+		t.Fatalf("Error writing index: %v", err)
+	}
+
+	var first srvpb.IdentifierPostings
+	if err := tbl.Lookup(ctx, []byte(trigramPrefix+"wri"), &first); err != nil {
+		t.Fatalf("Lookup error: %v", err)
+	} else if want := (maxCandidates + 2 + postingsShardSize - 1) / postingsShardSize; int(first.ShardCount) != want {
+		t.Errorf("Found %d shards; want %d", first.ShardCount, want)
+	} else if len(first.QualifiedName) != postingsShardSize {
+		t.Errorf("Found %d postings in first shard; want %d", len(first.QualifiedName), postingsShardSize)
+	}
+
+	it := &Table{tbl}
+	reply, err := it.Find(ctx, &ipb.FindRequest{Identifier: "writer", Fuzzy: true, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Find error: %v", err)
+	}
+	var got []string
+	for _, m := range reply.Matches {
+		got = append(got, m.QualifiedName)
+	}
+	if err := testutil.DeepEqual([]string{"z.Writer", "a.WriterFactory00000"}, got); err != nil {
+		t.Error(err)
+	}
+	if reply.NextPageToken != "2" {
+		t.Errorf("next_page_token %q; want %q", reply.NextPageToken, "2")
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+	}{
+		{"NewWriter", []string{"new", "writer"}},
+		{"newWriter", []string{"new", "writer"}},
+		{"XMLReader", []string{"xml", "reader"}},
+		{"base64_encode", []string{"base64", "encode"}},
+		{"HTTP2Server", []string{"http2", "server"}},
+		{"x", []string{"x"}},
+		{"", nil},
+	}
+	for _, test := range tests {
+		if err := testutil.DeepEqual(test.words, splitWords(test.name)); err != nil {
+			t.Errorf("splitWords(%q): %v", test.name, err)
+		}
+	}
+}
+
+func identMatch(qname, base, ticket string) *srvpb.IdentifierMatch {
+	return &srvpb.IdentifierMatch{
+		QualifiedName: qname,
+		BaseName:      base,
+		Node:          []*srvpb.IdentifierMatch_Node{node(ticket, "function", "")},
+	}
+}
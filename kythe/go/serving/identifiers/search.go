@@ -0,0 +1,515 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identifiers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"bitbucket.org/creachadair/stringset"
+
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// Key prefixes of the identifier search index.  Each key maps to the
+// IdentifierPostings of the qualified names it indexes.
+const (
+	// A trigram key indexes each identifier whose lowercased qualified name
+	// contains the trigram.
+	trigramPrefix = "idgram:"
+
+	// An abbreviation key indexes each identifier whose lowercased base name
+	// begins with the abbreviation or whose base name's first two words begin
+	// with the abbreviation's two characters (e.g. "nw" for "NewWriter").
+	abbrevPrefix = "idabbrev:"
+)
+
+const (
+	// postingsShardSize is the maximum number of identifiers recorded in a
+	// single IdentifierPostings shard.
+	postingsShardSize = 1024
+
+	// maxPostings is the maximum number of postings read for a single fuzzy
+	// request.  Identifiers recorded past this point in the postings of the
+	// most selective term are not considered.
+	maxPostings = 128 * postingsShardSize
+
+	// maxCandidates is the maximum number of the best-scoring identifiers
+	// considered for a single fuzzy request.
+	maxCandidates = 10000
+)
+
+// SearchKeys returns the keys of the identifier search index under which the
+// identifier with the given qualified and base names is recorded.
+func SearchKeys(qualifiedName, baseName string) []string {
+	keys := stringset.New()
+	for _, g := range trigrams(strings.ToLower(qualifiedName)) {
+		keys.Add(trigramPrefix + g)
+	}
+	if base := strings.ToLower(baseName); len(base) >= 2 {
+		keys.Add(abbrevPrefix + base[:2])
+	}
+	if words := splitWords(baseName); len(words) >= 2 {
+		keys.Add(abbrevPrefix + words[0][:1] + words[1][:1])
+	}
+	return keys.Elements()
+}
+
+// postingsKey returns the table key of the given shard of the postings
+// recorded under an index key.
+func postingsKey(key string, shard int) string {
+	if shard == 0 {
+		return key
+	}
+	return key + "#" + strconv.Itoa(shard)
+}
+
+// A PostingsWriter splits the postings of a single index key into
+// IdentifierPostings shards of bounded size.  Identifiers must be added in
+// order of their qualified names; repeated names are ignored.
+type PostingsWriter struct {
+	key        string
+	emit       func(string, *srvpb.IdentifierPostings) error
+	first, cur *srvpb.IdentifierPostings
+	shards     int
+}
+
+// NewPostingsWriter returns a PostingsWriter that passes each shard of the
+// postings for key to emit along with its table key.
+func NewPostingsWriter(key string, emit func(string, *srvpb.IdentifierPostings) error) *PostingsWriter {
+	first := &srvpb.IdentifierPostings{}
+	return &PostingsWriter{key: key, emit: emit, first: first, cur: first, shards: 1}
+}
+
+// Add records the identifier with the given names in the postings.
+func (w *PostingsWriter) Add(qualifiedName, baseName string) error {
+	if n := len(w.cur.QualifiedName); n > 0 && w.cur.QualifiedName[n-1] == qualifiedName {
+		return nil
+	} else if n == postingsShardSize {
+		if w.cur != w.first {
+			if err := w.emit(postingsKey(w.key, w.shards-1), w.cur); err != nil {
+				return err
+			}
+		}
+		w.cur = &srvpb.IdentifierPostings{}
+		w.shards++
+	}
+	w.cur.QualifiedName = append(w.cur.QualifiedName, qualifiedName)
+	w.cur.BaseName = append(w.cur.BaseName, baseName)
+	return nil
+}
+
+// Close emits the remaining shards of the postings.
+func (w *PostingsWriter) Close() error {
+	if w.cur != w.first {
+		if err := w.emit(postingsKey(w.key, w.shards-1), w.cur); err != nil {
+			return err
+		}
+	}
+	w.first.ShardCount = int32(w.shards)
+	return w.emit(w.key, w.first)
+}
+
+// An IndexBuilder accumulates the identifier search index for a set of
+// IdentifierMatch records in memory.  The zero value is ready for use.
+type IndexBuilder struct {
+	postings map[string]map[string]string // key -> qualified name -> base name
+}
+
+// Add records the identifier described by m in the index.
+func (b *IndexBuilder) Add(m *srvpb.IdentifierMatch) {
+	if b.postings == nil {
+		b.postings = make(map[string]map[string]string)
+	}
+	for _, key := range SearchKeys(m.QualifiedName, m.BaseName) {
+		names, ok := b.postings[key]
+		if !ok {
+			names = make(map[string]string)
+			b.postings[key] = names
+		}
+		names[m.QualifiedName] = m.BaseName
+	}
+}
+
+// Write writes the IdentifierPostings of the index to t.
+func (b *IndexBuilder) Write(ctx context.Context, t table.Proto) error {
+	put := func(key string, p *srvpb.IdentifierPostings) error { return t.Put(ctx, []byte(key), p) }
+	for _, key := range stringset.FromKeys(b.postings).Elements() {
+		w := NewPostingsWriter(key, put)
+		names := b.postings[key]
+		for _, qname := range stringset.FromKeys(names).Elements() {
+			if err := w.Add(qname, names[qname]); err != nil {
+				return err
+			}
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// search returns the nodes of the identifiers matching the fuzzy query in
+// req.Identifier, ranked by how closely they match the query.  Matches are
+// only looked up until the page requested by req is filled.
+func (it *Table) search(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	terms := strings.Fields(strings.ToLower(req.GetIdentifier()))
+	if len(terms) == 0 {
+		return &ipb.FindReply{}, nil
+	}
+	start, pageSize, err := pageRange(req)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := it.candidates(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		score int
+		match *ipb.FindReply_Match
+	}
+	var results []result
+	for _, c := range candidates {
+		if pageSize > 0 && len(results) > start+pageSize {
+			// Later candidates rank below every result of the requested page
+			// and the result that indicates a following page.
+			break
+		}
+		var match srvpb.IdentifierMatch
+		if err := it.Lookup(ctx, []byte(c.qualifiedName), &match); err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, node := range match.Node {
+			if !validCorpusAndLang(req.GetCorpus(), req.GetLanguages(), node) {
+				continue
+			}
+			results = append(results, result{c.score, &ipb.FindReply_Match{
+				Ticket:        node.GetTicket(),
+				NodeKind:      node.GetNodeKind(),
+				NodeSubkind:   node.GetNodeSubkind(),
+				BaseName:      match.GetBaseName(),
+				QualifiedName: match.GetQualifiedName(),
+			}})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.score != b.score {
+			return a.score > b.score
+		} else if len(a.match.QualifiedName) != len(b.match.QualifiedName) {
+			return len(a.match.QualifiedName) < len(b.match.QualifiedName)
+		} else if a.match.QualifiedName != b.match.QualifiedName {
+			return a.match.QualifiedName < b.match.QualifiedName
+		}
+		return a.match.Ticket < b.match.Ticket
+	})
+	reply := &ipb.FindReply{Matches: make([]*ipb.FindReply_Match, len(results))}
+	for i, r := range results {
+		reply.Matches[i] = r.match
+	}
+	return reply, nil
+}
+
+// A candidate is an identifier matching each term of a fuzzy query.
+type candidate struct {
+	qualifiedName string
+	score         int
+}
+
+// candidates returns the identifiers matching all of the given lowercased
+// terms, ordered by decreasing score.
+//
+// Each term selects the identifiers recorded under its abbreviations and, for
+// terms of at least 3 bytes, under its rarest trigram (an identifier that
+// contains the term contains each of its trigrams).  Shorter terms only
+// select identifiers when no term is long enough, and single-byte terms never
+// select identifiers.  Only the postings of the most selective term are read;
+// every term is then verified against each of its identifiers by matchScore.
+func (it *Table) candidates(ctx context.Context, terms []string) ([]candidate, error) {
+	minLen := 2
+	for _, t := range terms {
+		if len(t) >= 3 {
+			minLen = 3
+			break
+		}
+	}
+
+	firsts := make(map[string]*srvpb.IdentifierPostings)
+	size := func(key string) (int, error) {
+		p, ok := firsts[key]
+		if !ok {
+			var err error
+			if p, err = it.postingsShard(ctx, key, 0); err != nil {
+				return 0, err
+			}
+			firsts[key] = p
+		}
+		if p.ShardCount > 1 {
+			return int(p.ShardCount) * postingsShardSize, nil
+		}
+		return len(p.QualifiedName), nil
+	}
+
+	var selected []string
+	selectedSize := -1
+	for _, t := range terms {
+		if len(t) < minLen {
+			continue
+		}
+		var keys []string
+		var total int
+		if len(t) >= 3 {
+			var rarest string
+			rarestSize := -1
+			for _, g := range trigrams(t) {
+				n, err := size(trigramPrefix + g)
+				if err != nil {
+					return nil, err
+				} else if rarestSize < 0 || n < rarestSize {
+					rarest, rarestSize = trigramPrefix+g, n
+				}
+				if n == 0 {
+					break
+				}
+			}
+			if rarestSize > 0 {
+				keys, total = append(keys, rarest), rarestSize
+			}
+		}
+		for _, abbrev := range abbreviations(t) {
+			n, err := size(abbrevPrefix + abbrev)
+			if err != nil {
+				return nil, err
+			} else if n > 0 {
+				keys, total = append(keys, abbrevPrefix+abbrev), total+n
+			}
+		}
+		if selectedSize < 0 || total < selectedSize {
+			selected, selectedSize = keys, total
+		}
+		if total == 0 {
+			break
+		}
+	}
+
+	names := make(map[string]string) // qualified name -> base name
+	var read int
+	for _, key := range selected {
+		p := firsts[key]
+		for shard := 0; read < maxPostings; {
+			for i, qname := range p.QualifiedName {
+				if i < len(p.BaseName) {
+					names[qname] = p.BaseName[i]
+				} else {
+					names[qname] = ""
+				}
+			}
+			read += len(p.QualifiedName)
+			if shard++; shard >= int(firsts[key].ShardCount) {
+				break
+			}
+			var err error
+			if p, err = it.postingsShard(ctx, key, shard); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var cs []candidate
+	for qname, base := range names {
+		if score := matchScore(terms, qname, base); score > 0 {
+			cs = append(cs, candidate{qname, score})
+		}
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.score != b.score {
+			return a.score > b.score
+		} else if len(a.qualifiedName) != len(b.qualifiedName) {
+			return len(a.qualifiedName) < len(b.qualifiedName)
+		}
+		return a.qualifiedName < b.qualifiedName
+	})
+	if len(cs) > maxCandidates {
+		cs = cs[:maxCandidates]
+	}
+	return cs, nil
+}
+
+// postingsShard returns the given shard of the postings recorded under an
+// index key.  An empty IdentifierPostings is returned for missing shards.
+func (it *Table) postingsShard(ctx context.Context, key string, shard int) (*srvpb.IdentifierPostings, error) {
+	var p srvpb.IdentifierPostings
+	if err := it.Lookup(ctx, []byte(postingsKey(key, shard)), &p); err != nil && err != table.ErrNoSuchKey {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Scores of the ways in which a search term may match an identifier.
+const (
+	exactBaseScore     = 100
+	basePrefixScore    = 80
+	humpScore          = 60
+	baseSubstringScore = 40
+	nameBoundaryScore  = 30
+	nameSubstringScore = 20
+
+	// exactNameScore is added when the whole query equals the qualified name.
+	exactNameScore = 200
+)
+
+// matchScore returns the rank of the identifier with the given names for the
+// lowercased search terms, or 0 if some term does not match the identifier.
+func matchScore(terms []string, qualifiedName, baseName string) int {
+	qname, base := strings.ToLower(qualifiedName), strings.ToLower(baseName)
+	words := splitWords(baseName)
+
+	var total int
+	for _, t := range terms {
+		var score int
+		switch {
+		case base == t:
+			score = exactBaseScore
+		case strings.HasPrefix(base, t):
+			score = basePrefixScore
+		case humpMatch(t, words):
+			score = humpScore
+		case strings.Contains(base, t):
+			score = baseSubstringScore
+		case containsAtBoundary(qname, t):
+			score = nameBoundaryScore
+		case strings.Contains(qname, t):
+			score = nameSubstringScore
+		default:
+			return 0
+		}
+		total += score
+	}
+	if strings.Join(terms, " ") == qname {
+		total += exactNameScore
+	}
+	return total
+}
+
+// containsAtBoundary reports whether t occurs in s at the start of s or just
+// after a character that is not a letter or digit.
+func containsAtBoundary(s, t string) bool {
+	for i := strings.Index(s, t); i >= 0; {
+		if i == 0 {
+			return true
+		}
+		if r, _ := utf8.DecodeLastRuneInString(s[:i]); !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return true
+		}
+		j := strings.Index(s[i+1:], t)
+		if j < 0 {
+			break
+		}
+		i += j + 1
+	}
+	return false
+}
+
+// humpMatch reports whether t is a concatenation of non-empty prefixes of
+// consecutive words, beginning with the first (e.g. "nw" or "newwri" for the
+// words of "NewWriter").
+func humpMatch(t string, words []string) bool {
+	if t == "" {
+		return true
+	} else if len(words) == 0 {
+		return false
+	}
+	w := words[0]
+	n := len(w)
+	if len(t) < n {
+		n = len(t)
+	}
+	for ; n > 0; n-- {
+		if t[:n] == w[:n] && humpMatch(t[n:], words[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// abbreviations returns the abbreviation keys that may index an identifier
+// matching the lowercased term t: its first two bytes, and its first byte
+// paired with each later byte (any of which may begin the second word of a
+// camel-hump match).
+func abbreviations(t string) []string {
+	if len(t) < 2 {
+		return nil
+	}
+	abbrevs := stringset.New()
+	for i := 1; i < len(t); i++ {
+		abbrevs.Add(t[:1] + t[i:i+1])
+	}
+	return abbrevs.Elements()
+}
+
+// splitWords splits name into its lowercased words, separated by characters
+// other than letters and digits and by camel-case boundaries (e.g. "XMLReader"
+// is split into "xml" and "reader").
+func splitWords(name string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	rs := []rune(name)
+	for i, r := range rs {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return words
+}
+
+// trigrams returns the distinct 3-byte substrings of s.
+func trigrams(s string) []string {
+	grams := stringset.New()
+	for i := 0; i+3 <= len(s); i++ {
+		grams.Add(s[i : i+3])
+	}
+	return grams.Elements()
+}
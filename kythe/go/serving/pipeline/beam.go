@@ -17,12 +17,14 @@
 package pipeline
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
 
 	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
 	"kythe.io/kythe/go/serving/pipeline/nodes"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
 	"kythe.io/kythe/go/util/compare"
@@ -45,28 +47,45 @@ import (
 func init() {
 	beam.RegisterFunction(completeDocument)
 	beam.RegisterFunction(defToDecorPiece)
+	beam.RegisterFunction(diagToDecorPieces)
 	beam.RegisterFunction(fileToDecorPiece)
-	beam.RegisterFunction(groupCrossRefs)
-	beam.RegisterFunction(groupEdges)
+	beam.RegisterFunction(fileToTags)
+	beam.RegisterFunction(groupIdentifiers)
+	beam.RegisterFunction(groupPostings)
+	beam.RegisterFunction(identifierSearchKeys)
 	beam.RegisterFunction(keyByPath)
+	beam.RegisterFunction(keyByScope)
 	beam.RegisterFunction(keyRef)
 	beam.RegisterFunction(moveSourceToKey)
 	beam.RegisterFunction(nodeToChildren)
 	beam.RegisterFunction(nodeToDecorPiece)
 	beam.RegisterFunction(nodeToDocs)
 	beam.RegisterFunction(nodeToEdges)
+	beam.RegisterFunction(nodeToIdentifier)
+	beam.RegisterFunction(nodeToOverrides)
+	beam.RegisterFunction(nodeToRelations)
 	beam.RegisterFunction(nodeToReverseEdges)
+	beam.RegisterFunction(overrideToDecorPieces)
 	beam.RegisterFunction(parseMarkedSource)
 	beam.RegisterFunction(refToDecorPiece)
 	beam.RegisterFunction(reverseEdge)
+	beam.RegisterFunction(toBinding)
+	beam.RegisterFunction(toCallers)
+	beam.RegisterFunction(toDefiningFile)
 	beam.RegisterFunction(toDefinition)
 	beam.RegisterFunction(toEnclosingFile)
+	beam.RegisterFunction(toFilePieces)
 	beam.RegisterFunction(toFiles)
 	beam.RegisterFunction(toRefs)
+	beam.RegisterFunction(toRelatedNodes)
+	beam.RegisterFunction(toTaggedAnchor)
 
 	beam.RegisterType(reflect.TypeOf((*combineDecorPieces)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupCrossRefs)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupEdges)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ticketKey)(nil)).Elem())
 
+	beam.RegisterType(reflect.TypeOf((*cpb.Diagnostic)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*cpb.MarkedSource)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ppb.DecorationPiece)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ppb.Edge)(nil)).Elem())
@@ -80,8 +99,12 @@ func init() {
 	beam.RegisterType(reflect.TypeOf((*srvpb.ExpandedAnchor)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.File)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDecorations)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileDecorations_Override)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDirectory)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.IdentifierMatch)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.IdentifierPostings)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences_Group)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences_Page)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedEdgeSet)(nil)).Elem())
 }
@@ -89,6 +112,11 @@ func init() {
 // KytheBeam controls the lifetime and generation of PCollections in the Kythe
 // pipeline.
 type KytheBeam struct {
+	// MaxPageSize is the maximum number of edges/cross-references that are
+	// allowed in each PagedEdgeSet, EdgePage, PagedCrossReferences, and
+	// PagedCrossReferences_Page.  If MaxPageSize <= 0, no paging is attempted.
+	MaxPageSize int
+
 	s beam.Scope
 
 	fileVNames beam.PCollection // *spb.VName
@@ -113,40 +141,256 @@ func FromEntries(s beam.Scope, entries beam.PCollection) *KytheBeam {
 // KV<string, *srvpb.PagedCrossReferences_Page>, respectively.
 func (k *KytheBeam) CrossReferences() (sets, pages beam.PCollection) {
 	s := k.s.Scope("CrossReferences")
-	refs := beam.GroupByKey(s, beam.ParDo(s, keyRef, k.References()))
-	// TODO(schroederc): related nodes
-	// TODO(schroederc): callers
-	// TODO(schroederc): MarkedSource
-	// TODO(schroederc): source_node
-	return beam.ParDo2(s, groupCrossRefs, refs)
+	refs := beam.ParDo(s, keyRef, k.References())
+	srcNodes := beam.ParDo(s, moveSourceToKey, beam.ParDo(s, &nodes.Filter{IncludeEdges: []string{}}, k.nodes))
+	return beam.ParDo2(s, &groupCrossRefs{MaxPageSize: k.MaxPageSize},
+		beam.CoGroupByKey(s, srcNodes, refs, k.relatedNodes(), k.callers()))
 }
 
-// groupCrossRefs emits *srvpb.PagedCrossReferences and *srvpb.PagedCrossReferences_Pages for a
-// single node's collection of *ppb.References.
-func groupCrossRefs(key *spb.VName, refStream func(**ppb.Reference) bool, emitSet func(string, *srvpb.PagedCrossReferences), emitPage func(string, *srvpb.PagedCrossReferences_Page)) {
-	set := &srvpb.PagedCrossReferences{SourceTicket: kytheuri.ToString(key)}
-	// TODO(schroederc): add paging
+// groupCrossRefs emits *srvpb.PagedCrossReferences and
+// *srvpb.PagedCrossReferences_Pages for a single node's collection of
+// *ppb.References, related nodes, and callers.
+type groupCrossRefs struct {
+	// MaxPageSize is the maximum number of cross-references in each set/page.
+	MaxPageSize int
+}
 
+func (g *groupCrossRefs) ProcessElement(ctx context.Context, key *spb.VName, nodeStream func(**ppb.Node) bool, refStream func(**ppb.Reference) bool, relatedStream, callerStream func(**srvpb.PagedCrossReferences_Group) bool, emitSet func(string, *srvpb.PagedCrossReferences), emitPage func(string, *srvpb.PagedCrossReferences_Page)) error {
 	groups := make(map[string]*srvpb.PagedCrossReferences_Group)
+	group := func(kind string) *srvpb.PagedCrossReferences_Group {
+		grp, ok := groups[kind]
+		if !ok {
+			grp = &srvpb.PagedCrossReferences_Group{Kind: kind}
+			groups[kind] = grp
+		}
+		return grp
+	}
 
 	var ref *ppb.Reference
 	for refStream(&ref) {
-		kind := refKind(ref)
-		g, ok := groups[kind]
-		if !ok {
-			g = &srvpb.PagedCrossReferences_Group{Kind: kind}
-			groups[kind] = g
-			set.Group = append(set.Group, g)
+		grp := group(refKind(ref))
+		grp.Anchor = append(grp.Anchor, ref.Anchor)
+	}
+	var single *srvpb.PagedCrossReferences_Group
+	for relatedStream(&single) {
+		grp := group(single.Kind)
+		grp.RelatedNode = append(grp.RelatedNode, single.RelatedNode...)
+	}
+	for callerStream(&single) {
+		grp := group(single.Kind)
+		grp.Caller = append(grp.Caller, single.Caller...)
+	}
+	if len(groups) == 0 {
+		return nil // the node has no cross-references
+	}
+
+	var src *srvpb.Node
+	var ms *cpb.MarkedSource
+	var n *ppb.Node
+	if nodeStream(&n) {
+		src = assemble.FilterTextFacts(convertPipelineNode(&ppb.Node{
+			Source:  key,
+			Kind:    n.Kind,
+			Subkind: n.Subkind,
+			Fact:    n.Fact,
+		}))
+		for _, f := range n.Fact {
+			if f.GetKytheName() == scpb.FactName_CODE {
+				ms = new(cpb.MarkedSource)
+				if err := proto.Unmarshal(f.Value, ms); err != nil {
+					return err
+				}
+				break
+			}
 		}
-		g.Anchor = append(g.Anchor, ref.Anchor)
 	}
 
-	sort.Slice(set.Group, func(i, j int) bool { return set.Group[i].Kind < set.Group[j].Kind })
-	for _, g := range set.Group {
-		sort.Slice(g.Anchor, func(i, j int) bool { return g.Anchor[i].Ticket < g.Anchor[j].Ticket })
+	xb := &assemble.CrossReferencesBuilder{
+		MaxPageSize: g.MaxPageSize,
+		Output: func(ctx context.Context, set *srvpb.PagedCrossReferences) error {
+			set.SourceNode = src
+			set.MarkedSource = ms
+			emitSet("xrefs:"+set.SourceTicket, set)
+			return nil
+		},
+		OutputPage: func(ctx context.Context, p *srvpb.PagedCrossReferences_Page) error {
+			emitPage("xrefPages:"+p.PageKey, p)
+			return nil
+		},
+	}
+	head := src
+	if head == nil {
+		head = &srvpb.Node{Ticket: kytheuri.ToString(key)}
+	}
+	if err := xb.StartSet(ctx, head); err != nil {
+		return err
 	}
 
-	emitSet("xrefs:"+set.SourceTicket, set)
+	groupKinds := make([]string, 0, len(groups))
+	for kind := range groups {
+		groupKinds = append(groupKinds, kind)
+	}
+	sort.Strings(groupKinds)
+	for _, kind := range groupKinds {
+		grp := groups[kind]
+		sort.Slice(grp.Anchor, func(i, j int) bool { return grp.Anchor[i].Ticket < grp.Anchor[j].Ticket })
+		sort.Slice(grp.RelatedNode, func(i, j int) bool {
+			return compare.Compare(grp.RelatedNode[i].Ordinal, grp.RelatedNode[j].Ordinal).
+				AndThen(grp.RelatedNode[i].Node.Ticket, grp.RelatedNode[j].Node.Ticket) == compare.LT
+		})
+		sort.Slice(grp.Caller, func(i, j int) bool { return grp.Caller[i].SemanticCaller < grp.Caller[j].SemanticCaller })
+		if err := xb.AddGroup(ctx, grp); err != nil {
+			return err
+		}
+	}
+	return xb.Flush(ctx)
+}
+
+// relatedNodes returns a collection of single-node
+// *srvpb.PagedCrossReferences_Groups keyed by the VName of the node to which
+// each is related.  Nodes are related by any non-anchor edge between two
+// non-anchor nodes; each edge relates its source to its target and vice versa.
+func (k *KytheBeam) relatedNodes() beam.PCollection {
+	s := k.s.Scope("RelatedNodes")
+	bareNodes := beam.ParDo(s, moveSourceToKey, beam.ParDo(s, &nodes.Filter{IncludeEdges: []string{}}, k.nodes))
+	relations := beam.ParDo(s, nodeToRelations, k.nodes)
+	return beam.ParDo(s, toRelatedNodes, beam.CoGroupByKey(s, bareNodes, k.directDefinitions(), relations))
+}
+
+// nodeToRelations emits an *ppb.Edge in both directions for each of n's
+// non-anchor edges.  Each *ppb.Edge is keyed by its Target (the related node);
+// its Source is the node whose cross-references include the related node.
+func nodeToRelations(n *ppb.Node, emit func(*spb.VName, *ppb.Edge)) {
+	if nodes.Kind(n) == kinds.Anchor {
+		return
+	}
+	for _, e := range n.Edge {
+		kind := nodes.EdgeKind(e)
+		if edges.IsAnchorEdge(kind) {
+			continue
+		}
+		emit(e.Target, &ppb.Edge{
+			Source:  n.Source,
+			Target:  e.Target,
+			Kind:    e.Kind,
+			Ordinal: e.Ordinal,
+		})
+		emit(n.Source, &ppb.Edge{
+			Source:  e.Target,
+			Target:  n.Source,
+			Kind:    &ppb.Edge_GenericKind{edges.Mirror(kind)},
+			Ordinal: e.Ordinal,
+		})
+	}
+}
+
+// toRelatedNodes emits a single-node *srvpb.PagedCrossReferences_Group, keyed
+// by the *ppb.Edge's Source, for each *ppb.Edge relating a node to the given
+// node.  The related node embeds its facts and definition, if known.
+func toRelatedNodes(key *spb.VName, nodeStream func(**ppb.Node) bool, defStream func(**srvpb.ExpandedAnchor) bool, edgeStream func(**ppb.Edge) bool, emit func(*spb.VName, *srvpb.PagedCrossReferences_Group)) {
+	related := &srvpb.Node{Ticket: kytheuri.ToString(key)}
+	var n *ppb.Node
+	if nodeStream(&n) {
+		if nodes.Kind(n) == kinds.Anchor {
+			return
+		}
+		related = assemble.FilterTextFacts(convertPipelineNode(&ppb.Node{
+			Source:  key,
+			Kind:    n.Kind,
+			Subkind: n.Subkind,
+			Fact:    n.Fact,
+		}))
+	}
+	var def *srvpb.ExpandedAnchor
+	if defStream(&def) {
+		// TODO(schroederc): select ambiguous definition better
+		related.DefinitionLocation = def
+	}
+
+	var e *ppb.Edge
+	for edgeStream(&e) {
+		emit(e.Source, &srvpb.PagedCrossReferences_Group{
+			Kind: nodes.EdgeKind(e),
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+				Node:    related,
+				Ordinal: e.Ordinal,
+			}},
+		})
+	}
+}
+
+// callers returns a collection of single-caller
+// *srvpb.PagedCrossReferences_Groups keyed by the VName of each callee.  A
+// caller is the scope (i.e. the childof parent) of a /kythe/edge/ref/call
+// anchor and must have a /kythe/edge/defines/binding anchor of its own.
+func (k *KytheBeam) callers() beam.PCollection {
+	s := k.s.Scope("Callers")
+	callsites := beam.ParDo(s, keyByScope, k.References())
+	bindings := beam.ParDo(s, toBinding, k.References())
+	markedSources := beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{facts.Code},
+		IncludeEdges: []string{},
+	}, parseMarkedSource)
+	return beam.ParDo(s, toCallers, beam.CoGroupByKey(s, bindings, markedSources, callsites))
+}
+
+// keyByScope emits each /kythe/edge/ref/call *ppb.Reference keyed by its
+// anchor's scope.
+func keyByScope(r *ppb.Reference, emit func(*spb.VName, *ppb.Reference)) {
+	if r.Scope != nil && edges.IsVariant(refKind(r), edges.RefCall) {
+		emit(r.Scope, &ppb.Reference{
+			Source: r.Source,
+			Kind:   r.Kind,
+			Anchor: r.Anchor,
+		})
+	}
+}
+
+// toBinding emits the anchor of each /kythe/edge/defines/binding
+// *ppb.Reference keyed by the node it binds.
+func toBinding(r *ppb.Reference, emit func(*spb.VName, *srvpb.ExpandedAnchor)) {
+	if refKind(r) == edges.DefinesBinding {
+		emit(r.Source, r.Anchor)
+	}
+}
+
+// toCallers emits a single-caller *srvpb.PagedCrossReferences_Group, keyed by
+// the callee, for each node called within the given caller.
+func toCallers(caller *spb.VName, bindingStream func(**srvpb.ExpandedAnchor) bool, msStream func(**cpb.MarkedSource) bool, callStream func(**ppb.Reference) bool, emit func(*spb.VName, *srvpb.PagedCrossReferences_Group)) {
+	var binding *srvpb.ExpandedAnchor
+	if !bindingStream(&binding) {
+		return
+	}
+	var ms *cpb.MarkedSource
+	msStream(&ms)
+
+	ticket := kytheuri.ToString(caller)
+	callers := make(map[string]*srvpb.PagedCrossReferences_Caller)
+	var callees []*spb.VName
+	var r *ppb.Reference
+	for callStream(&r) {
+		callee := kytheuri.ToString(r.Source)
+		c, ok := callers[callee]
+		if !ok {
+			c = &srvpb.PagedCrossReferences_Caller{
+				Caller:         binding,
+				SemanticCaller: ticket,
+				MarkedSource:   ms,
+			}
+			callers[callee] = c
+			callees = append(callees, r.Source)
+		}
+		c.Callsite = append(c.Callsite, r.Anchor)
+	}
+
+	for _, callee := range callees {
+		c := callers[kytheuri.ToString(callee)]
+		sort.Slice(c.Callsite, func(i, j int) bool { return c.Callsite[i].Ticket < c.Callsite[j].Ticket })
+		emit(callee, &srvpb.PagedCrossReferences_Group{
+			Kind:   xrefs.DirectCallerKind,
+			Caller: []*srvpb.PagedCrossReferences_Caller{c},
+		})
+	}
 }
 
 func keyRef(r *ppb.Reference) (*spb.VName, *ppb.Reference) {
@@ -171,10 +415,11 @@ func (k *KytheBeam) Decorations() beam.PCollection {
 		beam.CoGroupByKey(s, beam.ParDo(s, moveSourceToKey, bareNodes), targets))
 	defs := beam.ParDo(s, defToDecorPiece,
 		beam.CoGroupByKey(s, k.directDefinitions(), targets))
-	// TODO(schroederc): overrides
-	// TODO(schroederc): diagnostics
+	overrides := beam.ParDo(s, toFilePieces,
+		beam.CoGroupByKey(s, beam.ParDo(s, toDefiningFile, k.References()), k.overridePieces()))
+	diagnostics := k.diagnosticPieces()
 
-	pieces := beam.Flatten(s, decor, files, nodes, defs)
+	pieces := beam.Flatten(s, decor, files, nodes, defs, overrides, diagnostics)
 	return beam.ParDo(s, &ticketKey{"decor:"}, beam.CombinePerKey(s, &combineDecorPieces{}, pieces))
 }
 
@@ -201,6 +446,14 @@ func (c *combineDecorPieces) CreateAccumulator() *srvpb.FileDecorations {
 }
 
 func (c *combineDecorPieces) MergeAccumulators(accum, n *srvpb.FileDecorations) *srvpb.FileDecorations {
+	if accum.File == nil {
+		accum.File = n.File
+	}
+	accum.Decoration = append(accum.Decoration, n.Decoration...)
+	accum.Target = append(accum.Target, n.Target...)
+	accum.TargetDefinitions = append(accum.TargetDefinitions, n.TargetDefinitions...)
+	accum.TargetOverride = append(accum.TargetOverride, n.TargetOverride...)
+	accum.Diagnostic = append(accum.Diagnostic, n.Diagnostic...)
 	return accum
 }
 
@@ -231,6 +484,10 @@ func (c *combineDecorPieces) AddInput(accum *srvpb.FileDecorations, p *ppb.Decor
 			Ticket:             kytheuri.ToString(def.Node),
 			DefinitionLocation: &srvpb.ExpandedAnchor{Ticket: def.Definition.Ticket},
 		})
+	case *ppb.DecorationPiece_Override:
+		accum.TargetOverride = append(accum.TargetOverride, p.Override)
+	case *ppb.DecorationPiece_Diagnostic:
+		accum.Diagnostic = append(accum.Diagnostic, p.Diagnostic)
 	default:
 		panic(fmt.Errorf("unhandled DecorationPiece: %T", p))
 	}
@@ -293,9 +550,46 @@ func (c *combineDecorPieces) ExtractOutput(fd *srvpb.FileDecorations) *srvpb.Fil
 		return fd.Decoration[i].Target < fd.Decoration[j].Target
 	})
 	sort.Slice(fd.Target, func(i, j int) bool { return fd.Target[i].Ticket < fd.Target[j].Ticket })
+	fd.Target = dedupNodes(fd.Target)
+	sort.Slice(fd.TargetDefinitions, func(i, j int) bool {
+		return fd.TargetDefinitions[i].Ticket < fd.TargetDefinitions[j].Ticket
+	})
+	fd.TargetDefinitions = dedupAnchors(fd.TargetDefinitions)
+	sort.Slice(fd.TargetOverride, func(i, j int) bool {
+		return compare.Compare(fd.TargetOverride[i].Overriding, fd.TargetOverride[j].Overriding).
+			AndThen(fd.TargetOverride[i].Overridden, fd.TargetOverride[j].Overridden) == compare.LT
+	})
+	sort.Slice(fd.Diagnostic, func(i, j int) bool {
+		a, b := fd.Diagnostic[i], fd.Diagnostic[j]
+		return compare.Compare(a.GetSpan().GetStart().GetByteOffset(), b.GetSpan().GetStart().GetByteOffset()).
+			AndThen(a.GetSpan().GetEnd().GetByteOffset(), b.GetSpan().GetEnd().GetByteOffset()).
+			AndThen(a.Message, b.Message) == compare.LT
+	})
 	return fd
 }
 
+// dedupNodes removes consecutive *srvpb.Nodes with the same ticket.
+func dedupNodes(ns []*srvpb.Node) []*srvpb.Node {
+	var res []*srvpb.Node
+	for i, n := range ns {
+		if i == 0 || ns[i-1].Ticket != n.Ticket {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// dedupAnchors removes consecutive *srvpb.ExpandedAnchors with the same ticket.
+func dedupAnchors(as []*srvpb.ExpandedAnchor) []*srvpb.ExpandedAnchor {
+	var res []*srvpb.ExpandedAnchor
+	for i, a := range as {
+		if i == 0 || as[i-1].Ticket != a.Ticket {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
 func fileToDecorPiece(src *spb.VName, f *srvpb.File) (*spb.VName, *ppb.DecorationPiece) {
 	return src, &ppb.DecorationPiece{Piece: &ppb.DecorationPiece_File{f}}
 }
@@ -368,6 +662,196 @@ func defToDecorPiece(node *spb.VName, defs func(**srvpb.ExpandedAnchor) bool, fi
 	}
 }
 
+// toDefiningFile emits the enclosing file of each /kythe/edge/defines or
+// /kythe/edge/defines/binding *ppb.Reference keyed by the defined node.
+func toDefiningFile(r *ppb.Reference, emit func(*spb.VName, *spb.VName)) error {
+	if kind := refKind(r); kind != edges.Defines && kind != edges.DefinesBinding {
+		return nil
+	}
+	node, file, err := toEnclosingFile(r)
+	if err != nil {
+		return err
+	}
+	emit(node, file)
+	return nil
+}
+
+// toFilePieces emits each of a node's *ppb.DecorationPieces for each file in
+// which the node is defined.
+func toFilePieces(node *spb.VName, fileStream func(**spb.VName) bool, pieceStream func(**ppb.DecorationPiece) bool, emit func(*spb.VName, *ppb.DecorationPiece)) {
+	var files []*spb.VName
+	var file *spb.VName
+	for fileStream(&file) {
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return
+	}
+	var piece *ppb.DecorationPiece
+	for pieceStream(&piece) {
+		for _, f := range files {
+			emit(f, piece)
+		}
+	}
+}
+
+// overridePieces returns a collection of *ppb.DecorationPieces keyed by the
+// VName of each overriding node.  For each /kythe/edge/overrides or
+// /kythe/edge/extends edge, the overridden node's Override, Node, and
+// Definition (if known) pieces are emitted.
+func (k *KytheBeam) overridePieces() beam.PCollection {
+	s := k.s.Scope("Overrides")
+	bareNodes := beam.ParDo(s, moveSourceToKey, beam.ParDo(s, &nodes.Filter{IncludeEdges: []string{}}, k.nodes))
+	overrides := beam.ParDo(s, nodeToOverrides, k.nodes)
+	return beam.ParDo(s, overrideToDecorPieces, beam.CoGroupByKey(s, bareNodes, k.directDefinitions(), overrides))
+}
+
+// nodeToOverrides emits each of n's /kythe/edge/overrides and
+// /kythe/edge/extends edges keyed by its Target (the overridden node).
+func nodeToOverrides(n *ppb.Node, emit func(*spb.VName, *ppb.Edge)) {
+	for _, e := range n.Edge {
+		if kind := nodes.EdgeKind(e); kind == edges.Overrides || edges.IsVariant(kind, edges.Extends) {
+			emit(e.Target, &ppb.Edge{
+				Source: n.Source,
+				Target: e.Target,
+				Kind:   e.Kind,
+			})
+		}
+	}
+}
+
+// overrideToDecorPieces emits the *ppb.DecorationPieces describing the given
+// overridden node for each node overriding it.
+func overrideToDecorPieces(overridden *spb.VName, nodeStream func(**ppb.Node) bool, defStream func(**srvpb.ExpandedAnchor) bool, edgeStream func(**ppb.Edge) bool, emit func(*spb.VName, *ppb.DecorationPiece)) error {
+	ticket := kytheuri.ToString(overridden)
+
+	var pieces []*ppb.DecorationPiece
+	var ms *cpb.MarkedSource
+	var n *ppb.Node
+	if nodeStream(&n) {
+		for _, f := range n.Fact {
+			if f.GetKytheName() == scpb.FactName_CODE {
+				ms = new(cpb.MarkedSource)
+				if err := proto.Unmarshal(f.Value, ms); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		pieces = append(pieces, &ppb.DecorationPiece{
+			Piece: &ppb.DecorationPiece_Node{&ppb.Node{
+				Source:  overridden,
+				Kind:    n.Kind,
+				Subkind: n.Subkind,
+				Fact:    n.Fact,
+			}},
+		})
+	}
+	var def *srvpb.ExpandedAnchor
+	if defStream(&def) {
+		// TODO(schroederc): select ambiguous definition better
+		pieces = append(pieces, &ppb.DecorationPiece{
+			Piece: &ppb.DecorationPiece_Definition_{&ppb.DecorationPiece_Definition{
+				Node:       overridden,
+				Definition: def,
+			}},
+		})
+	}
+
+	var e *ppb.Edge
+	for edgeStream(&e) {
+		kind := srvpb.FileDecorations_Override_OVERRIDES
+		if edges.IsVariant(nodes.EdgeKind(e), edges.Extends) {
+			kind = srvpb.FileDecorations_Override_EXTENDS
+		}
+		emit(e.Source, &ppb.DecorationPiece{
+			Piece: &ppb.DecorationPiece_Override{&srvpb.FileDecorations_Override{
+				Overriding:   kytheuri.ToString(e.Source),
+				Overridden:   ticket,
+				Kind:         kind,
+				MarkedSource: ms,
+			}},
+		})
+		for _, p := range pieces {
+			emit(e.Source, p)
+		}
+	}
+	return nil
+}
+
+// diagnosticPieces returns a collection of *ppb.DecorationPieces keyed by the
+// VName of each file with a diagnostic.  A diagnostic node is attached to a
+// file either directly or by one of the file's anchors with a
+// /kythe/edge/tagged edge.
+func (k *KytheBeam) diagnosticPieces() beam.PCollection {
+	s := k.s.Scope("Diagnostics")
+	diags := beam.ParDo(s, moveSourceToKey, beam.ParDo(s, &nodes.Filter{
+		FilterByKind: []string{kinds.Diagnostic},
+		IncludeFacts: []string{facts.Message, facts.Details, facts.ContextURL},
+		IncludeEdges: []string{},
+	}, k.nodes))
+	anchors := beam.ParDo(s, toTaggedAnchor, k.References())
+	files := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.File},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.Tagged},
+	}, fileToTags)
+	return beam.ParDo(s, diagToDecorPieces, beam.CoGroupByKey(s, diags, anchors, files))
+}
+
+// toTaggedAnchor emits the anchor of each /kythe/edge/tagged *ppb.Reference
+// keyed by its diagnostic node.
+func toTaggedAnchor(r *ppb.Reference, emit func(*spb.VName, *srvpb.ExpandedAnchor)) {
+	if refKind(r) == edges.Tagged {
+		emit(r.Source, r.Anchor)
+	}
+}
+
+// fileToTags emits a (diagnostic, file) pair for each /kythe/edge/tagged edge
+// of the given file *ppb.Node.
+func fileToTags(n *ppb.Node, emit func(*spb.VName, *spb.VName)) {
+	for _, e := range n.Edge {
+		emit(e.Target, n.Source)
+	}
+}
+
+// diagToDecorPieces emits a Diagnostic *ppb.DecorationPiece for each anchor
+// and file tagged with the given diagnostic node.
+func diagToDecorPieces(key *spb.VName, nodeStream func(**ppb.Node) bool, anchorStream func(**srvpb.ExpandedAnchor) bool, fileStream func(**spb.VName) bool, emit func(*spb.VName, *ppb.DecorationPiece)) error {
+	var n *ppb.Node
+	if !nodeStream(&n) {
+		return nil
+	}
+	diagnostic := func(span *cpb.Span) *ppb.DecorationPiece {
+		d := &cpb.Diagnostic{Span: span}
+		for _, f := range n.Fact {
+			switch f.GetKytheName() {
+			case scpb.FactName_MESSAGE:
+				d.Message = string(f.Value)
+			case scpb.FactName_DETAILS:
+				d.Details = string(f.Value)
+			case scpb.FactName_CONTEXT_URL:
+				d.ContextUrl = string(f.Value)
+			}
+		}
+		return &ppb.DecorationPiece{Piece: &ppb.DecorationPiece_Diagnostic{d}}
+	}
+
+	var a *srvpb.ExpandedAnchor
+	for anchorStream(&a) {
+		anchor, err := kytheuri.ToVName(a.Ticket)
+		if err != nil {
+			return err
+		}
+		emit(fileVName(anchor), diagnostic(a.Span))
+	}
+	var file *spb.VName
+	for fileStream(&file) {
+		emit(file, diagnostic(nil))
+	}
+	return nil
+}
+
 // Nodes returns all *ppb.Nodes from the Kythe input graph.
 func (k *KytheBeam) Nodes() beam.PCollection { return k.nodes }
 
@@ -530,7 +1014,7 @@ func (k *KytheBeam) Edges() (beam.PCollection, beam.PCollection) {
 	edges := beam.ParDo(s, reverseEdge, beam.CoGroupByKey(s, nodes, beam.ParDo(s, nodeToEdges, k.nodes)))
 	rev := beam.ParDo(s, nodeToReverseEdges, k.nodes)
 
-	return beam.ParDo2(s, groupEdges, beam.CoGroupByKey(s, nodes, edges, rev))
+	return beam.ParDo2(s, &groupEdges{MaxPageSize: k.MaxPageSize}, beam.CoGroupByKey(s, nodes, edges, rev))
 }
 
 // nodeToReverseEdges emits an *ppb.Edge with its SourceNode populated for each of n's edges.  The
@@ -577,59 +1061,80 @@ func reverseEdge(src *spb.VName, nodeStream func(**ppb.Node) bool, edgeStream fu
 	}
 }
 
-// groupEdges emits *srvpb.PagedEdgeSets and *srvpb.EdgePages for a node and its forward/reverse
-// edges.
-func groupEdges(src *spb.VName, nodeStream func(**ppb.Node) bool, edgeStream, revStream func(**ppb.Edge) bool, emitSet func(string, *srvpb.PagedEdgeSet), emitPage func(string, *srvpb.EdgePage)) {
-	set := &srvpb.PagedEdgeSet{}
-	// TODO(schroederc): paging
+// groupEdges emits *srvpb.PagedEdgeSets and *srvpb.EdgePages for a node and
+// its forward/reverse edges.
+type groupEdges struct {
+	// MaxPageSize is the maximum number of edges in each set/page.
+	MaxPageSize int
+}
 
+func (g *groupEdges) ProcessElement(ctx context.Context, src *spb.VName, nodeStream func(**ppb.Node) bool, edgeStream, revStream func(**ppb.Edge) bool, emitSet func(string, *srvpb.PagedEdgeSet), emitPage func(string, *srvpb.EdgePage)) error {
+	var source *srvpb.Node
 	var node *ppb.Node
 	if nodeStream(&node) {
 		node.Source = src
-		set.Source = convertPipelineNode(node)
+		source = convertPipelineNode(node)
 	} else {
-		set.Source = &srvpb.Node{Ticket: kytheuri.ToString(src)}
+		source = &srvpb.Node{Ticket: kytheuri.ToString(src)}
 	}
 
 	groups := make(map[string]*srvpb.EdgeGroup)
+	group := func(kind string) *srvpb.EdgeGroup {
+		eg, ok := groups[kind]
+		if !ok {
+			eg = &srvpb.EdgeGroup{Kind: kind}
+			groups[kind] = eg
+		}
+		return eg
+	}
 
 	var edge *ppb.Edge
 	for edgeStream(&edge) {
-		kind := nodes.EdgeKind(edge)
-		g, ok := groups[kind]
-		if !ok {
-			g = &srvpb.EdgeGroup{Kind: kind}
-			groups[kind] = g
-			set.Group = append(set.Group, g)
-		}
-		g.Edge = append(g.Edge, &srvpb.EdgeGroup_Edge{
+		eg := group(nodes.EdgeKind(edge))
+		eg.Edge = append(eg.Edge, &srvpb.EdgeGroup_Edge{
 			Target:  convertPipelineNode(edge.TargetNode),
 			Ordinal: edge.Ordinal,
 		})
 	}
 	for revStream(&edge) {
-		kind := "%" + nodes.EdgeKind(edge) // encode reverse edge kind
-		g, ok := groups[kind]
-		if !ok {
-			g = &srvpb.EdgeGroup{Kind: kind}
-			groups[kind] = g
-			set.Group = append(set.Group, g)
-		}
-		g.Edge = append(g.Edge, &srvpb.EdgeGroup_Edge{
+		eg := group("%" + nodes.EdgeKind(edge)) // encode reverse edge kind
+		eg.Edge = append(eg.Edge, &srvpb.EdgeGroup_Edge{
 			Target:  convertPipelineNode(edge.SourceNode),
 			Ordinal: edge.Ordinal,
 		})
 	}
 
-	sort.Slice(set.Group, func(i, j int) bool { return set.Group[i].Kind < set.Group[j].Kind })
-	for _, g := range set.Group {
-		sort.Slice(g.Edge, func(i, j int) bool {
-			return compare.Compare(g.Edge[i].Ordinal, g.Edge[j].Ordinal).
-				AndThen(g.Edge[i].Target.Ticket, g.Edge[j].Target.Ticket) == compare.LT
-		})
+	eb := &assemble.EdgeSetBuilder{
+		MaxEdgePageSize: g.MaxPageSize,
+		Output: func(ctx context.Context, set *srvpb.PagedEdgeSet) error {
+			emitSet("edgeSets:"+set.Source.Ticket, set)
+			return nil
+		},
+		OutputPage: func(ctx context.Context, p *srvpb.EdgePage) error {
+			emitPage("edgePages:"+p.PageKey, p)
+			return nil
+		},
+	}
+	if err := eb.StartEdgeSet(ctx, source); err != nil {
+		return err
 	}
 
-	emitSet("edgeSets:"+set.Source.Ticket, set)
+	edgeKinds := make([]string, 0, len(groups))
+	for kind := range groups {
+		edgeKinds = append(edgeKinds, kind)
+	}
+	sort.Strings(edgeKinds)
+	for _, kind := range edgeKinds {
+		eg := groups[kind]
+		sort.Slice(eg.Edge, func(i, j int) bool {
+			return compare.Compare(eg.Edge[i].Ordinal, eg.Edge[j].Ordinal).
+				AndThen(eg.Edge[i].Target.Ticket, eg.Edge[j].Target.Ticket) == compare.LT
+		})
+		if err := eb.AddGroup(ctx, eg); err != nil {
+			return err
+		}
+	}
+	return eb.Flush(ctx)
 }
 
 // Documents returns a Kythe documentation table derived from the Kythe input
@@ -708,6 +1213,91 @@ func parseMarkedSource(n *ppb.Node, emit func(*spb.VName, *cpb.MarkedSource)) er
 	return nil
 }
 
+// Identifiers returns a Kythe identifiers table derived from the Kythe input
+// graph.  The beam.PCollections have elements of type
+// KV<string, *srvpb.IdentifierMatch> and KV<string, *srvpb.IdentifierPostings>,
+// respectively; the latter form the identifier search index.
+func (k *KytheBeam) Identifiers() (matches, postings beam.PCollection) {
+	s := k.s.Scope("Identifiers")
+	ids := beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{facts.Code},
+		IncludeEdges: []string{},
+	}, nodeToIdentifier)
+	matches = beam.ParDo(s, groupIdentifiers, beam.GroupByKey(s, ids))
+	keys := beam.ParDo(s, identifierSearchKeys, matches)
+	postings = beam.ParDo(s, groupPostings, beam.GroupByKey(s, keys))
+	return matches, postings
+}
+
+// nodeToIdentifier emits a single-node *srvpb.IdentifierMatch, keyed by its
+// qualified name, for each *ppb.Node with an identifier in its MarkedSource.
+func nodeToIdentifier(n *ppb.Node, emit func(string, *srvpb.IdentifierMatch)) error {
+	for _, f := range n.Fact {
+		if f.GetKytheName() == scpb.FactName_CODE {
+			m, err := identifierMatch(kytheuri.ToString(n.Source), nodes.Kind(n), nodes.Subkind(n), f.Value)
+			if err != nil {
+				return err
+			} else if m != nil {
+				emit(m.QualifiedName, m)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// groupIdentifiers merges the *srvpb.IdentifierMatches sharing a single
+// qualified name.
+func groupIdentifiers(qname string, matchStream func(**srvpb.IdentifierMatch) bool, emit func(string, *srvpb.IdentifierMatch)) {
+	merged := &srvpb.IdentifierMatch{QualifiedName: qname}
+	var m *srvpb.IdentifierMatch
+	for matchStream(&m) {
+		merged.BaseName = m.BaseName
+		merged.Node = append(merged.Node, m.Node...)
+	}
+	sort.Slice(merged.Node, func(i, j int) bool { return merged.Node[i].Ticket < merged.Node[j].Ticket })
+	emit(qname, merged)
+}
+
+// identifierSearchKeys emits a single-identifier *srvpb.IdentifierPostings
+// for each key of the identifier search index recording the given
+// *srvpb.IdentifierMatch.
+func identifierSearchKeys(qname string, m *srvpb.IdentifierMatch, emit func(string, *srvpb.IdentifierPostings)) {
+	for _, key := range identifiers.SearchKeys(m.QualifiedName, m.BaseName) {
+		emit(key, &srvpb.IdentifierPostings{
+			QualifiedName: []string{qname},
+			BaseName:      []string{m.BaseName},
+		})
+	}
+}
+
+// groupPostings emits the *srvpb.IdentifierPostings shards, keyed by their
+// table keys, for a single key of the identifier search index.
+func groupPostings(key string, postingStream func(**srvpb.IdentifierPostings) bool, emit func(string, *srvpb.IdentifierPostings)) error {
+	names := make(map[string]string) // qualified name -> base name
+	var p *srvpb.IdentifierPostings
+	for postingStream(&p) {
+		for i, qname := range p.QualifiedName {
+			names[qname] = p.BaseName[i]
+		}
+	}
+	qnames := make([]string, 0, len(names))
+	for qname := range names {
+		qnames = append(qnames, qname)
+	}
+	sort.Strings(qnames)
+	w := identifiers.NewPostingsWriter(key, func(k string, p *srvpb.IdentifierPostings) error {
+		emit(k, p)
+		return nil
+	})
+	for _, qname := range qnames {
+		if err := w.Add(qname, names[qname]); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
 // nodeToChildren emits a (parent, child) pair for each /kythe/edge/childof edge
 // per *ppb.Node.
 func nodeToChildren(n *ppb.Node, emit func(*spb.VName, *spb.VName)) {
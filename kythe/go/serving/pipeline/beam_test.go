@@ -19,6 +19,8 @@ package pipeline
 import (
 	"testing"
 
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
 	"kythe.io/kythe/go/serving/pipeline/beamtest"
 
 	"github.com/apache/beam/sdks/go/pkg/beam"
@@ -369,6 +371,192 @@ func TestDecorations_targetDefinition(t *testing.T) {
 	}
 }
 
+func TestDecorations_overrides(t *testing.T) {
+	ms := &cpb.MarkedSource{Kind: cpb.MarkedSource_IDENTIFIER, PreText: "base"}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some text\n"),
+		}},
+	}, {
+		Source: &spb.VName{Path: "path", Signature: "anchor1"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_START},
+			Value: []byte("0"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_END},
+			Value: []byte("4"),
+		}},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+			Target: &spb.VName{Signature: "derived"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "derived"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_OVERRIDES},
+			Target: &spb.VName{Signature: "base"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "base"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+	}}
+
+	def := &srvpb.ExpandedAnchor{
+		Ticket: "kythe:?path=path#anchor1",
+		Text:   "some",
+		Span: &cpb.Span{
+			Start: &cpb.Point{LineNumber: 1},
+			End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+		},
+		Snippet: "some text",
+		SnippetSpan: &cpb.Span{
+			Start: &cpb.Point{LineNumber: 1},
+			End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+		},
+	}
+	expected := []*srvpb.FileDecorations{{
+		File: &srvpb.File{Text: []byte("some text\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{
+				StartOffset: 0,
+				EndOffset:   4,
+			},
+			Kind:             "/kythe/edge/defines/binding",
+			Target:           "kythe:#derived",
+			TargetDefinition: "kythe:?path=path#anchor1",
+		}},
+		Target: []*srvpb.Node{{
+			Ticket: "kythe:#base",
+			Fact: []*cpb.Fact{
+				{Name: "/kythe/code", Value: rec},
+				{Name: "/kythe/node/kind", Value: []byte("function")},
+			},
+		}, {
+			Ticket: "kythe:#derived",
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("function")}},
+		}},
+		TargetDefinitions: []*srvpb.ExpandedAnchor{def},
+		TargetOverride: []*srvpb.FileDecorations_Override{{
+			Overriding:   "kythe:#derived",
+			Overridden:   "kythe:#base",
+			Kind:         srvpb.FileDecorations_Override_OVERRIDES,
+			MarkedSource: ms,
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	decor := FromNodes(s, nodes).Decorations()
+	debug.Print(s, decor)
+	passert.Equals(s, beam.DropKey(s, decor), beam.CreateList(s, expected))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestDecorations_diagnostics(t *testing.T) {
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some text\n"),
+		}},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_TAGGED},
+			Target: &spb.VName{Signature: "diag2"},
+		}},
+	}, {
+		Source: &spb.VName{Path: "path", Signature: "anchor1"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_START},
+			Value: []byte("5"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_END},
+			Value: []byte("9"),
+		}},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_TAGGED},
+			Target: &spb.VName{Signature: "diag1"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "diag1"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_DIAGNOSTIC},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_MESSAGE},
+			Value: []byte("anchor message"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_DETAILS},
+			Value: []byte("some details"),
+		}},
+	}, {
+		Source: &spb.VName{Signature: "diag2"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_DIAGNOSTIC},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_MESSAGE},
+			Value: []byte("file message"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_CONTEXT_URL},
+			Value: []byte("https://kythe.io/docs"),
+		}},
+	}}
+
+	expected := []*srvpb.FileDecorations{{
+		File: &srvpb.File{Text: []byte("some text\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{
+				StartOffset: 5,
+				EndOffset:   9,
+			},
+			Kind:   "/kythe/edge/tagged",
+			Target: "kythe:#diag1",
+		}},
+		Target: []*srvpb.Node{{
+			Ticket: "kythe:#diag1",
+			Fact: []*cpb.Fact{
+				{Name: "/kythe/details", Value: []byte("some details")},
+				{Name: "/kythe/message", Value: []byte("anchor message")},
+				{Name: "/kythe/node/kind", Value: []byte("diagnostic")},
+			},
+		}},
+		Diagnostic: []*cpb.Diagnostic{{
+			Message:    "file message",
+			ContextUrl: "https://kythe.io/docs",
+		}, {
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: 5, LineNumber: 1, ColumnOffset: 5},
+				End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+			},
+			Message: "anchor message",
+			Details: "some details",
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	decor := FromNodes(s, nodes).Decorations()
+	debug.Print(s, decor)
+	passert.Equals(s, beam.DropKey(s, decor), beam.CreateList(s, expected))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestCrossReferences(t *testing.T) {
 	testRefs := []*ppb.Reference{{
 		Source: &spb.VName{Signature: "node1"},
@@ -476,6 +664,7 @@ func TestCrossReferences(t *testing.T) {
 				},
 			}},
 		}},
+		TotalReferences: 2,
 	}, {
 		SourceTicket: "kythe:#node2",
 		Group: []*srvpb.PagedCrossReferences_Group{{
@@ -508,10 +697,11 @@ func TestCrossReferences(t *testing.T) {
 				},
 			}},
 		}},
+		TotalReferences: 1,
 	}}
 
 	p, s, refs := ptest.CreateList(testRefs)
-	k := &KytheBeam{s: s, refs: refs}
+	k := &KytheBeam{s: s, refs: refs, nodes: beam.CreateList(s, []*ppb.Node{})}
 	sets, _ := k.CrossReferences()
 	debug.Print(s, sets)
 	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
@@ -521,6 +711,230 @@ func TestCrossReferences(t *testing.T) {
 	}
 }
 
+func TestCrossReferences_relatedNodes(t *testing.T) {
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Signature: "node1"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "node2"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "node2"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_PACKAGE},
+	}}
+
+	node1 := &srvpb.Node{
+		Ticket: "kythe:#node1",
+		Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("record")}},
+	}
+	node2 := &srvpb.Node{
+		Ticket: "kythe:#node2",
+		Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("package")}},
+	}
+	expectedSets := []*srvpb.PagedCrossReferences{{
+		SourceTicket: "kythe:#node1",
+		SourceNode:   node1,
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:        "/kythe/edge/childof",
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{Node: node2}},
+		}},
+		TotalReferences: 1,
+	}, {
+		SourceTicket: "kythe:#node2",
+		SourceNode:   node2,
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:        "%/kythe/edge/childof",
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{Node: node1}},
+		}},
+		TotalReferences: 1,
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	sets, _ := FromNodes(s, nodes).CrossReferences()
+	debug.Print(s, sets)
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestCrossReferences_callers(t *testing.T) {
+	ms := &cpb.MarkedSource{Kind: cpb.MarkedSource_IDENTIFIER, PreText: "caller"}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some text\n"),
+		}},
+	}, {
+		Source: &spb.VName{Path: "path", Signature: "binding"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_START},
+			Value: []byte("0"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_END},
+			Value: []byte("4"),
+		}},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+			Target: &spb.VName{Signature: "caller"},
+		}},
+	}, {
+		Source: &spb.VName{Path: "path", Signature: "callsite"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_START},
+			Value: []byte("5"),
+		}, {
+			Name:  &ppb.Fact_KytheName{scpb.FactName_LOC_END},
+			Value: []byte("9"),
+		}},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_REF_CALL},
+			Target: &spb.VName{Signature: "callee"},
+		}, {
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "caller"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "caller"},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+	}}
+
+	binding := &srvpb.ExpandedAnchor{
+		Ticket: "kythe:?path=path#binding",
+		Text:   "some",
+		Span: &cpb.Span{
+			Start: &cpb.Point{LineNumber: 1},
+			End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+		},
+		Snippet: "some text",
+		SnippetSpan: &cpb.Span{
+			Start: &cpb.Point{LineNumber: 1},
+			End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+		},
+	}
+	callsite := &srvpb.ExpandedAnchor{
+		Ticket: "kythe:?path=path#callsite",
+		Text:   "text",
+		Span: &cpb.Span{
+			Start: &cpb.Point{ByteOffset: 5, LineNumber: 1, ColumnOffset: 5},
+			End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+		},
+		Snippet: "some text",
+		SnippetSpan: &cpb.Span{
+			Start: &cpb.Point{LineNumber: 1},
+			End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+		},
+	}
+	expectedSets := []*srvpb.PagedCrossReferences{{
+		SourceTicket: "kythe:#callee",
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "/kythe/edge/ref/call",
+			Anchor: []*srvpb.ExpandedAnchor{callsite},
+		}, {
+			Kind: xrefs.DirectCallerKind,
+			Caller: []*srvpb.PagedCrossReferences_Caller{{
+				Caller:         binding,
+				SemanticCaller: "kythe:#caller",
+				MarkedSource:   ms,
+				Callsite:       []*srvpb.ExpandedAnchor{callsite},
+			}},
+		}},
+		TotalReferences: 2,
+	}, {
+		SourceTicket: "kythe:#caller",
+		SourceNode: &srvpb.Node{
+			Ticket: "kythe:#caller",
+			Fact:   []*cpb.Fact{{Name: "/kythe/code", Value: rec}},
+		},
+		MarkedSource: ms,
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "/kythe/edge/defines/binding",
+			Anchor: []*srvpb.ExpandedAnchor{binding},
+		}},
+		TotalReferences: 1,
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	sets, _ := FromNodes(s, nodes).CrossReferences()
+	debug.Print(s, sets)
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestCrossReferences_paging(t *testing.T) {
+	testRefs := []*ppb.Reference{{
+		Source: &spb.VName{Signature: "node1"},
+		Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_REF},
+		Anchor: &srvpb.ExpandedAnchor{Ticket: "kythe:?path=path#anchor1"},
+	}, {
+		Source: &spb.VName{Signature: "node1"},
+		Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_REF},
+		Anchor: &srvpb.ExpandedAnchor{Ticket: "kythe:?path=path#anchor2"},
+	}, {
+		Source: &spb.VName{Signature: "node1"},
+		Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_REF_CALL},
+		Anchor: &srvpb.ExpandedAnchor{Ticket: "kythe:?path=path#anchor3"},
+	}}
+
+	expectedSets := []*srvpb.PagedCrossReferences{{
+		SourceTicket: "kythe:#node1",
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "/kythe/edge/ref/call",
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=path#anchor3"}},
+		}},
+		PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+			PageKey: "kythe:#node1.0000000000",
+			Kind:    "/kythe/edge/ref",
+			Count:   2,
+			Corpus:  []string{""},
+			Root:    []string{""},
+			MinPath: "path",
+			MaxPath: "path",
+		}},
+		TotalReferences: 3,
+	}}
+	expectedPages := []*srvpb.PagedCrossReferences_Page{{
+		PageKey:      "kythe:#node1.0000000000",
+		SourceTicket: "kythe:#node1",
+		Group: &srvpb.PagedCrossReferences_Group{
+			Kind: "/kythe/edge/ref",
+			Anchor: []*srvpb.ExpandedAnchor{
+				{Ticket: "kythe:?path=path#anchor1"},
+				{Ticket: "kythe:?path=path#anchor2"},
+			},
+		},
+	}}
+
+	p, s, refs := ptest.CreateList(testRefs)
+	k := &KytheBeam{s: s, refs: refs, nodes: beam.CreateList(s, []*ppb.Node{}), MaxPageSize: 2}
+	sets, pages := k.CrossReferences()
+	debug.Print(s, sets)
+	debug.Print(s, pages)
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+	passert.Equals(s, beam.DropKey(s, pages), beam.CreateList(s, expectedPages))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestEdges_grouping(t *testing.T) {
 	testNodes := []*ppb.Node{{
 		Source: &spb.VName{Signature: "node1"},
@@ -536,7 +950,7 @@ func TestEdges_grouping(t *testing.T) {
 	expectedSets := []*srvpb.PagedEdgeSet{{
 		Source: &srvpb.Node{Ticket: "kythe:#node1"},
 		Group: []*srvpb.EdgeGroup{{
-			Kind: "%/kythe/edge/param",
+			Kind: "/kythe/edge/param",
 			Edge: []*srvpb.EdgeGroup_Edge{{
 				Target: &srvpb.Node{Ticket: "kythe:#node1"},
 			}, {
@@ -544,7 +958,7 @@ func TestEdges_grouping(t *testing.T) {
 				Ordinal: 1,
 			}},
 		}, {
-			Kind: "/kythe/edge/param",
+			Kind: "%/kythe/edge/param",
 			Edge: []*srvpb.EdgeGroup_Edge{{
 				Target: &srvpb.Node{Ticket: "kythe:#node1"},
 			}, {
@@ -552,6 +966,7 @@ func TestEdges_grouping(t *testing.T) {
 				Ordinal: 1,
 			}},
 		}},
+		TotalEdges: 4,
 	}}
 
 	p, s, nodes := ptest.CreateList(testNodes)
@@ -588,6 +1003,7 @@ func TestEdges_reverses(t *testing.T) {
 				Target: &srvpb.Node{Ticket: "kythe:#node2"},
 			}},
 		}},
+		TotalEdges: 1,
 	}, {
 		Source: &srvpb.Node{Ticket: "kythe:#node2"},
 		Group: []*srvpb.EdgeGroup{{
@@ -602,6 +1018,7 @@ func TestEdges_reverses(t *testing.T) {
 				},
 			}},
 		}},
+		TotalEdges: 1,
 	}}
 
 	p, s, nodes := ptest.CreateList(testNodes)
@@ -615,6 +1032,88 @@ func TestEdges_reverses(t *testing.T) {
 	}
 }
 
+func TestEdges_paging(t *testing.T) {
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Signature: "node1"},
+		Edge: []*ppb.Edge{{
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target: &spb.VName{Signature: "node2"},
+		}, {
+			Kind:    &ppb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Ordinal: 1,
+			Target:  &spb.VName{Signature: "node3"},
+		}, {
+			Kind:   &ppb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "node2"},
+		}},
+	}}
+	expectedSets := []*srvpb.PagedEdgeSet{{
+		Source: &srvpb.Node{Ticket: "kythe:#node1"},
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "/kythe/edge/childof",
+			Edge: []*srvpb.EdgeGroup_Edge{{
+				Target: &srvpb.Node{Ticket: "kythe:#node2"},
+			}},
+		}},
+		PageIndex: []*srvpb.PageIndex{{
+			PageKey:   "kythe:#node1.0000000000",
+			EdgeKind:  "/kythe/edge/param",
+			EdgeCount: 2,
+		}},
+		TotalEdges: 3,
+	}, {
+		Source: &srvpb.Node{Ticket: "kythe:#node2"},
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "%/kythe/edge/childof",
+			Edge: []*srvpb.EdgeGroup_Edge{{
+				Target: &srvpb.Node{Ticket: "kythe:#node1"},
+			}},
+		}, {
+			Kind: "%/kythe/edge/param",
+			Edge: []*srvpb.EdgeGroup_Edge{{
+				Target: &srvpb.Node{Ticket: "kythe:#node1"},
+			}},
+		}},
+		TotalEdges: 2,
+	}, {
+		Source: &srvpb.Node{Ticket: "kythe:#node3"},
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "%/kythe/edge/param",
+			Edge: []*srvpb.EdgeGroup_Edge{{
+				Target:  &srvpb.Node{Ticket: "kythe:#node1"},
+				Ordinal: 1,
+			}},
+		}},
+		TotalEdges: 1,
+	}}
+	expectedPages := []*srvpb.EdgePage{{
+		PageKey:      "kythe:#node1.0000000000",
+		SourceTicket: "kythe:#node1",
+		EdgesGroup: &srvpb.EdgeGroup{
+			Kind: "/kythe/edge/param",
+			Edge: []*srvpb.EdgeGroup_Edge{{
+				Target: &srvpb.Node{Ticket: "kythe:#node2"},
+			}, {
+				Target:  &srvpb.Node{Ticket: "kythe:#node3"},
+				Ordinal: 1,
+			}},
+		},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	k := FromNodes(s, nodes)
+	k.MaxPageSize = 2
+	sets, pages := k.Edges()
+	debug.Print(s, sets)
+	debug.Print(s, pages)
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+	passert.Equals(s, beam.DropKey(s, pages), beam.CreateList(s, expectedPages))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestFileTree_registrations(t *testing.T) {
 	testNodes := []*ppb.Node{{}}
 	p, s, nodes := ptest.CreateList(testNodes)
@@ -758,3 +1257,83 @@ func TestDocuments_registrations(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestIdentifiers(t *testing.T) {
+	ms := &cpb.MarkedSource{
+		Kind: cpb.MarkedSource_BOX,
+		Child: []*cpb.MarkedSource{{
+			Kind:          cpb.MarkedSource_CONTEXT,
+			PostChildText: "::",
+			Child: []*cpb.MarkedSource{{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "pkg",
+			}},
+		}, {
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: "Name",
+		}},
+	}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Signature: "node1"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+	}, {
+		Source:  &spb.VName{Signature: "node2"},
+		Kind:    &ppb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Subkind: &ppb.Node_KytheSubkind{scpb.Subkind_CLASS},
+		Fact: []*ppb.Fact{{
+			Name:  &ppb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+	}, {
+		Source: &spb.VName{Signature: "node3"},
+		Kind:   &ppb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+	}}
+	expectedMatches := []*srvpb.IdentifierMatch{{
+		QualifiedName: "pkg::Name",
+		BaseName:      "Name",
+		Node: []*srvpb.IdentifierMatch_Node{{
+			Ticket:   "kythe:#node1",
+			NodeKind: "function",
+		}, {
+			Ticket:      "kythe:#node2",
+			NodeKind:    "record",
+			NodeSubkind: "class",
+		}},
+	}}
+	var expectedPostings []*srvpb.IdentifierPostings
+	for range identifiers.SearchKeys("pkg::Name", "Name") {
+		expectedPostings = append(expectedPostings, &srvpb.IdentifierPostings{
+			QualifiedName: []string{"pkg::Name"},
+			BaseName:      []string{"Name"},
+			ShardCount:    1,
+		})
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	matches, postings := FromNodes(s, nodes).Identifiers()
+	debug.Print(s, matches)
+	passert.Equals(s, beam.DropKey(s, matches), beam.CreateList(s, expectedMatches))
+	passert.Equals(s, beam.DropKey(s, postings), beam.CreateList(s, expectedPostings))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestIdentifiers_registrations(t *testing.T) {
+	testNodes := []*ppb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Identifiers()
+	if err := beamtest.CheckRegistrations(p); err != nil {
+		t.Fatal(err)
+	}
+}
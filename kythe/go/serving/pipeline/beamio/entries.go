@@ -18,6 +18,7 @@ package beamio
 
 import (
 	"context"
+	"fmt"
 
 	"kythe.io/kythe/go/storage/stream"
 
@@ -28,14 +29,39 @@ import (
 )
 
 func init() {
+	beam.RegisterFunction(expandFiles)
 	beam.RegisterFunction(readStream)
 }
 
-// ReadEntries reads a delimited stream of *spb.Entry messages into a
-// PCollection from the given file.  The file can be part of any filesystem
-// registered with the beam/io/filesystem package.
+// ReadEntries reads a stream of *spb.Entry messages into a PCollection from
+// the given file.  The file can be part of any filesystem registered with the
+// beam/io/filesystem package and may be in any format accepted by
+// stream.NewReader (e.g. delimited or Riegeli).  The file may also be a glob
+// pattern (e.g. "entries-*.riegeli") in which case every matching file shard
+// is read independently.
 func ReadEntries(s beam.Scope, file string) beam.PCollection {
-	return beam.ParDo(s, readStream, beam.Create(s, file))
+	s = s.Scope("ReadEntries")
+	return beam.ParDo(s, readStream, beam.ParDo(s, expandFiles, beam.Create(s, file)))
+}
+
+// expandFiles emits each filename matching the given glob pattern.  It is an
+// error for the pattern not to match any files.
+func expandFiles(ctx context.Context, pattern string, emit func(string)) error {
+	fs, err := filesystem.New(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+	files, err := fs.List(ctx, pattern)
+	if err != nil {
+		return err
+	} else if len(files) == 0 {
+		return fmt.Errorf("no files matching %q", pattern)
+	}
+	for _, file := range files {
+		emit(file)
+	}
+	return nil
 }
 
 func readStream(ctx context.Context, filename string, emit func(*spb.Entry)) error {
@@ -43,13 +69,17 @@ func readStream(ctx context.Context, filename string, emit func(*spb.Entry)) err
 	if err != nil {
 		return err
 	}
+	defer fs.Close()
 	f, err := fs.OpenRead(ctx, filename)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	for e := range stream.ReadEntries(f) {
+	if err := stream.NewReader(f)(func(e *spb.Entry) error {
 		emit(e)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading %q: %v", filename, err)
 	}
-	return fs.Close()
+	return nil
 }
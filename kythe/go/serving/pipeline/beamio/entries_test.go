@@ -19,10 +19,12 @@ package beamio
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/util/riegeli"
 
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/testing/ptest"
@@ -80,6 +82,87 @@ func TestReadEntries(t *testing.T) {
 	}
 }
 
+func TestReadEntries_sharded(t *testing.T) {
+	entries := []*spb.Entry{{
+		Source:   &spb.VName{Signature: "sig1"},
+		FactName: "/kythe/fact/name",
+	}, {
+		Source:    &spb.VName{Signature: "sig1"},
+		FactName:  "/kythe/fact/name2",
+		FactValue: []byte("value"),
+	}, {
+		Source:   &spb.VName{Signature: "sig2"},
+		EdgeKind: "/kythe/edge/kind",
+		Target:   &spb.VName{Signature: "sig1"},
+	}}
+
+	dir, err := ioutil.TempDir("", "entries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Write the first entries as a delimited shard and the rest as a Riegeli shard.
+	delimitedShard, err := os.Create(filepath.Join(dir, "entries-00000-of-00002"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wr := delimited.NewWriter(delimitedShard)
+	for _, e := range entries[:2] {
+		if err := wr.PutProto(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := delimitedShard.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	riegeliShard, err := os.Create(filepath.Join(dir, "entries-00001-of-00002"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := riegeli.NewWriter(riegeliShard, nil)
+	for _, e := range entries[2:] {
+		if err := rw.PutProto(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := riegeliShard.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, s := beam.NewPipelineWithRoot()
+
+	coll := ReadEntries(s, filepath.Join(dir, "entries-*"))
+
+	var found []*spb.Entry
+	beam.ParDo(s, func(e *spb.Entry, emit func(*spb.Entry)) { found = append(found, e) }, coll)
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(entries, found, ignoreProtoXXXFields); diff != "" {
+		t.Fatalf("Diff found (-expected; +found):\n%s", diff)
+	}
+}
+
+func TestReadEntries_noMatches(t *testing.T) {
+	dir, err := ioutil.TempDir("", "entries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p, s := beam.NewPipelineWithRoot()
+	ReadEntries(s, filepath.Join(dir, "missing-*"))
+	if err := ptest.Run(p); err == nil {
+		t.Fatal("Expected error reading non-existent files")
+	}
+}
+
 var ignoreProtoXXXFields = cmp.FilterPath(func(p cmp.Path) bool {
 	for _, s := range p {
 		if strings.HasPrefix(s.String(), ".XXX_") {
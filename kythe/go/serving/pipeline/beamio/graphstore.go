@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package beamio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/gsutil"
+
+	"github.com/apache/beam/sdks/go/pkg/beam"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func init() {
+	beam.RegisterType(reflect.TypeOf((*readShard)(nil)).Elem())
+}
+
+// ReadGraphStore reads all *spb.Entry messages from the GraphStore with the
+// given gsutil specification into a PCollection.  The GraphStore must
+// implement graphstore.Sharded; each of numShards shards is read
+// independently.  The GraphStore's gsutil.Handler must be registered in each
+// process executing the pipeline (e.g. by importing the
+// kythe.io/kythe/go/storage/leveldb package).
+func ReadGraphStore(s beam.Scope, spec string, numShards int) beam.PCollection {
+	s = s.Scope("ReadGraphStore")
+	shards := make([]int64, numShards)
+	for i := range shards {
+		shards[i] = int64(i)
+	}
+	return beam.ParDo(s, &readShard{Spec: spec, Shards: int64(numShards)}, beam.CreateList(s, shards))
+}
+
+// readShard emits each *spb.Entry in a single shard of a graphstore.Sharded.
+type readShard struct {
+	Spec   string
+	Shards int64
+
+	gs graphstore.Sharded
+}
+
+// Setup opens the GraphStore.
+func (r *readShard) Setup() error {
+	gs, err := gsutil.ParseGraphStore(r.Spec)
+	if err != nil {
+		return fmt.Errorf("error opening GraphStore %q: %v", r.Spec, err)
+	}
+	sharded, ok := gs.(graphstore.Sharded)
+	if !ok {
+		gs.Close(context.Background())
+		return fmt.Errorf("GraphStore %q (%T) does not support sharding", r.Spec, gs)
+	}
+	r.gs = sharded
+	return nil
+}
+
+// ProcessElement emits each *spb.Entry in the given shard.
+func (r *readShard) ProcessElement(ctx context.Context, shard int64, emit func(*spb.Entry)) error {
+	return r.gs.Shard(ctx, &spb.ShardRequest{Index: shard, Shards: r.Shards}, func(e *spb.Entry) error {
+		emit(e)
+		return nil
+	})
+}
+
+// Teardown closes the GraphStore.
+func (r *readShard) Teardown() error {
+	if r.gs == nil {
+		return nil
+	}
+	return r.gs.Close(context.Background())
+}
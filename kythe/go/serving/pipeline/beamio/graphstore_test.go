@@ -0,0 +1,98 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package beamio
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/util/compare"
+
+	"github.com/apache/beam/sdks/go/pkg/beam"
+	"github.com/apache/beam/sdks/go/pkg/beam/testing/ptest"
+	"github.com/google/go-cmp/cmp"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func TestReadGraphStore(t *testing.T) {
+	ctx := context.Background()
+	var entries []*spb.Entry
+	for _, sig := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		entries = append(entries, &spb.Entry{
+			Source:    &spb.VName{Signature: sig},
+			FactName:  "/kythe/node/kind",
+			FactValue: []byte("record"),
+		}, &spb.Entry{
+			Source:   &spb.VName{Signature: sig},
+			EdgeKind: "/kythe/edge/childof",
+			Target:   &spb.VName{Signature: "a"},
+			FactName: "/",
+		})
+	}
+
+	path, err := ioutil.TempDir("", "graphstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+	gs, err := goleveldb.OpenGraphStore(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if err := gs.Write(ctx, &spb.WriteRequest{
+			Source: e.Source,
+			Update: []*spb.WriteRequest_Update{{
+				EdgeKind:  e.EdgeKind,
+				Target:    e.Target,
+				FactName:  e.FactName,
+				FactValue: e.FactValue,
+			}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := gs.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	p, s := beam.NewPipelineWithRoot()
+
+	coll := ReadGraphStore(s, "goleveldb:"+path, 3)
+
+	var found []*spb.Entry
+	beam.ParDo(s, func(e *spb.Entry, emit func(*spb.Entry)) { found = append(found, e) }, coll)
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatal(err)
+	}
+
+	sortEntries(entries)
+	sortEntries(found)
+	if diff := cmp.Diff(entries, found, ignoreProtoXXXFields); diff != "" {
+		t.Fatalf("Diff found (-expected; +found):\n%s", diff)
+	}
+}
+
+func sortEntries(es []*spb.Entry) {
+	sort.Slice(es, func(i, j int) bool { return compare.Entries(es[i], es[j]) == compare.LT })
+}
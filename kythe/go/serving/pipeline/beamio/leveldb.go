@@ -26,9 +26,10 @@ import (
 	"log"
 	"path/filepath"
 	"reflect"
-	"sort"
 	"time"
 
+	"kythe.io/kythe/go/util/disksort"
+
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/core/runtime/exec"
 	"github.com/apache/beam/sdks/go/pkg/beam/io/filesystem"
@@ -50,6 +51,10 @@ func init() {
 // key-value entry according to their enclosing PCollection's beam.Coder.  Each
 // table may have different KV types.  Keys must be unique across all
 // PCollections.
+//
+// Each shard is sorted on disk so, when run by the disksort runner
+// (kythe.io/third_party/beam/sdks/go/pkg/beam/runners/disksort), the sink's
+// memory usage is bounded regardless of the size of its input.
 func WriteLevelDB(s beam.Scope, path string, numShards int, tables ...beam.PCollection) {
 	filesystem.ValidateScheme(path)
 	s = s.Scope("WriteLevelDB")
@@ -68,7 +73,7 @@ func WriteLevelDB(s beam.Scope, path string, numShards int, tables ...beam.PColl
 
 	// Write each shard to a separate SSTable.  The resulting PCollection contains
 	// each SSTable's metadata (*tableMetadata).
-	tableMetadata := beam.ParDo(s, &writeTable{path}, shards)
+	tableMetadata := beam.ParDo(s, &writeTable{Path: path}, shards)
 
 	// Write all SSTable metadata to the LevelDB's MANIFEST journal.
 	beam.ParDo(s, &writeManifest{Path: path}, beam.GroupByKey(s, beam.AddFixedKey(s, tableMetadata)))
@@ -204,7 +209,13 @@ func putBytes(w io.Writer, b []byte) error {
 	return err
 }
 
-type writeTable struct{ Path string }
+type writeTable struct {
+	Path string
+
+	// MaxInMemory is the maximum number of keyValues kept in memory while
+	// sorting a shard.  If non-positive, disksort.DefaultMaxInMemory is used.
+	MaxInMemory int
+}
 
 // tableMetadata represents a single SSTable within a LevelDB.  Each SSTable
 // written by the LevelDB sink is a level-0 table (meaning that its key ranges
@@ -225,9 +236,9 @@ type tableMetadata struct {
 
 var duplicateLevelDBKeysCounter = beam.NewCounter("kythe.beamio.leveldb", "duplicate-keys")
 
-// ProcessElement writes a set of keyValues to the an SSTable per shard.  Shards
-// should be small enough to fit into memory so that they can be sorted.
-// TODO(BEAM-4405): use SortValues extension to remove in-memory requirement
+// ProcessElement writes a set of keyValues to the an SSTable per shard.  The
+// shard's keyValues are sorted using a disksort so that only a bounded number
+// are kept in memory at once.
 func (w *writeTable) ProcessElement(ctx context.Context, shard int, kvIter func(*keyValue) bool, emit func(tableMetadata)) error {
 	opts := &opt.Options{
 		BlockSize: 5 * opt.MiB,
@@ -240,36 +251,21 @@ func (w *writeTable) ProcessElement(ctx context.Context, shard int, kvIter func(
 	}(time.Now())
 	md := tableMetadata{Shard: shard + 1}
 
-	var els []keyValue
+	sorter, err := disksort.NewMergeSorter(disksort.MergeOptions{
+		Lesser:         keyValueLesser{},
+		Marshaler:      keyValueMarshaler{},
+		MaxInMemory:    w.MaxInMemory,
+		CompressShards: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating sorter: %v", err)
+	}
 	var kv keyValue
 	for kvIter(&kv) {
-		els = append(els, kv)
-	}
-	sort.Slice(els, func(i, j int) bool {
-		return bytes.Compare(els[i].Key, els[j].Key) < 0
-	})
-
-	// Remove duplicate keys
-	j := 1
-	for i := 1; i < len(els); i++ {
-		if bytes.Equal(els[j-1].Key, els[i].Key) {
-			duplicateLevelDBKeysCounter.Inc(ctx, 1)
-		} else {
-			els[j] = els[i]
-			j++
+		if err := sorter.Add(kv); err != nil {
+			return fmt.Errorf("error sorting key-value: %v", err)
 		}
 	}
-	els = els[:j]
-
-	// Encode keys for LevelDB
-	for i := 0; i < len(els); i++ {
-		md.Seq++
-		els[i].Key = makeLevelDBKey(uint64(md.Seq), els[i].Key)
-	}
-
-	totalElements = len(els)
-	md.First = els[0].Key
-	md.Last = els[len(els)-1].Key
 
 	// Write each sorted key-value to an SSTable.
 	f, err := openWrite(ctx, filepath.Join(w.Path, fmt.Sprintf("%06d.ldb", md.Shard)))
@@ -277,10 +273,26 @@ func (w *writeTable) ProcessElement(ctx context.Context, shard int, kvIter func(
 		return err
 	}
 	wr := table.NewWriter(f, opts)
-	for _, kv := range els {
-		if err := wr.Append(kv.Key, kv.Value); err != nil {
-			return err
+	var lastKey []byte
+	if err := sorter.Read(func(i interface{}) error {
+		kv := i.(keyValue)
+		if md.First != nil && bytes.Equal(lastKey, kv.Key) {
+			duplicateLevelDBKeysCounter.Inc(ctx, 1)
+			return nil
+		}
+		lastKey = append(lastKey[:0], kv.Key...)
+
+		// Encode keys for LevelDB
+		md.Seq++
+		key := makeLevelDBKey(uint64(md.Seq), kv.Key)
+		if md.First == nil {
+			md.First = key
 		}
+		md.Last = key
+		totalElements++
+		return wr.Append(key, kv.Value)
+	}); err != nil {
+		return err
 	}
 	if err := wr.Close(); err != nil {
 		return err
@@ -293,6 +305,43 @@ func (w *writeTable) ProcessElement(ctx context.Context, shard int, kvIter func(
 	return nil
 }
 
+// keyValueLesser orders keyValues by their keys.
+type keyValueLesser struct{}
+
+// Less implements the sortutil.Lesser interface.
+func (keyValueLesser) Less(a, b interface{}) bool {
+	return bytes.Compare(a.(keyValue).Key, b.(keyValue).Key) < 0
+}
+
+// keyValueMarshaler encodes keyValues as a varint-prefixed key followed by the
+// value.
+type keyValueMarshaler struct{}
+
+// Marshal implements part of the disksort.Marshaler interface.
+func (keyValueMarshaler) Marshal(x interface{}) ([]byte, error) {
+	kv := x.(keyValue)
+	buf := make([]byte, binary.MaxVarintLen64+len(kv.Key)+len(kv.Value))
+	n := binary.PutUvarint(buf, uint64(len(kv.Key)))
+	n += copy(buf[n:], kv.Key)
+	n += copy(buf[n:], kv.Value)
+	return buf[:n], nil
+}
+
+// Unmarshal implements part of the disksort.Marshaler interface.  The
+// returned keyValue does not share memory with rec, which the disksort may
+// reuse for the following record.
+func (keyValueMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	size, n := binary.Uvarint(rec)
+	if n <= 0 || uint64(len(rec)-n) < size {
+		return nil, fmt.Errorf("invalid keyValue encoding")
+	}
+	rec = rec[n:]
+	return keyValue{
+		Key:   append([]byte(nil), rec[:size]...),
+		Value: append([]byte(nil), rec[size:]...),
+	}, nil
+}
+
 type shardKeyValue struct{ Shards int }
 
 func (s *shardKeyValue) ProcessElement(kv keyValue) (int, keyValue) {
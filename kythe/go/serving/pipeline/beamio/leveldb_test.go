@@ -17,9 +17,12 @@
 package beamio
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 	"unicode"
@@ -27,6 +30,9 @@ import (
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/testing/ptest"
 	"github.com/jmhodges/levigo"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/table"
 
 	_ "github.com/apache/beam/sdks/go/pkg/beam/io/filesystem/local"
 )
@@ -106,3 +112,66 @@ func TestLevelDBSink(t *testing.T) {
 }
 
 func extendToKey(v beam.T) (beam.T, beam.T) { return v, v }
+
+func TestWriteTableSpill(t *testing.T) {
+	var keys []string
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprintf("key%02d", i))
+	}
+	// Add each key twice in a shuffled order; each should be written once.
+	kvs := make([]keyValue, 0, 2*len(keys))
+	for _, k := range append(keys, keys...) {
+		kvs = append(kvs, keyValue{Key: []byte(k), Value: []byte("value:" + k)})
+	}
+	rand.New(rand.NewSource(0)).Shuffle(len(kvs), func(i, j int) { kvs[i], kvs[j] = kvs[j], kvs[i] })
+
+	path, err := ioutil.TempDir("", "leveldb")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	// Force the shard's sort to spill to disk.
+	w := &writeTable{Path: path, MaxInMemory: 4}
+	var md []tableMetadata
+	if err := w.ProcessElement(context.Background(), 0, func(kv *keyValue) bool {
+		if len(kvs) == 0 {
+			return false
+		}
+		*kv, kvs = kvs[0], kvs[1:]
+		return true
+	}, func(m tableMetadata) { md = append(md, m) }); err != nil {
+		t.Fatal(err)
+	} else if len(md) != 1 || md[0].Seq != len(keys) {
+		t.Fatalf("Unexpected table metadata: %+v", md)
+	}
+
+	f, err := os.Open(filepath.Join(path, "000001.ldb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := table.NewReader(f, int64(md[0].Size), storage.FileDesc{Type: storage.TypeTable, Num: 1}, nil, nil, &opt.Options{Comparer: keyComparer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	var found []string
+	iter := r.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		// Strip the LevelDB key's 8-byte sequence/type suffix.
+		key := string(iter.Key()[:len(iter.Key())-8])
+		if val := string(iter.Value()); val != "value:"+key {
+			t.Errorf("Expected value %q for key %q; found %q", "value:"+key, key, val)
+		}
+		found = append(found, key)
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(found) != fmt.Sprint(keys) {
+		t.Errorf("Expected keys %v; found %v", keys, found)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/disksort"
+	"kythe.io/kythe/go/util/markedsource"
+
+	"github.com/golang/protobuf/proto"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// identifierMatch returns an IdentifierMatch for the node with the given
+// ticket, kinds, and /kythe/code fact value.  The identifier's base and
+// qualified names are rendered from the node's MarkedSource; a node whose
+// MarkedSource has no context is identified by its base name alone.  nil is
+// returned for nodes without an identifier.
+func identifierMatch(ticket, kind, subkind string, code []byte) (*srvpb.IdentifierMatch, error) {
+	var ms cpb.MarkedSource
+	if err := proto.Unmarshal(code, &ms); err != nil {
+		return nil, err
+	}
+	info := markedsource.RenderQualifiedName(&ms)
+	if info.BaseName == "" {
+		return nil, nil
+	}
+	qname := info.QualifiedName
+	if qname == "" {
+		qname = info.BaseName
+	}
+	return &srvpb.IdentifierMatch{
+		QualifiedName: qname,
+		BaseName:      info.BaseName,
+		Node: []*srvpb.IdentifierMatch_Node{{
+			Ticket:      ticket,
+			NodeKind:    kind,
+			NodeSubkind: subkind,
+		}},
+	}, nil
+}
+
+// writeIdentifiers writes the identifier table, along with its search index,
+// for the single-node IdentifierMatch records in sorted.  Records sharing a
+// qualified name are merged.  The search index postings are disk-sorted by
+// their key so that only a single key's postings are held in memory at once.
+func writeIdentifiers(ctx context.Context, opts *Options, sorted disksort.Interface, out table.Proto) error {
+	postings, err := opts.diskSorter(postingLesser{}, postingMarshaler{})
+	if err != nil {
+		return err
+	}
+
+	buffer := out.Buffered()
+	var match *srvpb.IdentifierMatch
+	flush := func() error {
+		if match == nil {
+			return nil
+		}
+		for _, key := range identifiers.SearchKeys(match.QualifiedName, match.BaseName) {
+			if err := postings.Add(posting{Key: key, QualifiedName: match.QualifiedName, BaseName: match.BaseName}); err != nil {
+				return err
+			}
+		}
+		return buffer.Put(ctx, []byte(match.QualifiedName), match)
+	}
+	if err := sorted.Read(func(i interface{}) error {
+		m := i.(*srvpb.IdentifierMatch)
+		if match != nil && match.QualifiedName == m.QualifiedName {
+			match.Node = append(match.Node, m.Node...)
+			return nil
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		match = m
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := buffer.Flush(ctx); err != nil {
+		return err
+	}
+	return writePostings(ctx, postings, out)
+}
+
+// writePostings writes the IdentifierPostings shards for each search key of
+// the postings in sorted.
+func writePostings(ctx context.Context, sorted disksort.Interface, out table.Proto) error {
+	buffer := out.Buffered()
+	put := func(key string, p *srvpb.IdentifierPostings) error { return buffer.Put(ctx, []byte(key), p) }
+	var (
+		key string
+		w   *identifiers.PostingsWriter
+	)
+	if err := sorted.Read(func(i interface{}) error {
+		x := i.(posting)
+		if w == nil || x.Key != key {
+			if w != nil {
+				if err := w.Close(); err != nil {
+					return err
+				}
+			}
+			key, w = x.Key, identifiers.NewPostingsWriter(x.Key, put)
+		}
+		return w.Add(x.QualifiedName, x.BaseName)
+	}); err != nil {
+		return err
+	}
+	if w != nil {
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+	return buffer.Flush(ctx)
+}
+
+// A posting records an identifier's names under a key of the identifier
+// search index.
+type posting struct{ Key, QualifiedName, BaseName string }
+
+// postingLesser orders postings by their key and then qualified name.
+type postingLesser struct{}
+
+func (postingLesser) Less(a, b interface{}) bool {
+	x, y := a.(posting), b.(posting)
+	if x.Key == y.Key {
+		return x.QualifiedName < y.QualifiedName
+	}
+	return x.Key < y.Key
+}
+
+// postingMarshaler encodes postings as a varint-prefixed key and qualified
+// name followed by the base name.
+type postingMarshaler struct{}
+
+func (postingMarshaler) Marshal(x interface{}) ([]byte, error) {
+	p := x.(posting)
+	buf := make([]byte, 2*binary.MaxVarintLen64+len(p.Key)+len(p.QualifiedName)+len(p.BaseName))
+	n := binary.PutUvarint(buf, uint64(len(p.Key)))
+	n += copy(buf[n:], p.Key)
+	n += binary.PutUvarint(buf[n:], uint64(len(p.QualifiedName)))
+	n += copy(buf[n:], p.QualifiedName)
+	n += copy(buf[n:], p.BaseName)
+	return buf[:n], nil
+}
+
+func (postingMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var fields [2]string
+	for i := range fields {
+		size, n := binary.Uvarint(rec)
+		if n <= 0 || uint64(len(rec)-n) < size {
+			return nil, errors.New("invalid posting encoding")
+		}
+		fields[i], rec = string(rec[n:n+int(size)]), rec[n+int(size):]
+	}
+	return posting{Key: fields[0], QualifiedName: fields[1], BaseName: string(rec)}, nil
+}
+
+type identifierLesser struct{}
+
+func (identifierLesser) Less(a, b interface{}) bool {
+	x, y := a.(*srvpb.IdentifierMatch), b.(*srvpb.IdentifierMatch)
+	if x.QualifiedName == y.QualifiedName {
+		return x.Node[0].Ticket < y.Node[0].Ticket
+	}
+	return x.QualifiedName < y.QualifiedName
+}
+
+type identifierMarshaler struct{}
+
+func (identifierMarshaler) Marshal(x interface{}) ([]byte, error) {
+	return proto.Marshal(x.(proto.Message))
+}
+
+func (identifierMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var m srvpb.IdentifierMatch
+	return &m, proto.Unmarshal(rec, &m)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/storage/table"
+
+	"github.com/golang/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+func TestWriteIdentifiersSpill(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "identifiers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := goleveldb.Open(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	tbl := &table.KVProto{DB: db}
+
+	// A tiny shard size forces both the matches and postings to spill to disk.
+	opts := &Options{MaxShardSize: 2}
+	sorted, err := opts.diskSorter(identifierLesser{}, identifierMarshaler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := []*srvpb.IdentifierMatch{
+		{QualifiedName: "kzip.NewWriter", BaseName: "NewWriter", Node: []*srvpb.IdentifierMatch_Node{{Ticket: "kythe:#w"}}},
+		{QualifiedName: "kzip.NewReader", BaseName: "NewReader", Node: []*srvpb.IdentifierMatch_Node{{Ticket: "kythe:#r"}}},
+		{QualifiedName: "riegeli.NewWriter", BaseName: "NewWriter", Node: []*srvpb.IdentifierMatch_Node{{Ticket: "kythe:#rw"}}},
+		{QualifiedName: "kzip.NewWriter", BaseName: "NewWriter", Node: []*srvpb.IdentifierMatch_Node{{Ticket: "kythe:#w2"}}},
+	}
+	for _, m := range matches {
+		if err := sorted.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeIdentifiers(ctx, opts, sorted, tbl); err != nil {
+		t.Fatalf("writeIdentifiers error: %v", err)
+	}
+
+	var match srvpb.IdentifierMatch
+	if err := tbl.Lookup(ctx, []byte("kzip.NewWriter"), &match); err != nil {
+		t.Fatalf("Lookup error: %v", err)
+	} else if len(match.Node) != 2 {
+		t.Errorf("Found %d nodes for kzip.NewWriter; want 2", len(match.Node))
+	}
+
+	want := make(map[string]*srvpb.IdentifierPostings)
+	// Postings are recorded in order of their qualified names.
+	for _, m := range []*srvpb.IdentifierMatch{matches[1], matches[0], matches[2]} {
+		for _, key := range identifiers.SearchKeys(m.QualifiedName, m.BaseName) {
+			p, ok := want[key]
+			if !ok {
+				p = &srvpb.IdentifierPostings{ShardCount: 1}
+				want[key] = p
+			}
+			p.QualifiedName = append(p.QualifiedName, m.QualifiedName)
+			p.BaseName = append(p.BaseName, m.BaseName)
+		}
+	}
+	for key, want := range want {
+		var got srvpb.IdentifierPostings
+		if err := tbl.Lookup(ctx, []byte(key), &got); err != nil {
+			t.Errorf("Lookup(%q) error: %v", key, err)
+			continue
+		}
+		if !proto.Equal(want, &got) {
+			t.Errorf("Postings for %q: got %v; want %v", key, &got, want)
+		}
+	}
+}
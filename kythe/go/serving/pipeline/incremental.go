@@ -0,0 +1,573 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/stream"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
+
+	"github.com/golang/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// Update incrementally updates the serving table in db, which must have been
+// previously written by Run, with a delta of GraphStore-ordered entries.
+//
+// The delta must contain every entry whose source VName shares a corpus, root,
+// and path with one of the changed files: each file node in the delta and each
+// file given in changed (which allows files to be removed by passing them
+// without any corresponding entries).  All nodes, anchors, and edges owned by
+// the changed files are replaced by those in the delta.  Only the
+// FileDecorations of the changed files and the PagedCrossReferences and
+// PagedEdgeSets (along with their pages) of the nodes they touch are
+// recomputed; all other keys are left as-is.  Notably, the file tree,
+// identifiers, documentation, and status of the table are not updated and the
+// FileDecorations of unchanged files may refer to stale definition locations.
+//
+// All modifications to db are applied through a single keyvalue.Writer so that
+// readers of db never see a partially updated table if the DB supports atomic
+// batches.  If the update fails, the Writer's batch is discarded when it
+// implements keyvalue.Discarder, leaving db unchanged.  opts.Status is ignored.
+func Update(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, changed []*spb.VName, opts *Options) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	files := make(fileSet)
+	for _, f := range changed {
+		files.add(f)
+	}
+
+	dir, err := ioutil.TempDir("", "kythe_delta_table")
+	if err != nil {
+		return fmt.Errorf("error creating delta table directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	deltaDB, err := goleveldb.Open(dir, nil)
+	if err != nil {
+		return fmt.Errorf("error opening delta table: %v", err)
+	}
+	defer deltaDB.Close()
+
+	log.Println("Writing delta serving table")
+	deltaOpts := *opts
+	deltaOpts.Status = nil
+	if err := Run(ctx, func(f func(*spb.Entry) error) error {
+		return rd(func(e *spb.Entry) error {
+			if e.FactName == facts.NodeKind && string(e.FactValue) == nodes.File {
+				files.add(e.Source)
+			}
+			return f(e)
+		})
+	}, deltaDB, &deltaOpts); err != nil {
+		return fmt.Errorf("error writing delta table: %v", err)
+	}
+
+	wr, err := db.Writer()
+	if err != nil {
+		return err
+	}
+	u := &updater{
+		opts:  opts,
+		files: files,
+		old:   &table.KVProto{DB: db},
+		delta: &table.KVProto{DB: deltaDB},
+		wr:    wr,
+	}
+	if err := u.update(ctx, deltaDB); err != nil {
+		if d, ok := wr.(keyvalue.Discarder); ok {
+			d.Discard()
+		} else {
+			wr.Close()
+		}
+		return err
+	}
+	return wr.Close()
+}
+
+// A fileSet is a set of files keyed by their corpus, root, and path.
+type fileSet map[kytheuri.URI]bool
+
+func (s fileSet) add(file *spb.VName) {
+	uri := kytheuri.FromVName(file)
+	s[kytheuri.URI{Corpus: uri.Corpus, Root: uri.Root, Path: uri.Path}] = true
+}
+
+// owns reports whether the node with the given ticket is located within one of
+// the files in s.
+func (s fileSet) owns(ticket string) bool {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil || uri.Path == "" {
+		return false
+	}
+	return s[kytheuri.URI{Corpus: uri.Corpus, Root: uri.Root, Path: uri.Path}]
+}
+
+// tickets returns the sorted file tickets of s.
+func (s fileSet) tickets() []string {
+	var ts []string
+	for f := range s {
+		ts = append(ts, f.String())
+	}
+	sort.Strings(ts)
+	return ts
+}
+
+// An updater merges a delta serving table into an existing table.
+type updater struct {
+	opts  *Options
+	files fileSet
+
+	old, delta table.Proto
+	wr         keyvalue.Writer
+
+	nodes map[string]*srvpb.Node // cache for completeNode
+}
+
+func (u *updater) update(ctx context.Context, deltaDB keyvalue.DB) error {
+	affected := make(map[string]bool)
+	u.nodes = make(map[string]*srvpb.Node)
+
+	log.Println("Writing updated FileDecorations")
+	for _, file := range u.files.tickets() {
+		affected[file] = true
+		var decor srvpb.FileDecorations
+		if err := u.old.Lookup(ctx, xsrv.DecorationsKey(file), &decor); err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading decorations for %q: %v", file, err)
+		}
+		for _, d := range decor.Decoration {
+			affected[d.Anchor.Ticket] = true
+			affected[d.Target] = true
+		}
+		if err := u.wr.Delete(xsrv.DecorationsKey(file)); err != nil {
+			return err
+		}
+	}
+	if err := scanKeys(deltaDB, xsrv.DecorationsKey(""), func(key, val []byte) error {
+		var decor srvpb.FileDecorations
+		if err := proto.Unmarshal(val, &decor); err != nil {
+			return err
+		}
+		for i, n := range decor.Target {
+			var err error
+			if decor.Target[i], err = u.completeNode(ctx, n); err != nil {
+				return err
+			}
+		}
+		return u.put(key, &decor)
+	}); err != nil {
+		return fmt.Errorf("error copying delta decorations: %v", err)
+	}
+
+	for _, prefix := range [][]byte{gsrv.EdgeSetKey(""), xsrv.CrossReferencesKey("")} {
+		if err := scanKeys(deltaDB, prefix, func(key, _ []byte) error {
+			affected[string(key[len(prefix):])] = true
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error reading delta table keys: %v", err)
+		}
+	}
+
+	// Each edge of a node owned by a changed file has a mirrored edge in the
+	// edge set of its target; those targets must be updated as well.
+	for ticket := range affected {
+		if !u.files.owns(ticket) {
+			continue
+		}
+		_, groups, err := readEdgeSet(ctx, u.old, ticket)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			for _, e := range g.Edge {
+				affected[e.Target.Ticket] = true
+			}
+		}
+	}
+
+	tickets := make([]string, 0, len(affected))
+	for ticket := range affected {
+		tickets = append(tickets, ticket)
+	}
+	sort.Strings(tickets)
+
+	log.Printf("Writing updated EdgeSets and CrossReferences for %d nodes", len(tickets))
+	for _, ticket := range tickets {
+		if err := u.updateEdgeSet(ctx, ticket); err != nil {
+			return fmt.Errorf("error updating edges for %q: %v", ticket, err)
+		}
+		if err := u.updateCrossReferences(ctx, ticket); err != nil {
+			return fmt.Errorf("error updating cross-references for %q: %v", ticket, err)
+		}
+	}
+	return nil
+}
+
+// completeNode returns n with the facts recorded in the existing table if the
+// delta has no facts for n.  Only nodes owned by the changed files are
+// guaranteed to be complete in the delta table.
+func (u *updater) completeNode(ctx context.Context, n *srvpb.Node) (*srvpb.Node, error) {
+	if len(n.Fact) > 0 || u.files.owns(n.Ticket) {
+		return n, nil
+	} else if c, ok := u.nodes[n.Ticket]; ok {
+		return c, nil
+	}
+	c := n
+	var pes srvpb.PagedEdgeSet
+	if err := u.old.Lookup(ctx, gsrv.EdgeSetKey(n.Ticket), &pes); err == nil && len(pes.Source.GetFact()) > 0 {
+		c = assemble.FilterTextFacts(pes.Source)
+	} else if err != nil && err != table.ErrNoSuchKey {
+		return nil, fmt.Errorf("error reading node %q: %v", n.Ticket, err)
+	}
+	u.nodes[n.Ticket] = c
+	return c, nil
+}
+
+// put writes msg as the value for key to the updated table.
+func (u *updater) put(key []byte, msg proto.Message) error {
+	rec, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return u.wr.Write(key, rec)
+}
+
+func (u *updater) updateEdgeSet(ctx context.Context, ticket string) error {
+	oldSet, oldGroups, err := readEdgeSet(ctx, u.old, ticket)
+	if err != nil {
+		return err
+	}
+	newSet, newGroups, err := readEdgeSet(ctx, u.delta, ticket)
+	if err != nil {
+		return err
+	} else if oldSet == nil && newSet == nil {
+		return nil
+	}
+
+	if oldSet != nil {
+		if err := u.wr.Delete(gsrv.EdgeSetKey(ticket)); err != nil {
+			return err
+		}
+		for _, idx := range oldSet.PageIndex {
+			if err := u.wr.Delete(gsrv.EdgePageKey(idx.PageKey)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Edges from the delta take precedence over any (possibly stale) duplicate
+	// in the existing table.  The forward edges of an owned node and all edges
+	// to an owned node are only retained if they appear in the delta.
+	owned := u.files.owns(ticket)
+	type edgeKey struct {
+		kind, target string
+		ordinal      int32
+	}
+	seen := make(map[edgeKey]bool)
+	byKind := make(map[string][]*srvpb.EdgeGroup_Edge)
+	add := func(groups []*srvpb.EdgeGroup, filter bool) error {
+		for _, g := range groups {
+			for _, e := range g.Edge {
+				if filter && (u.files.owns(e.Target.Ticket) || (owned && edges.IsForward(g.Kind))) {
+					continue
+				}
+				k := edgeKey{g.Kind, e.Target.Ticket, e.Ordinal}
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				if !filter {
+					var err error
+					if e.Target, err = u.completeNode(ctx, e.Target); err != nil {
+						return err
+					}
+				}
+				byKind[g.Kind] = append(byKind[g.Kind], e)
+			}
+		}
+		return nil
+	}
+	if err := add(newGroups, false); err != nil {
+		return err
+	} else if err := add(oldGroups, true); err != nil {
+		return err
+	}
+
+	var src *srvpb.Node
+	switch {
+	case newSet != nil && len(newSet.Source.GetFact()) > 0:
+		src = newSet.Source
+	case oldSet != nil && !owned:
+		src = oldSet.Source
+	case newSet != nil:
+		src = newSet.Source
+	}
+	if src == nil && len(byKind) == 0 {
+		// The owned node was removed and nothing else refers to it.
+		return nil
+	} else if src == nil {
+		src = &srvpb.Node{Ticket: ticket}
+	}
+
+	esb := &assemble.EdgeSetBuilder{
+		MaxEdgePageSize: u.opts.MaxPageSize,
+		Output: func(ctx context.Context, pes *srvpb.PagedEdgeSet) error {
+			return u.put(gsrv.EdgeSetKey(pes.Source.Ticket), pes)
+		},
+		OutputPage: func(ctx context.Context, ep *srvpb.EdgePage) error {
+			return u.put(gsrv.EdgePageKey(ep.PageKey), ep)
+		},
+	}
+	if err := esb.StartEdgeSet(ctx, src); err != nil {
+		return err
+	}
+	for _, kind := range sortedKeys(byKind) {
+		es := byKind[kind]
+		sort.Slice(es, func(i, j int) bool {
+			if es[i].Ordinal == es[j].Ordinal {
+				return es[i].Target.Ticket < es[j].Target.Ticket
+			}
+			return es[i].Ordinal < es[j].Ordinal
+		})
+		if err := esb.AddGroup(ctx, &srvpb.EdgeGroup{Kind: kind, Edge: es}); err != nil {
+			return err
+		}
+	}
+	return esb.Flush(ctx)
+}
+
+func (u *updater) updateCrossReferences(ctx context.Context, ticket string) error {
+	oldSet, oldGroups, err := readCrossReferences(ctx, u.old, ticket)
+	if err != nil {
+		return err
+	}
+	newSet, newGroups, err := readCrossReferences(ctx, u.delta, ticket)
+	if err != nil {
+		return err
+	} else if oldSet == nil && newSet == nil {
+		return nil
+	}
+
+	if oldSet != nil {
+		if err := u.wr.Delete(xsrv.CrossReferencesKey(ticket)); err != nil {
+			return err
+		}
+		for _, idx := range oldSet.PageIndex {
+			if err := u.wr.Delete(xsrv.CrossReferencesPageKey(idx.PageKey)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Anchors, related nodes, and callers are kept in separate groups for each
+	// kind; references from the changed files are only retained if they appear
+	// in the delta.
+	anchors := make(map[string][]*srvpb.ExpandedAnchor)
+	related := make(map[string][]*srvpb.PagedCrossReferences_RelatedNode)
+	callers := make(map[string][]*srvpb.PagedCrossReferences_Caller)
+	seen := make(map[string]bool)
+	add := func(groups []*srvpb.PagedCrossReferences_Group, filter bool) {
+		for _, g := range groups {
+			for _, a := range g.Anchor {
+				if k := "a\000" + g.Kind + "\000" + a.Ticket; !seen[k] && !(filter && u.files.owns(a.Ticket)) {
+					seen[k] = true
+					anchors[g.Kind] = append(anchors[g.Kind], a)
+				}
+			}
+			for _, rn := range g.RelatedNode {
+				if k := fmt.Sprintf("r\000%s\000%s\000%d", g.Kind, rn.Node.GetTicket(), rn.Ordinal); !seen[k] && !(filter && u.files.owns(rn.Node.GetTicket())) {
+					seen[k] = true
+					related[g.Kind] = append(related[g.Kind], rn)
+				}
+			}
+			for _, c := range g.Caller {
+				if k := "c\000" + g.Kind + "\000" + c.Caller.GetTicket(); !seen[k] && !(filter && u.files.owns(c.Caller.GetTicket())) {
+					seen[k] = true
+					callers[g.Kind] = append(callers[g.Kind], c)
+				}
+			}
+		}
+	}
+	add(newGroups, false)
+	add(oldGroups, true)
+	if len(anchors)+len(related)+len(callers) == 0 {
+		return nil
+	}
+
+	// Prefer the delta's view of owned nodes and the existing table's view of
+	// all others, filling in any missing details from the other.
+	primary, secondary := oldSet, newSet
+	if newSet != nil && (oldSet == nil || u.files.owns(ticket)) {
+		primary, secondary = newSet, oldSet
+	}
+	hdr := &srvpb.PagedCrossReferences{
+		Incomplete:   primary.Incomplete,
+		SourceNode:   primary.SourceNode,
+		MarkedSource: primary.MarkedSource,
+		MergeWith:    primary.MergeWith,
+	}
+	if secondary != nil {
+		if hdr.SourceNode == nil {
+			hdr.SourceNode = secondary.SourceNode
+		}
+		if hdr.MarkedSource == nil {
+			hdr.MarkedSource = secondary.MarkedSource
+		}
+		if len(hdr.MergeWith) == 0 {
+			hdr.MergeWith = secondary.MergeWith
+		}
+	}
+
+	xb := &assemble.CrossReferencesBuilder{
+		MaxPageSize: u.opts.MaxPageSize,
+		Output: func(ctx context.Context, s *srvpb.PagedCrossReferences) error {
+			s.Incomplete = hdr.Incomplete
+			s.SourceNode = hdr.SourceNode
+			s.MarkedSource = hdr.MarkedSource
+			s.MergeWith = hdr.MergeWith
+			return u.put(xsrv.CrossReferencesKey(s.SourceTicket), s)
+		},
+		OutputPage: func(ctx context.Context, p *srvpb.PagedCrossReferences_Page) error {
+			return u.put(xsrv.CrossReferencesPageKey(p.PageKey), p)
+		},
+	}
+	if err := xb.StartSet(ctx, &srvpb.Node{Ticket: ticket}); err != nil {
+		return err
+	}
+	for _, kind := range sortedKeys(anchors) {
+		as := anchors[kind]
+		sort.Slice(as, func(i, j int) bool {
+			x, y := as[i].Span, as[j].Span
+			if x.GetStart().GetByteOffset() != y.GetStart().GetByteOffset() {
+				return x.GetStart().GetByteOffset() < y.GetStart().GetByteOffset()
+			} else if x.GetEnd().GetByteOffset() != y.GetEnd().GetByteOffset() {
+				return x.GetEnd().GetByteOffset() < y.GetEnd().GetByteOffset()
+			}
+			return as[i].Ticket < as[j].Ticket
+		})
+		// Like Run, add each anchor separately so that pages are split identically.
+		for _, a := range as {
+			if err := xb.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{Kind: kind, Anchor: []*srvpb.ExpandedAnchor{a}}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, kind := range sortedKeys(related) {
+		if err := xb.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{Kind: kind, RelatedNode: related[kind]}); err != nil {
+			return err
+		}
+	}
+	for _, kind := range sortedKeys(callers) {
+		if err := xb.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{Kind: kind, Caller: callers[kind]}); err != nil {
+			return err
+		}
+	}
+	return xb.Flush(ctx)
+}
+
+// readEdgeSet returns the PagedEdgeSet for ticket along with all of its groups,
+// including those stored in separate EdgePages.  If no such set exists, a nil
+// set is returned.
+func readEdgeSet(ctx context.Context, t table.Proto, ticket string) (*srvpb.PagedEdgeSet, []*srvpb.EdgeGroup, error) {
+	var pes srvpb.PagedEdgeSet
+	if err := t.Lookup(ctx, gsrv.EdgeSetKey(ticket), &pes); err == table.ErrNoSuchKey {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("error reading edge set for %q: %v", ticket, err)
+	}
+	groups := pes.Group
+	for _, idx := range pes.PageIndex {
+		var ep srvpb.EdgePage
+		if err := t.Lookup(ctx, gsrv.EdgePageKey(idx.PageKey), &ep); err != nil {
+			return nil, nil, fmt.Errorf("error reading edge page %q: %v", idx.PageKey, err)
+		}
+		groups = append(groups, ep.EdgesGroup)
+	}
+	return &pes, groups, nil
+}
+
+// readCrossReferences returns the PagedCrossReferences for ticket along with all
+// of its groups, including those stored in separate pages.  If no such set
+// exists, a nil set is returned.
+func readCrossReferences(ctx context.Context, t table.Proto, ticket string) (*srvpb.PagedCrossReferences, []*srvpb.PagedCrossReferences_Group, error) {
+	var cr srvpb.PagedCrossReferences
+	if err := t.Lookup(ctx, xsrv.CrossReferencesKey(ticket), &cr); err == table.ErrNoSuchKey {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("error reading cross-references for %q: %v", ticket, err)
+	}
+	groups := cr.Group
+	for _, idx := range cr.PageIndex {
+		var p srvpb.PagedCrossReferences_Page
+		if err := t.Lookup(ctx, xsrv.CrossReferencesPageKey(idx.PageKey), &p); err != nil {
+			return nil, nil, fmt.Errorf("error reading cross-references page %q: %v", idx.PageKey, err)
+		}
+		groups = append(groups, p.Group)
+	}
+	return &cr, groups, nil
+}
+
+// scanKeys calls f for each key-value in db with the given key prefix.
+func scanKeys(db keyvalue.DB, prefix []byte, f func(key, val []byte) error) error {
+	it, err := db.ScanPrefix(prefix, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		} else if err := f(key, val); err != nil {
+			return err
+		}
+	}
+}
+
+// sortedKeys returns the sorted keys of the given string-keyed map.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
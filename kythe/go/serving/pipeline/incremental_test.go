@@ -0,0 +1,243 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func vname(path, sig string) *spb.VName {
+	v := &spb.VName{Corpus: "corpus", Path: path, Signature: sig}
+	if sig != "" {
+		v.Language = "go"
+	}
+	return v
+}
+
+func fact(src *spb.VName, name, val string) *spb.Entry {
+	return &spb.Entry{Source: src, FactName: name, FactValue: []byte(val)}
+}
+
+func edge(src *spb.VName, kind string, tgt *spb.VName) *spb.Entry {
+	return &spb.Entry{Source: src, EdgeKind: kind, Target: tgt, FactName: "/"}
+}
+
+func file(path, text string) []*spb.Entry {
+	f := vname(path, "")
+	return []*spb.Entry{fact(f, facts.NodeKind, nodes.File), fact(f, facts.Text, text)}
+}
+
+func anchor(path, sig, start, end, kind string, tgt *spb.VName) []*spb.Entry {
+	a := vname(path, sig)
+	return []*spb.Entry{
+		fact(a, facts.NodeKind, nodes.Anchor),
+		fact(a, facts.AnchorStart, start),
+		fact(a, facts.AnchorEnd, end),
+		edge(a, kind, tgt),
+	}
+}
+
+func entries(es ...[]*spb.Entry) []*spb.Entry {
+	var all []*spb.Entry
+	for _, e := range es {
+		all = append(all, e...)
+	}
+	sort.Slice(all, func(i, j int) bool { return compare.Entries(all[i], all[j]) == compare.LT })
+	return all
+}
+
+var (
+	funcN = vname("a.go", "N")
+	varM  = vname("b.go", "M")
+
+	unchangedEntries = entries(
+		file("a.go", "func N()"),
+		[]*spb.Entry{fact(funcN, facts.NodeKind, nodes.Function)},
+		anchor("a.go", "a0", "5", "6", edges.DefinesBinding, funcN),
+		file("c.go", "N"),
+		anchor("c.go", "c0", "0", "1", edges.Ref, funcN),
+	)
+	oldEntries = entries(
+		file("b.go", "N(); N()"),
+		anchor("b.go", "b0", "0", "1", edges.Ref, funcN),
+		anchor("b.go", "b1", "5", "6", edges.Ref, funcN),
+	)
+	newEntries = entries(
+		file("b.go", "var M = N()"),
+		[]*spb.Entry{
+			fact(varM, facts.NodeKind, nodes.Variable),
+			edge(varM, edges.ChildOf, funcN),
+		},
+		anchor("b.go", "bM", "4", "5", edges.DefinesBinding, varM),
+		anchor("b.go", "b2", "8", "9", edges.Ref, funcN),
+	)
+)
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		delta   []*spb.Entry
+		changed []*spb.VName
+		want    []*spb.Entry
+	}{
+		{"changed", newEntries, nil, entries(unchangedEntries, newEntries)},
+		{"removed", nil, []*spb.VName{vname("b.go", "")}, unchangedEntries},
+		{"added", entries(file("d.go", "N"), anchor("d.go", "d0", "0", "1", edges.Ref, funcN)), nil,
+			entries(unchangedEntries, oldEntries, file("d.go", "N"), anchor("d.go", "d0", "0", "1", edges.Ref, funcN))},
+	}
+
+	for _, test := range tests {
+		for _, pageSize := range []int{0, 1} {
+			opts := &Options{MaxPageSize: pageSize}
+
+			got, cleanup := writeTable(t, entries(unchangedEntries, oldEntries), opts)
+			defer cleanup()
+			if err := Update(context.Background(), entryReader(test.delta), got, test.changed, opts); err != nil {
+				t.Fatalf("%s: Update error: %v", test.name, err)
+			}
+
+			want, cleanup := writeTable(t, test.want, opts)
+			defer cleanup()
+
+			for _, prefix := range []string{"decor:", "xrefs:", "xrefPages:", "edgeSets:", "edgePages:"} {
+				g, w := readPrefix(t, got, prefix), readPrefix(t, want, prefix)
+				for key, val := range w {
+					if gv, ok := g[key]; !ok {
+						t.Errorf("%s (page size %d): missing key %q", test.name, pageSize, key)
+					} else if !bytes.Equal(gv, val) {
+						t.Errorf("%s (page size %d): mismatched value for key %q", test.name, pageSize, key)
+					}
+				}
+				for key := range g {
+					if _, ok := w[key]; !ok {
+						t.Errorf("%s (page size %d): unexpected key %q", test.name, pageSize, key)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestUpdateFailure(t *testing.T) {
+	opts := &Options{}
+	db, cleanup := writeTable(t, entries(unchangedEntries, oldEntries), opts)
+	defer cleanup()
+
+	// Corrupt the edge set of a node referenced by the delta so that the update
+	// fails after it has started writing to db.
+	wr, err := db.Writer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wr.Write(gsrv.EdgeSetKey(kytheuri.ToString(funcN)), []byte("corrupt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := wr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	prefixes := []string{"decor:", "xrefs:", "xrefPages:", "edgeSets:", "edgePages:"}
+	before := make(map[string]map[string][]byte)
+	for _, prefix := range prefixes {
+		before[prefix] = readPrefix(t, db, prefix)
+	}
+
+	if err := Update(context.Background(), entryReader(newEntries), db, nil, opts); err == nil {
+		t.Fatal("Update succeeded with a corrupt table")
+	}
+
+	for _, prefix := range prefixes {
+		got, want := readPrefix(t, db, prefix), before[prefix]
+		if len(got) != len(want) {
+			t.Errorf("Found %d keys with prefix %q; expected %d", len(got), prefix, len(want))
+		}
+		for key, val := range want {
+			if !bytes.Equal(got[key], val) {
+				t.Errorf("Value for key %q changed after failed Update", key)
+			}
+		}
+	}
+}
+
+func entryReader(es []*spb.Entry) func(func(*spb.Entry) error) error {
+	return func(f func(*spb.Entry) error) error {
+		for _, e := range es {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// writeTable writes a serving table for es to a temporary DB.  The returned
+// function closes and removes the DB.
+func writeTable(t *testing.T, es []*spb.Entry, opts *Options) (keyvalue.DB, func()) {
+	dir, err := ioutil.TempDir("", "table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := goleveldb.Open(dir, nil)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	cleanup := func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+	if err := Run(context.Background(), entryReader(es), db, opts); err != nil {
+		cleanup()
+		t.Fatalf("Run error: %v", err)
+	}
+	return db, cleanup
+}
+
+func readPrefix(t *testing.T, db keyvalue.DB, prefix string) map[string][]byte {
+	it, err := db.ScanPrefix([]byte(prefix), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+	m := make(map[string][]byte)
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			return m
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		m[string(key)] = append([]byte(nil), val...)
+	}
+}
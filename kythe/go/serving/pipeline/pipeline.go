@@ -15,7 +15,8 @@
  */
 
 // Package pipeline implements an in-process pipeline to create a combined
-// filetree and xrefs serving table from a stream of GraphStore-ordered entries.
+// filetree, xrefs, and identifiers serving table from a stream of
+// GraphStore-ordered entries.
 package pipeline
 
 import (
@@ -32,6 +33,7 @@ import (
 	"kythe.io/kythe/go/services/xrefs"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/status"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
 	"kythe.io/kythe/go/storage/keyvalue"
@@ -68,6 +70,10 @@ type Options struct {
 	// MaxShardSize is the maximum number of elements to keep in-memory before
 	// flushing an intermediary data shard to disk.
 	MaxShardSize int
+
+	// Status, if non-nil, records the corpus and language of each entry's
+	// source and is written to the serving table once the file tree is done.
+	Status *status.Builder
 }
 
 func (o *Options) diskSorter(l sortutil.Lesser, m disksort.Marshaler) (disksort.Interface, error) {
@@ -85,8 +91,8 @@ type servingOutput struct {
 	xs table.Proto
 }
 
-// Run writes the xrefs and filetree serving tables to db based on the given
-// entries (in GraphStore-order).
+// Run writes the xrefs, filetree, and identifiers serving tables to db based on
+// the given entries (in GraphStore-order).
 func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Options) error {
 	if opts == nil {
 		opts = new(Options)
@@ -161,6 +167,9 @@ func combineNodesAndEdges(ctx context.Context, opts *Options, out *servingOutput
 				tree.AddFile(e.Source)
 				// TODO(schroederc): evict finished directories (based on GraphStore order)
 			}
+			if opts.Status != nil {
+				opts.Status.AddVName(e.Source)
+			}
 			return f(e)
 		})
 	}
@@ -170,7 +179,15 @@ func combineNodesAndEdges(ctx context.Context, opts *Options, out *servingOutput
 		return nil, err
 	}
 
+	idSorter, err := opts.diskSorter(identifierLesser{}, identifierMarshaler{})
+	if err != nil {
+		return nil, err
+	}
+
 	if err := assemble.Sources(rd, func(src *ipb.Source) error {
+		if err := addIdentifier(idSorter, src); err != nil {
+			return fmt.Errorf("error adding identifier for %q: %v", src.Ticket, err)
+		}
 		return writePartialEdges(ctx, partialSorter, src)
 	}); err != nil {
 		return nil, err
@@ -181,6 +198,17 @@ func combineNodesAndEdges(ctx context.Context, opts *Options, out *servingOutput
 	}
 	tree = nil
 
+	if opts.Status != nil {
+		if err := opts.Status.Write(ctx, out.xs); err != nil {
+			return nil, fmt.Errorf("error writing status: %v", err)
+		}
+	}
+
+	log.Println("Writing identifiers")
+	if err := writeIdentifiers(ctx, opts, idSorter, out.xs); err != nil {
+		return nil, fmt.Errorf("error writing identifiers: %v", err)
+	}
+
 	log.Println("Writing complete edges")
 
 	cSorter, err := opts.diskSorter(edgeLesser{}, edgeMarshaler{})
@@ -252,6 +280,20 @@ func filterReverses(rd stream.EntryReader) stream.EntryReader {
 	}
 }
 
+// addIdentifier adds an IdentifierMatch for src to sorter if src has a
+// MarkedSource with an identifier.
+func addIdentifier(sorter disksort.Interface, src *ipb.Source) error {
+	code, ok := src.Facts[facts.Code]
+	if !ok {
+		return nil
+	}
+	m, err := identifierMatch(src.Ticket, string(src.Facts[facts.NodeKind]), string(src.Facts[facts.Subkind]), code)
+	if err != nil || m == nil {
+		return err
+	}
+	return sorter.Add(m)
+}
+
 func writePartialEdges(ctx context.Context, sorter disksort.Interface, src *ipb.Source) error {
 	edges := assemble.PartialReverseEdges(src)
 	for _, pe := range edges {
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"reflect"
+	"sort"
+
+	"kythe.io/kythe/go/serving/status"
+
+	"github.com/apache/beam/sdks/go/pkg/beam"
+
+	ppb "kythe.io/kythe/proto/pipeline_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func init() {
+	beam.RegisterFunction(nodeToStatus)
+
+	beam.RegisterType(reflect.TypeOf((*combineStatus)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*completeStatus)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*stpb.StatusReply)(nil)).Elem())
+}
+
+// Status returns a Kythe *stpb.StatusReply table for the StatusService.  The
+// reply reports the corpora and languages of the Kythe input graph along with
+// the origins, language support levels, and index version recorded by b.  The
+// beam.PCollection has a single element of type KV<string, *stpb.StatusReply>.
+func (k *KytheBeam) Status(b *status.Builder) beam.PCollection {
+	s := k.s.Scope("Status")
+	reply := b.Reply()
+	// Include an empty reply so that a status is emitted even for an empty graph.
+	graph := beam.Flatten(s,
+		beam.Create(s, &stpb.StatusReply{}),
+		beam.ParDo(s, nodeToStatus, k.nodes))
+	return beam.ParDo(s, &completeStatus{
+		IndexVersion: reply.IndexVersion,
+		Support:      b.Support,
+		Origins:      reply.Origins,
+	}, beam.Combine(s, &combineStatus{}, graph))
+}
+
+// nodeToStatus returns a StatusReply with the corpus and language of the given
+// node's VName.
+func nodeToStatus(n *ppb.Node) *stpb.StatusReply {
+	var reply stpb.StatusReply
+	if corpus := n.Source.GetCorpus(); corpus != "" {
+		reply.Origins = []*stpb.StatusReply_Origin{{Corpus: corpus}}
+	}
+	if lang := n.Source.GetLanguage(); lang != "" {
+		reply.Languages = []*stpb.StatusReply_Language{{Name: lang}}
+	}
+	return &reply
+}
+
+// combineStatus merges the distinct origins and languages of StatusReplys.
+type combineStatus struct{}
+
+func (combineStatus) MergeAccumulators(accum, r *stpb.StatusReply) *stpb.StatusReply {
+	accum.Origins = append(accum.Origins, r.Origins...)
+	sort.Slice(accum.Origins, func(i, j int) bool { return accum.Origins[i].Corpus < accum.Origins[j].Corpus })
+	origins := accum.Origins[:0]
+	for i, o := range accum.Origins {
+		if i == 0 || o.Corpus != accum.Origins[i-1].Corpus {
+			origins = append(origins, o)
+		}
+	}
+	accum.Origins = origins
+
+	accum.Languages = append(accum.Languages, r.Languages...)
+	sort.Slice(accum.Languages, func(i, j int) bool { return accum.Languages[i].Name < accum.Languages[j].Name })
+	langs := accum.Languages[:0]
+	for i, l := range accum.Languages {
+		if i == 0 || l.Name != accum.Languages[i-1].Name {
+			langs = append(langs, l)
+		}
+	}
+	accum.Languages = langs
+	return accum
+}
+
+// completeStatus combines the corpora and languages of the Kythe graph with the
+// table's configured status metadata and keys the resulting StatusReply by
+// status.Key.
+type completeStatus struct {
+	IndexVersion string
+	Support      map[string]stpb.StatusReply_Language_Support
+	Origins      []*stpb.StatusReply_Origin
+}
+
+func (c *completeStatus) ProcessElement(graph *stpb.StatusReply) (string, *stpb.StatusReply) {
+	b := &status.Builder{IndexVersion: c.IndexVersion, Support: c.Support}
+	for _, o := range c.Origins {
+		if o.Revision == "" {
+			b.AddVName(&spb.VName{Corpus: o.Corpus})
+		} else {
+			b.AddOrigin(o.Corpus, o.Revision)
+		}
+	}
+	for _, o := range graph.Origins {
+		b.AddVName(&spb.VName{Corpus: o.Corpus})
+	}
+	for _, l := range graph.Languages {
+		b.AddVName(&spb.VName{Language: l.Name})
+	}
+	return string(status.Key), b.Reply()
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"testing"
+
+	"kythe.io/kythe/go/serving/status"
+
+	"github.com/apache/beam/sdks/go/pkg/beam"
+	"github.com/apache/beam/sdks/go/pkg/beam/testing/passert"
+	"github.com/apache/beam/sdks/go/pkg/beam/testing/ptest"
+
+	ppb "kythe.io/kythe/proto/pipeline_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func TestStatus(t *testing.T) {
+	testNodes := []*ppb.Node{{
+		Source: &spb.VName{Corpus: "corpus", Path: "file", Language: "go"},
+	}, {
+		Source: &spb.VName{Corpus: "corpus", Path: "file2", Language: "java"},
+	}, {
+		Source: &spb.VName{Corpus: "other", Path: "file"},
+	}, {
+		Source: &spb.VName{Corpus: "revised", Language: "go"},
+	}}
+
+	b := &status.Builder{
+		IndexVersion: "v1",
+		Support:      map[string]stpb.StatusReply_Language_Support{"java": stpb.StatusReply_Language_EXPERIMENTAL},
+	}
+	b.AddOrigin("revised", "rev1")
+	b.AddOrigin("unindexed", "rev2")
+
+	expected := &stpb.StatusReply{
+		IndexVersion: "v1",
+		Origins: []*stpb.StatusReply_Origin{
+			{Corpus: "corpus"},
+			{Corpus: "other"},
+			{Corpus: "revised", Revision: "rev1"},
+			{Corpus: "unindexed", Revision: "rev2"},
+		},
+		Languages: []*stpb.StatusReply_Language{
+			{Name: "go", Support: stpb.StatusReply_Language_SUPPORTED},
+			{Name: "java", Support: stpb.StatusReply_Language_EXPERIMENTAL},
+		},
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	st := FromNodes(s, nodes).Status(b)
+	passert.Equals(s, beam.DropValue(s, st), string(status.Key))
+	passert.Equals(s, beam.DropKey(s, st), expected)
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestStatus_empty(t *testing.T) {
+	p, s, nodes := ptest.CreateList([]*ppb.Node{})
+	st := FromNodes(s, nodes).Status(&status.Builder{IndexVersion: "v2"})
+	passert.Equals(s, beam.DropKey(s, st), &stpb.StatusReply{IndexVersion: "v2"})
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
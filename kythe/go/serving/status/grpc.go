@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/util/grpcutil"
+
+	"google.golang.org/grpc"
+
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+)
+
+// ServiceName is the fully-qualified name of the gRPC service implemented by a
+// Service, as declared in kythe/proto/status_service.proto.
+const ServiceName = "kythe.proto.StatusService"
+
+var statusMethod = grpcutil.MethodName(ServiceName, "Status")
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Service)(nil),
+	Methods: []grpc.MethodDesc{
+		grpcutil.UnaryMethod(ServiceName, "Status",
+			func() interface{} { return new(stpb.StatusRequest) },
+			func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(Service).Status(ctx, req.(*stpb.StatusRequest))
+			}),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/status_service.proto",
+}
+
+// RegisterGRPC registers st with s as the kythe.proto.StatusService service.
+func RegisterGRPC(s *grpc.Server, st Service) { s.RegisterService(&serviceDesc, st) }
+
+type grpcClient struct{ cc *grpc.ClientConn }
+
+// Status implements part of the Service interface.
+func (c *grpcClient) Status(ctx context.Context, req *stpb.StatusRequest) (*stpb.StatusReply, error) {
+	reply := new(stpb.StatusReply)
+	if err := c.cc.Invoke(ctx, statusMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GRPCClient returns a status Service that sends its requests to a remote gRPC
+// server over cc.
+func GRPCClient(cc *grpc.ClientConn) Service { return &grpcClient{cc} }
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package status provides a table-based implementation of the StatusService,
+// which reports the origins, languages and version of the data in a serving
+// table.  The table holds a single StatusReply at Key.
+package status
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"kythe.io/kythe/go/services/web"
+	"kythe.io/kythe/go/storage/table"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// Service describes the interface for the status service, which reports
+// information about the data served by other Kythe services.
+type Service interface {
+	// Status returns the origins, languages and version of the served data.
+	Status(context.Context, *stpb.StatusRequest) (*stpb.StatusReply, error)
+}
+
+// Key is the serving table key of the StatusReply written by a Builder.
+var Key = []byte("meta:status")
+
+// Table wraps around a table.Proto to provide the Service interface.
+type Table struct {
+	table.Proto
+}
+
+// Status implements the Service interface for Table.  A table without status
+// metadata yields an empty reply.
+func (t *Table) Status(ctx context.Context, req *stpb.StatusRequest) (*stpb.StatusReply, error) {
+	var reply stpb.StatusReply
+	if err := t.Lookup(ctx, Key, &reply); err != nil && err != table.ErrNoSuchKey {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// A Builder accumulates the metadata reported by the status service while a
+// serving table is being written.  The zero value is ready for use.
+type Builder struct {
+	// Support maps language names to their reported support level.  Languages
+	// not in Support are reported as SUPPORTED.
+	Support map[string]stpb.StatusReply_Language_Support
+
+	// IndexVersion is reported as the reply's index_version.  If empty, the UTC
+	// time at which the reply is built is used.
+	IndexVersion string
+
+	origins   map[origin]bool
+	corpora   map[string]bool
+	languages map[string]bool
+}
+
+type origin struct{ corpus, revision string }
+
+// AddUnit records an origin for each of the given revisions at which the
+// compilation unit was indexed.
+func (b *Builder) AddUnit(cu *apb.CompilationUnit, revisions []string) {
+	corpus := cu.GetVName().GetCorpus()
+	for _, rev := range revisions {
+		b.AddOrigin(corpus, rev)
+	}
+}
+
+// AddOrigin records an origin for the given corpus at revision.
+func (b *Builder) AddOrigin(corpus, revision string) {
+	if b.origins == nil {
+		b.origins = make(map[origin]bool)
+	}
+	b.origins[origin{corpus, revision}] = true
+}
+
+// AddVName records the corpus and language of v.
+func (b *Builder) AddVName(v *spb.VName) {
+	if v.GetCorpus() != "" {
+		if b.corpora == nil {
+			b.corpora = make(map[string]bool)
+		}
+		b.corpora[v.GetCorpus()] = true
+	}
+	if v.GetLanguage() != "" {
+		if b.languages == nil {
+			b.languages = make(map[string]bool)
+		}
+		b.languages[v.GetLanguage()] = true
+	}
+}
+
+// Reply returns a StatusReply for the recorded metadata.  Each recorded unit
+// revision is an origin, as is each corpus with no known revision.  Origins
+// and languages are sorted.
+func (b *Builder) Reply() *stpb.StatusReply {
+	reply := &stpb.StatusReply{IndexVersion: b.IndexVersion}
+	if reply.IndexVersion == "" {
+		reply.IndexVersion = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	revised := make(map[string]bool)
+	for o := range b.origins {
+		reply.Origins = append(reply.Origins, &stpb.StatusReply_Origin{Corpus: o.corpus, Revision: o.revision})
+		revised[o.corpus] = true
+	}
+	for corpus := range b.corpora {
+		if !revised[corpus] {
+			reply.Origins = append(reply.Origins, &stpb.StatusReply_Origin{Corpus: corpus})
+		}
+	}
+	sort.Slice(reply.Origins, func(i, j int) bool {
+		a, b := reply.Origins[i], reply.Origins[j]
+		if a.Corpus != b.Corpus {
+			return a.Corpus < b.Corpus
+		}
+		return a.Revision < b.Revision
+	})
+
+	for lang := range b.languages {
+		support, ok := b.Support[lang]
+		if !ok {
+			support = stpb.StatusReply_Language_SUPPORTED
+		}
+		reply.Languages = append(reply.Languages, &stpb.StatusReply_Language{Name: lang, Support: support})
+	}
+	sort.Slice(reply.Languages, func(i, j int) bool { return reply.Languages[i].Name < reply.Languages[j].Name })
+	return reply
+}
+
+// Write writes the Builder's Reply to t at Key.
+func (b *Builder) Write(ctx context.Context, t table.Proto) error {
+	return t.Put(ctx, Key, b.Reply())
+}
+
+// RegisterHTTPHandlers registers a JSON HTTP handler with mux using the given
+// status Service.  The following method will be exposed:
+//
+//   GET /status
+//     Request: JSON encoded kythe.proto.StatusRequest
+//     Response: JSON encoded kythe.proto.StatusReply
+//
+// Note: /status will return its response as a serialized protobuf if the
+// "proto" query parameter is set.
+func RegisterHTTPHandlers(ctx context.Context, s Service, mux *http.ServeMux) {
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("status.Status:\t%s", time.Since(start))
+		}()
+		var req stpb.StatusRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := s.Status(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+type webClient struct{ addr string }
+
+// Status implements part of the Service interface.
+func (w *webClient) Status(ctx context.Context, q *stpb.StatusRequest) (*stpb.StatusReply, error) {
+	var reply stpb.StatusReply
+	return &reply, web.Call(w.addr, "status", q, &reply)
+}
+
+// WebClient returns a status Service based on a remote web server.
+func WebClient(addr string) Service {
+	return &webClient{addr}
+}
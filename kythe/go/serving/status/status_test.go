@@ -0,0 +1,106 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func TestBuilder(t *testing.T) {
+	b := &Builder{
+		IndexVersion: "v1",
+		Support:      map[string]stpb.StatusReply_Language_Support{"rust": stpb.StatusReply_Language_EXPERIMENTAL},
+	}
+	b.AddUnit(&apb.CompilationUnit{VName: &spb.VName{Corpus: "kythe", Language: "go"}}, []string{"r2", "r1"})
+	b.AddUnit(&apb.CompilationUnit{VName: &spb.VName{Corpus: "kythe", Language: "go"}}, []string{"r1"})
+	b.AddUnit(&apb.CompilationUnit{VName: &spb.VName{Corpus: "unseen"}}, nil)
+	b.AddVName(&spb.VName{Corpus: "kythe", Path: "a.go", Language: "go"})
+	b.AddVName(&spb.VName{Corpus: "other", Path: "b.rs", Language: "rust"})
+	b.AddVName(&spb.VName{Corpus: "other", Path: "b.rs"})
+
+	expected := &stpb.StatusReply{
+		Origins: []*stpb.StatusReply_Origin{
+			{Corpus: "kythe", Revision: "r1"},
+			{Corpus: "kythe", Revision: "r2"},
+			{Corpus: "other"},
+		},
+		Languages: []*stpb.StatusReply_Language{
+			{Name: "go", Support: stpb.StatusReply_Language_SUPPORTED},
+			{Name: "rust", Support: stpb.StatusReply_Language_EXPERIMENTAL},
+		},
+		IndexVersion: "v1",
+	}
+	if reply := b.Reply(); !proto.Equal(reply, expected) {
+		t.Errorf("Reply: {%v}; expected {%v}", reply, expected)
+	}
+
+	ctx := context.Background()
+	tbl := testProtoTable{}
+	if err := b.Write(ctx, tbl); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	reply, err := (&Table{tbl}).Status(ctx, &stpb.StatusRequest{})
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	} else if !proto.Equal(reply, expected) {
+		t.Errorf("Status: {%v}; expected {%v}", reply, expected)
+	}
+}
+
+func TestBuilderIndexVersion(t *testing.T) {
+	if v := new(Builder).Reply().IndexVersion; v == "" {
+		t.Error("Missing default IndexVersion")
+	}
+}
+
+func TestMissingStatus(t *testing.T) {
+	reply, err := (&Table{testProtoTable{}}).Status(context.Background(), &stpb.StatusRequest{})
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	} else if !proto.Equal(reply, &stpb.StatusReply{}) {
+		t.Errorf("Status: {%v}; expected empty reply", reply)
+	}
+}
+
+type testProtoTable map[string]proto.Message
+
+func (t testProtoTable) Put(_ context.Context, key []byte, val proto.Message) error {
+	t[string(key)] = val
+	return nil
+}
+
+func (t testProtoTable) Lookup(_ context.Context, key []byte, msg proto.Message) error {
+	m, ok := t[string(key)]
+	if !ok {
+		return table.ErrNoSuchKey
+	}
+	proto.Merge(msg, m)
+	return nil
+}
+
+func (t testProtoTable) Buffered() table.BufferedProto { panic("UNIMPLEMENTED") }
+
+func (t testProtoTable) Close(_ context.Context) error { return nil }
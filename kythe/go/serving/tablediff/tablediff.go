@@ -0,0 +1,459 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tablediff reports the semantic differences between two combined
+// serving tables, such as those written by write_tables.
+//
+// Each kind of difference is found by a separate ordered scan over both
+// tables so that arbitrarily large tables can be compared in constant memory
+// (excluding the size of any single table value).
+package tablediff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"github.com/golang/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A Differ compares two combined serving tables.
+type Differ struct{ Old, New keyvalue.DB }
+
+// A FileDiff describes the changes to a file's decorations.  A file found in
+// only one of the tables is reported as Added or Removed along with all of its
+// decorations.
+type FileDiff struct {
+	Ticket string `json:"ticket"`
+
+	Added       bool `json:"added,omitempty"`
+	Removed     bool `json:"removed,omitempty"`
+	TextChanged bool `json:"text_changed,omitempty"`
+
+	AddedDecorations   []*Decoration `json:"added_decorations,omitempty"`
+	RemovedDecorations []*Decoration `json:"removed_decorations,omitempty"`
+}
+
+// A Decoration is a file decoration identified by its span rather than its
+// anchor ticket, which often differs between indexer versions.
+type Decoration struct {
+	Start  int32  `json:"start"`
+	End    int32  `json:"end"`
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+}
+
+// A NodeDiff describes the changes to a node's facts.  A node found in only
+// one of the tables is reported as Added or Removed along with all of its
+// facts.
+type NodeDiff struct {
+	Ticket string `json:"ticket"`
+
+	Added   bool `json:"added,omitempty"`
+	Removed bool `json:"removed,omitempty"`
+
+	AddedFacts   []string `json:"added_facts,omitempty"`
+	RemovedFacts []string `json:"removed_facts,omitempty"`
+	ChangedFacts []string `json:"changed_facts,omitempty"`
+}
+
+// A CrossReferencesDiff describes the changes in the number of a node's
+// cross-references for each kind whose count differs.
+type CrossReferencesDiff struct {
+	Ticket string           `json:"ticket"`
+	Counts map[string]Count `json:"counts"`
+}
+
+// A Count is a pair of old and new counts.
+type Count struct {
+	Old int `json:"old"`
+	New int `json:"new"`
+}
+
+// A DocumentationDiff describes a change to a node's documentation.
+type DocumentationDiff struct {
+	Ticket string `json:"ticket"`
+
+	Added   bool `json:"added,omitempty"`
+	Removed bool `json:"removed,omitempty"`
+
+	// The raw text of the documentation in each table.
+	OldText string `json:"old_text,omitempty"`
+	NewText string `json:"new_text,omitempty"`
+}
+
+// Files calls f with the decoration changes for each file, in ticket order.
+func (d *Differ) Files(ctx context.Context, f func(*FileDiff) error) error {
+	return d.join(xsrv.DecorationsKey(""), func(key, oldVal, newVal []byte) error {
+		var oldDecor, newDecor srvpb.FileDecorations
+		if err := unmarshal(oldVal, &oldDecor); err != nil {
+			return err
+		} else if err := unmarshal(newVal, &newDecor); err != nil {
+			return err
+		}
+
+		fd := &FileDiff{
+			Ticket:  string(key),
+			Added:   oldVal == nil,
+			Removed: newVal == nil,
+		}
+		if oldVal != nil && newVal != nil {
+			fd.TextChanged = !bytes.Equal(oldDecor.File.GetText(), newDecor.File.GetText())
+		}
+		fd.RemovedDecorations, fd.AddedDecorations = diffDecorations(decorations(&oldDecor), decorations(&newDecor))
+		if !fd.Added && !fd.Removed && !fd.TextChanged && len(fd.AddedDecorations) == 0 && len(fd.RemovedDecorations) == 0 {
+			return nil
+		}
+		return f(fd)
+	})
+}
+
+// Nodes calls f with the fact changes for each node, in ticket order.  A
+// node's facts are read from its edge set or, if it has none, from the source
+// node of its cross-references; nodes with neither are not reported.
+func (d *Differ) Nodes(ctx context.Context, f func(*NodeDiff) error) error {
+	edgeSets, err := d.newJoiner(gsrv.EdgeSetKey(""))
+	if err != nil {
+		return err
+	}
+	defer edgeSets.close()
+	crossRefs, err := d.newJoiner(xsrv.CrossReferencesKey(""))
+	if err != nil {
+		return err
+	}
+	defer crossRefs.close()
+
+	esTicket, esOld, esNew, err := edgeSets.next()
+	if err != nil {
+		return err
+	}
+	crTicket, crOld, crNew, err := crossRefs.next()
+	if err != nil {
+		return err
+	}
+	for esTicket != nil || crTicket != nil {
+		var oldNode, newNode *srvpb.Node
+		var hasOld, hasNew bool
+		ticket := esTicket
+		if esTicket == nil || (crTicket != nil && bytes.Compare(crTicket, esTicket) < 0) {
+			ticket = crTicket
+		}
+		if bytes.Equal(crTicket, ticket) {
+			var oldSet, newSet srvpb.PagedCrossReferences
+			if err := unmarshal(crOld, &oldSet); err != nil {
+				return err
+			} else if err := unmarshal(crNew, &newSet); err != nil {
+				return err
+			}
+			oldNode, newNode = oldSet.SourceNode, newSet.SourceNode
+			hasOld, hasNew = crOld != nil, crNew != nil
+			if crTicket, crOld, crNew, err = crossRefs.next(); err != nil {
+				return err
+			}
+		}
+		if bytes.Equal(esTicket, ticket) {
+			var oldSet, newSet srvpb.PagedEdgeSet
+			if err := unmarshal(esOld, &oldSet); err != nil {
+				return err
+			} else if err := unmarshal(esNew, &newSet); err != nil {
+				return err
+			}
+			if esOld != nil {
+				oldNode, hasOld = oldSet.Source, true
+			}
+			if esNew != nil {
+				newNode, hasNew = newSet.Source, true
+			}
+			if esTicket, esOld, esNew, err = edgeSets.next(); err != nil {
+				return err
+			}
+		}
+
+		if nd := diffNode(string(ticket), oldNode, newNode, hasOld, hasNew); nd != nil {
+			if err := f(nd); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// diffNode returns the changes between the facts of the old and new nodes for
+// ticket, or nil if there are none.  hasOld and hasNew report whether the node
+// was found in each table.
+func diffNode(ticket string, oldNode, newNode *srvpb.Node, hasOld, hasNew bool) *NodeDiff {
+	nd := &NodeDiff{
+		Ticket:  ticket,
+		Added:   !hasOld,
+		Removed: !hasNew,
+	}
+	oldFacts, newFacts := make(map[string][]byte), make(map[string][]byte)
+	for _, f := range oldNode.GetFact() {
+		oldFacts[f.Name] = f.Value
+	}
+	for _, f := range newNode.GetFact() {
+		newFacts[f.Name] = f.Value
+	}
+	for name, val := range newFacts {
+		if old, ok := oldFacts[name]; !ok {
+			nd.AddedFacts = append(nd.AddedFacts, name)
+		} else if !bytes.Equal(old, val) {
+			nd.ChangedFacts = append(nd.ChangedFacts, name)
+		}
+	}
+	for name := range oldFacts {
+		if _, ok := newFacts[name]; !ok {
+			nd.RemovedFacts = append(nd.RemovedFacts, name)
+		}
+	}
+	if !nd.Added && !nd.Removed && len(nd.AddedFacts)+len(nd.RemovedFacts)+len(nd.ChangedFacts) == 0 {
+		return nil
+	}
+	sort.Strings(nd.AddedFacts)
+	sort.Strings(nd.RemovedFacts)
+	sort.Strings(nd.ChangedFacts)
+	return nd
+}
+
+// CrossReferences calls f with the changes in cross-reference counts for each
+// node, in ticket order.
+func (d *Differ) CrossReferences(ctx context.Context, f func(*CrossReferencesDiff) error) error {
+	return d.join(xsrv.CrossReferencesKey(""), func(key, oldVal, newVal []byte) error {
+		var oldSet, newSet srvpb.PagedCrossReferences
+		if err := unmarshal(oldVal, &oldSet); err != nil {
+			return err
+		} else if err := unmarshal(newVal, &newSet); err != nil {
+			return err
+		}
+
+		counts := make(map[string]Count)
+		for kind, n := range crossReferenceCounts(&oldSet) {
+			c := counts[kind]
+			c.Old = n
+			counts[kind] = c
+		}
+		for kind, n := range crossReferenceCounts(&newSet) {
+			c := counts[kind]
+			c.New = n
+			counts[kind] = c
+		}
+		for kind, c := range counts {
+			if c.Old == c.New {
+				delete(counts, kind)
+			}
+		}
+		if len(counts) == 0 {
+			return nil
+		}
+		return f(&CrossReferencesDiff{
+			Ticket: string(key),
+			Counts: counts,
+		})
+	})
+}
+
+// Documentation calls f with the documentation changes for each node, in
+// ticket order.
+func (d *Differ) Documentation(ctx context.Context, f func(*DocumentationDiff) error) error {
+	return d.join(xsrv.DocumentationKey(""), func(key, oldVal, newVal []byte) error {
+		var oldDoc, newDoc srvpb.Document
+		if err := unmarshal(oldVal, &oldDoc); err != nil {
+			return err
+		} else if err := unmarshal(newVal, &newDoc); err != nil {
+			return err
+		} else if proto.Equal(&oldDoc, &newDoc) {
+			return nil
+		}
+		return f(&DocumentationDiff{
+			Ticket:  string(key),
+			Added:   oldVal == nil,
+			Removed: newVal == nil,
+			OldText: oldDoc.RawText,
+			NewText: newDoc.RawText,
+		})
+	})
+}
+
+// crossReferenceCounts returns the number of cross-references in s for each
+// kind, including those stored in separate pages.
+func crossReferenceCounts(s *srvpb.PagedCrossReferences) map[string]int {
+	counts := make(map[string]int)
+	for _, g := range s.Group {
+		counts[g.Kind] += len(g.Anchor) + len(g.RelatedNode) + len(g.Caller)
+	}
+	for _, idx := range s.PageIndex {
+		counts[idx.Kind] += int(idx.Count)
+	}
+	return counts
+}
+
+func decorations(fd *srvpb.FileDecorations) []*Decoration {
+	ds := make([]*Decoration, len(fd.Decoration))
+	for i, d := range fd.Decoration {
+		ds[i] = &Decoration{
+			Start:  d.Anchor.GetStartOffset(),
+			End:    d.Anchor.GetEndOffset(),
+			Kind:   d.Kind,
+			Target: d.Target,
+		}
+	}
+	sort.Slice(ds, func(i, j int) bool { return decorationLess(ds[i], ds[j]) })
+	return ds
+}
+
+func decorationLess(a, b *Decoration) bool {
+	switch {
+	case a.Start != b.Start:
+		return a.Start < b.Start
+	case a.End != b.End:
+		return a.End < b.End
+	case a.Kind != b.Kind:
+		return a.Kind < b.Kind
+	default:
+		return a.Target < b.Target
+	}
+}
+
+// diffDecorations returns the decorations found only in the sorted old or new
+// slices, respectively.
+func diffDecorations(old, new []*Decoration) (removed, added []*Decoration) {
+	for len(old) > 0 || len(new) > 0 {
+		switch {
+		case len(new) == 0 || (len(old) > 0 && decorationLess(old[0], new[0])):
+			removed, old = append(removed, old[0]), old[1:]
+		case len(old) == 0 || decorationLess(new[0], old[0]):
+			added, new = append(added, new[0]), new[1:]
+		default:
+			old, new = old[1:], new[1:]
+		}
+	}
+	return
+}
+
+// unmarshal unmarshals rec into msg, leaving msg empty if rec is nil.
+func unmarshal(rec []byte, msg proto.Message) error {
+	if rec == nil {
+		return nil
+	} else if err := proto.Unmarshal(rec, msg); err != nil {
+		return fmt.Errorf("error unmarshaling %T: %v", msg, err)
+	}
+	return nil
+}
+
+// join scans the keys with the given prefix in both tables, calling f with each
+// key, without the prefix, and its old and new values in order.  The value of
+// a key missing from a table is nil.
+func (d *Differ) join(prefix []byte, f func(key, oldVal, newVal []byte) error) error {
+	j, err := d.newJoiner(prefix)
+	if err != nil {
+		return err
+	}
+	defer j.close()
+	for {
+		key, oldVal, newVal, err := j.next()
+		if err != nil {
+			return err
+		} else if key == nil {
+			return nil
+		} else if err := f(key, oldVal, newVal); err != nil {
+			return err
+		}
+	}
+}
+
+// A joiner scans the keys with a common prefix in both tables in order.
+type joiner struct {
+	prefix []byte
+
+	oldIt, newIt                   keyvalue.Iterator
+	oldKey, oldVal, newKey, newVal []byte
+}
+
+func (d *Differ) newJoiner(prefix []byte) (*joiner, error) {
+	j := &joiner{prefix: prefix}
+	var err error
+	if j.oldIt, err = d.Old.ScanPrefix(prefix, &keyvalue.Options{LargeRead: true}); err != nil {
+		return nil, fmt.Errorf("error scanning old table: %v", err)
+	} else if j.newIt, err = d.New.ScanPrefix(prefix, &keyvalue.Options{LargeRead: true}); err != nil {
+		j.oldIt.Close()
+		return nil, fmt.Errorf("error scanning new table: %v", err)
+	}
+	if j.oldKey, j.oldVal, err = next(j.oldIt); err != nil {
+		j.close()
+		return nil, fmt.Errorf("error reading old table: %v", err)
+	} else if j.newKey, j.newVal, err = next(j.newIt); err != nil {
+		j.close()
+		return nil, fmt.Errorf("error reading new table: %v", err)
+	}
+	return j, nil
+}
+
+func (j *joiner) close() {
+	j.oldIt.Close()
+	j.newIt.Close()
+}
+
+// next returns the next key, without its prefix, along with its old and new
+// values.  The value of a key missing from a table is nil.  A nil key is
+// returned at the end of the scan.
+func (j *joiner) next() (key, oldVal, newVal []byte, err error) {
+	var advanceOld, advanceNew bool
+	switch c := bytes.Compare(j.oldKey, j.newKey); {
+	case j.oldKey == nil && j.newKey == nil:
+		return nil, nil, nil, nil
+	case j.newKey == nil || (j.oldKey != nil && c < 0):
+		advanceOld = true
+		key, oldVal = j.oldKey, j.oldVal
+	case j.oldKey == nil || c > 0:
+		advanceNew = true
+		key, newVal = j.newKey, j.newVal
+	default:
+		advanceOld, advanceNew = true, true
+		key, oldVal, newVal = j.oldKey, j.oldVal, j.newVal
+	}
+
+	if advanceOld {
+		if j.oldKey, j.oldVal, err = next(j.oldIt); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading old table: %v", err)
+		}
+	}
+	if advanceNew {
+		if j.newKey, j.newVal, err = next(j.newIt); err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading new table: %v", err)
+		}
+	}
+	return key[len(j.prefix):], oldVal, newVal, nil
+}
+
+// next returns a copy of the next key-value in it or a nil key at the end of
+// the iteration.  The returned value is never nil otherwise.
+func next(it keyvalue.Iterator) (key, val []byte, err error) {
+	key, val, err = it.Next()
+	if err == io.EOF {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return append([]byte{}, key...), append([]byte{}, val...), nil
+}
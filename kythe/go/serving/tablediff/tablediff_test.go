@@ -0,0 +1,294 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tablediff
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/goleveldb"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/table"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+type kv struct {
+	key []byte
+	val proto.Message
+}
+
+func decor(file, text string, ds ...*srvpb.FileDecorations_Decoration) kv {
+	return kv{xsrv.DecorationsKey(file), &srvpb.FileDecorations{
+		File:       &srvpb.File{Ticket: file, Text: []byte(text)},
+		Decoration: ds,
+	}}
+}
+
+func ref(anchor string, start, end int32, target string) *srvpb.FileDecorations_Decoration {
+	return &srvpb.FileDecorations_Decoration{
+		Anchor: &srvpb.RawAnchor{Ticket: anchor, StartOffset: start, EndOffset: end},
+		Kind:   "/kythe/edge/ref",
+		Target: target,
+	}
+}
+
+func srvNode(ticket string, facts ...string) *srvpb.Node {
+	n := &srvpb.Node{Ticket: ticket}
+	for i := 0; i < len(facts); i += 2 {
+		n.Fact = append(n.Fact, &cpb.Fact{Name: facts[i], Value: []byte(facts[i+1])})
+	}
+	return n
+}
+
+func node(ticket string, facts ...string) kv {
+	return kv{gsrv.EdgeSetKey(ticket), &srvpb.PagedEdgeSet{Source: srvNode(ticket, facts...)}}
+}
+
+// xrefsNode returns empty cross-references for a node without an edge set.
+func xrefsNode(ticket string, facts ...string) kv {
+	return kv{xsrv.CrossReferencesKey(ticket), &srvpb.PagedCrossReferences{
+		SourceTicket: ticket,
+		SourceNode:   srvNode(ticket, facts...),
+	}}
+}
+
+func xrefs(ticket string, groups []*srvpb.PagedCrossReferences_Group, pages ...*srvpb.PagedCrossReferences_PageIndex) kv {
+	return kv{xsrv.CrossReferencesKey(ticket), &srvpb.PagedCrossReferences{
+		SourceTicket: ticket,
+		Group:        groups,
+		PageIndex:    pages,
+	}}
+}
+
+func anchors(kind string, n int) *srvpb.PagedCrossReferences_Group {
+	g := &srvpb.PagedCrossReferences_Group{Kind: kind}
+	for i := 0; i < n; i++ {
+		g.Anchor = append(g.Anchor, &srvpb.ExpandedAnchor{})
+	}
+	return g
+}
+
+func doc(ticket, text string) kv {
+	return kv{xsrv.DocumentationKey(ticket), &srvpb.Document{Ticket: ticket, RawText: text}}
+}
+
+var (
+	oldTable = []kv{
+		decor("kythe:#a", "text", ref("kythe:#a0", 0, 1, "kythe:#x"), ref("kythe:#a1", 2, 3, "kythe:#y")),
+		decor("kythe:#b", "text", ref("kythe:#b0", 0, 1, "kythe:#x")),
+		decor("kythe:#d", "old"),
+		xrefsNode("kythe:#u", "/kythe/node/kind", "variable"),
+		node("kythe:#w", "/kythe/node/kind", "function"),
+		node("kythe:#x", "/kythe/node/kind", "function", "/kythe/complete", "definition"),
+		node("kythe:#y", "/kythe/node/kind", "function"),
+		xrefs("kythe:#w", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 1)}),
+		xrefs("kythe:#x", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 2)},
+			&srvpb.PagedCrossReferences_PageIndex{Kind: "%/kythe/edge/ref", Count: 3}),
+		xrefs("kythe:#y", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 1)}),
+		doc("kythe:#w", "same"),
+		doc("kythe:#x", "old"),
+		doc("kythe:#y", "removed"),
+	}
+	newTable = []kv{
+		decor("kythe:#a", "text", ref("kythe:#new_a0", 0, 1, "kythe:#x"), ref("kythe:#a2", 4, 5, "kythe:#z")),
+		decor("kythe:#c", "text"),
+		decor("kythe:#d", "new"),
+		xrefsNode("kythe:#u", "/kythe/node/kind", "constant"),
+		xrefsNode("kythe:#v", "/kythe/node/kind", "variable"),
+		node("kythe:#w", "/kythe/node/kind", "function"),
+		node("kythe:#x", "/kythe/node/kind", "function", "/kythe/complete", "incomplete", "/kythe/subkind", "method"),
+		node("kythe:#z", "/kythe/node/kind", "function"),
+		xrefs("kythe:#w", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 1)}),
+		xrefs("kythe:#x", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 4), anchors("%/kythe/edge/defines", 1)}),
+		xrefs("kythe:#z", []*srvpb.PagedCrossReferences_Group{anchors("%/kythe/edge/ref", 1)}),
+		doc("kythe:#w", "same"),
+		doc("kythe:#x", "new"),
+		doc("kythe:#z", "added"),
+	}
+)
+
+func TestFiles(t *testing.T) {
+	d, cleanup := differ(t)
+	defer cleanup()
+
+	var found []*FileDiff
+	if err := d.Files(context.Background(), func(fd *FileDiff) error {
+		found = append(found, fd)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*FileDiff{{
+		Ticket:             "kythe:#a",
+		AddedDecorations:   []*Decoration{{4, 5, "/kythe/edge/ref", "kythe:#z"}},
+		RemovedDecorations: []*Decoration{{2, 3, "/kythe/edge/ref", "kythe:#y"}},
+	}, {
+		Ticket:             "kythe:#b",
+		Removed:            true,
+		RemovedDecorations: []*Decoration{{0, 1, "/kythe/edge/ref", "kythe:#x"}},
+	}, {
+		Ticket: "kythe:#c",
+		Added:  true,
+	}, {
+		Ticket:      "kythe:#d",
+		TextChanged: true,
+	}}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("Unexpected diff (- expected; + found):\n%s", diff)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	d, cleanup := differ(t)
+	defer cleanup()
+
+	var found []*NodeDiff
+	if err := d.Nodes(context.Background(), func(nd *NodeDiff) error {
+		found = append(found, nd)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*NodeDiff{{
+		Ticket:       "kythe:#u",
+		ChangedFacts: []string{"/kythe/node/kind"},
+	}, {
+		Ticket:     "kythe:#v",
+		Added:      true,
+		AddedFacts: []string{"/kythe/node/kind"},
+	}, {
+		Ticket:       "kythe:#x",
+		AddedFacts:   []string{"/kythe/subkind"},
+		ChangedFacts: []string{"/kythe/complete"},
+	}, {
+		Ticket:       "kythe:#y",
+		Removed:      true,
+		RemovedFacts: []string{"/kythe/node/kind"},
+	}, {
+		Ticket:     "kythe:#z",
+		Added:      true,
+		AddedFacts: []string{"/kythe/node/kind"},
+	}}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("Unexpected diff (- expected; + found):\n%s", diff)
+	}
+}
+
+func TestCrossReferences(t *testing.T) {
+	d, cleanup := differ(t)
+	defer cleanup()
+
+	var found []*CrossReferencesDiff
+	if err := d.CrossReferences(context.Background(), func(xd *CrossReferencesDiff) error {
+		found = append(found, xd)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*CrossReferencesDiff{{
+		Ticket: "kythe:#x",
+		Counts: map[string]Count{
+			"%/kythe/edge/ref":     {5, 4},
+			"%/kythe/edge/defines": {0, 1},
+		},
+	}, {
+		Ticket: "kythe:#y",
+		Counts: map[string]Count{"%/kythe/edge/ref": {1, 0}},
+	}, {
+		Ticket: "kythe:#z",
+		Counts: map[string]Count{"%/kythe/edge/ref": {0, 1}},
+	}}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("Unexpected diff (- expected; + found):\n%s", diff)
+	}
+}
+
+func TestDocumentation(t *testing.T) {
+	d, cleanup := differ(t)
+	defer cleanup()
+
+	var found []*DocumentationDiff
+	if err := d.Documentation(context.Background(), func(dd *DocumentationDiff) error {
+		found = append(found, dd)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*DocumentationDiff{{
+		Ticket:  "kythe:#x",
+		OldText: "old",
+		NewText: "new",
+	}, {
+		Ticket:  "kythe:#y",
+		Removed: true,
+		OldText: "removed",
+	}, {
+		Ticket:  "kythe:#z",
+		Added:   true,
+		NewText: "added",
+	}}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("Unexpected diff (- expected; + found):\n%s", diff)
+	}
+}
+
+// differ returns a Differ between oldTable and newTable.  The returned function
+// closes and removes both tables.
+func differ(t *testing.T) (*Differ, func()) {
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+	open := func(kvs []kv) keyvalue.DB {
+		dir, err := ioutil.TempDir("", "tablediff")
+		if err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+		cleanups = append(cleanups, func() { os.RemoveAll(dir) })
+		db, err := goleveldb.Open(dir, nil)
+		if err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+		cleanups = append(cleanups, func() { db.Close() })
+		tbl := &table.KVProto{DB: db}
+		for _, kv := range kvs {
+			if err := tbl.Put(context.Background(), kv.key, kv.val); err != nil {
+				cleanup()
+				t.Fatal(err)
+			}
+		}
+		return db
+	}
+	return &Differ{Old: open(oldTable), New: open(newTable)}, cleanup
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Binary diff_tables reports the semantic differences between two combined
+// serving tables, such as those written by write_tables before and after an
+// indexer upgrade.
+//
+// For each file, the decorations added or removed are reported.  For each
+// node, the facts that appeared or disappeared, the changes to its number of
+// cross-references of each kind, and any documentation changes are reported.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"kythe.io/kythe/go/serving/tablediff"
+	"kythe.io/kythe/go/storage/leveldb"
+	"kythe.io/kythe/go/util/flagutil"
+)
+
+var (
+	emitJSON = flag.Bool("json", false, "Emit each difference as a JSON object on its own line")
+	sections = flag.String("sections", "files,nodes,xrefs,docs", "Comma-separated list of differences to report (files, nodes, xrefs, docs)")
+)
+
+func init() {
+	flag.Usage = flagutil.SimpleUsage("Reports the semantic differences between two serving tables",
+		"[--json] [--sections list] old_table new_table")
+}
+
+func main() {
+	flag.Parse()
+	if len(flag.Args()) != 2 {
+		flagutil.UsageError("expected exactly two serving table paths")
+	}
+
+	enabled := make(map[string]bool)
+	for _, s := range strings.Split(*sections, ",") {
+		switch s {
+		case "files", "nodes", "xrefs", "docs":
+			enabled[s] = true
+		default:
+			flagutil.UsageErrorf("unknown section: %q", s)
+		}
+	}
+
+	oldDB, err := leveldb.Open(flag.Arg(0), &leveldb.Options{MustExist: true})
+	if err != nil {
+		log.Fatalf("Error opening table at %q: %v", flag.Arg(0), err)
+	}
+	defer oldDB.Close()
+	newDB, err := leveldb.Open(flag.Arg(1), &leveldb.Options{MustExist: true})
+	if err != nil {
+		log.Fatalf("Error opening table at %q: %v", flag.Arg(1), err)
+	}
+	defer newDB.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer func() {
+		if err := out.Flush(); err != nil {
+			log.Fatalf("Error writing output: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	d := &tablediff.Differ{Old: oldDB, New: newDB}
+	p := &printer{w: out, json: *emitJSON}
+	if enabled["files"] {
+		if err := d.Files(ctx, p.file); err != nil {
+			log.Fatalf("Error comparing files: %v", err)
+		}
+	}
+	if enabled["nodes"] {
+		if err := d.Nodes(ctx, p.node); err != nil {
+			log.Fatalf("Error comparing nodes: %v", err)
+		}
+	}
+	if enabled["xrefs"] {
+		if err := d.CrossReferences(ctx, p.crossReferences); err != nil {
+			log.Fatalf("Error comparing cross-references: %v", err)
+		}
+	}
+	if enabled["docs"] {
+		if err := d.Documentation(ctx, p.documentation); err != nil {
+			log.Fatalf("Error comparing documentation: %v", err)
+		}
+	}
+}
+
+// printer writes differences either as JSON lines or as a human-readable
+// report.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func (p *printer) emitJSON(key string, val interface{}) error {
+	return json.NewEncoder(p.w).Encode(map[string]interface{}{key: val})
+}
+
+func (p *printer) file(fd *tablediff.FileDiff) error {
+	if p.json {
+		return p.emitJSON("file", fd)
+	}
+	fmt.Fprintf(p.w, "file %s:%s +%d -%d decorations\n", fd.Ticket, status(fd.Added, fd.Removed), len(fd.AddedDecorations), len(fd.RemovedDecorations))
+	if fd.TextChanged {
+		fmt.Fprintln(p.w, "  text changed")
+	}
+	for _, d := range fd.AddedDecorations {
+		fmt.Fprintf(p.w, "  + [%d, %d) %s %s\n", d.Start, d.End, d.Kind, d.Target)
+	}
+	for _, d := range fd.RemovedDecorations {
+		fmt.Fprintf(p.w, "  - [%d, %d) %s %s\n", d.Start, d.End, d.Kind, d.Target)
+	}
+	return nil
+}
+
+func (p *printer) node(nd *tablediff.NodeDiff) error {
+	if p.json {
+		return p.emitJSON("node", nd)
+	}
+	fmt.Fprintf(p.w, "node %s:%s\n", nd.Ticket, status(nd.Added, nd.Removed))
+	for _, f := range nd.AddedFacts {
+		fmt.Fprintf(p.w, "  + %s\n", f)
+	}
+	for _, f := range nd.RemovedFacts {
+		fmt.Fprintf(p.w, "  - %s\n", f)
+	}
+	for _, f := range nd.ChangedFacts {
+		fmt.Fprintf(p.w, "  ~ %s\n", f)
+	}
+	return nil
+}
+
+func (p *printer) crossReferences(xd *tablediff.CrossReferencesDiff) error {
+	if p.json {
+		return p.emitJSON("xrefs", xd)
+	}
+	fmt.Fprintf(p.w, "xrefs %s:\n", xd.Ticket)
+	kinds := make([]string, 0, len(xd.Counts))
+	for kind := range xd.Counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		c := xd.Counts[kind]
+		fmt.Fprintf(p.w, "  %s %d -> %d (%+d)\n", kind, c.Old, c.New, c.New-c.Old)
+	}
+	return nil
+}
+
+func (p *printer) documentation(dd *tablediff.DocumentationDiff) error {
+	if p.json {
+		return p.emitJSON("docs", dd)
+	}
+	fmt.Fprintf(p.w, "docs %s:%s\n", dd.Ticket, status(dd.Added, dd.Removed))
+	if dd.OldText != "" {
+		fmt.Fprintf(p.w, "  - %q\n", dd.OldText)
+	}
+	if dd.NewText != "" {
+		fmt.Fprintf(p.w, "  + %q\n", dd.NewText)
+	}
+	return nil
+}
+
+func status(added, removed bool) string {
+	switch {
+	case added:
+		return " added"
+	case removed:
+		return " removed"
+	default:
+		return ""
+	}
+}
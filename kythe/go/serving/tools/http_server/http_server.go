@@ -15,30 +15,41 @@
  */
 
 // Binary http_server exposes HTTP interfaces for the xrefs and filetree
-// services backed by a combined serving table.
+// services backed by a combined serving table.  With --grpc_listen, the xrefs,
+// graph, filetree, identifiers, status and link services are also served over
+// gRPC.
 package main
 
 import (
 	"context"
 	"flag"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/link"
 	"kythe.io/kythe/go/services/xrefs"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/status"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/flagutil"
 
 	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/services/graphstore/remote"
+	_ "kythe.io/kythe/go/storage/goleveldb"
 	_ "kythe.io/kythe/go/storage/leveldb"
 )
 
@@ -53,20 +64,22 @@ var (
 	tlsCertFile      = flag.String("tls_cert_file", "", "Path to file with concatenation of TLS certificates")
 	tlsKeyFile       = flag.String("tls_key_file", "", "Path to file with TLS private key")
 
+	grpcListeningAddr = flag.String("grpc_listen", "", "Listening address for gRPC server")
+
 	maxTicketsPerRequest = flag.Int("max_tickets_per_request", 20, "Maximum number of tickets allowed per request")
 )
 
 func init() {
 	flag.Usage = flagutil.SimpleUsage("Exposes HTTP interfaces for the xrefs and filetree services",
-		"(--graphstore spec | --serving_table path) [--listen addr] [--public_resources dir]")
+		"(--graphstore spec | --serving_table path) [--listen addr] [--grpc_listen addr] [--public_resources dir]")
 }
 
 func main() {
 	flag.Parse()
 	if *servingTable == "" {
 		flagutil.UsageError("missing --serving_table")
-	} else if *httpListeningAddr == "" && *tlsListeningAddr == "" {
-		flagutil.UsageError("missing either --listen or --tls_listen argument")
+	} else if *httpListeningAddr == "" && *tlsListeningAddr == "" && *grpcListeningAddr == "" {
+		flagutil.UsageError("missing either --listen, --tls_listen, or --grpc_listen argument")
 	} else if *tlsListeningAddr != "" && (*tlsCertFile == "" || *tlsKeyFile == "") {
 		flagutil.UsageError("--tls_cert_file and --tls_key_file are required if given --tls_listen")
 	} else if flag.NArg() > 0 {
@@ -77,6 +90,9 @@ func main() {
 		xs xrefs.Service
 		gs graph.Service
 		ft filetree.Service
+		id identifiers.Service
+		st status.Service
+		ln link.Service
 	)
 
 	ctx := context.Background()
@@ -99,6 +115,9 @@ func main() {
 		}
 	}
 	ft = &ftsrv.Table{Proto: tbl, PrefixedKeys: true}
+	id = &identifiers.Table{Proto: tbl}
+	st = &status.Table{Proto: tbl}
+	ln = &link.Resolver{Client: linkClient{xs, id}}
 
 	if *httpListeningAddr != "" || *tlsListeningAddr != "" {
 		apiMux := http.NewServeMux()
@@ -112,6 +131,9 @@ func main() {
 		xrefs.RegisterHTTPHandlers(ctx, xs, apiMux)
 		graph.RegisterHTTPHandlers(ctx, gs, apiMux)
 		filetree.RegisterHTTPHandlers(ctx, ft, apiMux)
+		identifiers.RegisterHTTPHandlers(ctx, id, apiMux)
+		status.RegisterHTTPHandlers(ctx, st, apiMux)
+		link.RegisterHTTPHandlers(ctx, ln, apiMux)
 		if *publicResources != "" {
 			log.Println("Serving public resources at", *publicResources)
 			if s, err := os.Stat(*publicResources); err != nil {
@@ -130,15 +152,46 @@ func main() {
 	if *tlsListeningAddr != "" {
 		go startTLS()
 	}
+	if *grpcListeningAddr != "" {
+		srv := grpc.NewServer()
+		xrefs.RegisterGRPC(srv, xs)
+		graph.RegisterGRPC(srv, gs)
+		filetree.RegisterGRPC(srv, ft)
+		identifiers.RegisterGRPC(srv, id)
+		status.RegisterGRPC(srv, st)
+		link.RegisterGRPC(srv, ln)
+		go startGRPC(srv)
+	}
 
 	select {} // block forever
 }
 
+// linkClient provides the xrefs and identifiers services needed by a
+// link.Resolver.
+type linkClient struct {
+	xrefs.Service
+	id identifiers.Service
+}
+
+// Find implements part of the identifiers Service interface.
+func (c linkClient) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	return c.id.Find(ctx, req)
+}
+
 func startHTTP() {
 	log.Printf("HTTP server listening on %q", *httpListeningAddr)
 	log.Fatal(http.ListenAndServe(*httpListeningAddr, nil))
 }
 
+func startGRPC(srv *grpc.Server) {
+	lis, err := net.Listen("tcp", *grpcListeningAddr)
+	if err != nil {
+		log.Fatalf("Error listening on %q: %v", *grpcListeningAddr, err)
+	}
+	log.Printf("gRPC server listening on %s", lis.Addr())
+	log.Fatal(srv.Serve(lis))
+}
+
 func startTLS() {
 	srv := &http.Server{Addr: *tlsListeningAddr}
 	http2.ConfigureServer(srv, nil)
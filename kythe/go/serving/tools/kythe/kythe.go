@@ -21,6 +21,12 @@
 //   # Show complete command listing
 //   kythe
 //
+//   # Show the origins, languages and version of the served index
+//   kythe --api /path/to/table status
+//
+//   # Show the definition locations of the identifier foo.Bar
+//   kythe --api /path/to/table link foo.Bar
+//
 //   # List all corpus root uris
 //   kythe --api /path/to/table ls --uris
 //
@@ -61,6 +67,8 @@ func main() {
 		GraphService:      *apiFlag,
 		FileTreeService:   *apiFlag,
 		IdentifierService: *apiFlag,
+		StatusService:     *apiFlag,
+		LinkService:       *apiFlag,
 	})
 	(*apiFlag).Close()
 	os.Exit(int(status))
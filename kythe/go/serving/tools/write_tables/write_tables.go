@@ -16,36 +16,51 @@
 
 // Binary write_tables creates a combined xrefs/filetree/search serving table
 // based on a given GraphStore.
+//
+// The table also records the metadata reported by the StatusService: the
+// corpora and languages of the input entries, the revisions of the
+// compilation units in any --compilations kzip files, and an index version.
+//
+// With --update, an existing table is instead incrementally updated from a
+// delta of entries covering each changed file (see pipeline.Update).
 package main
 
 import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
+	"kythe.io/kythe/go/platform/kzip"
 	"kythe.io/kythe/go/platform/vfs"
-	"kythe.io/kythe/go/services/graphstore"
 	"kythe.io/kythe/go/serving/pipeline"
 	"kythe.io/kythe/go/serving/pipeline/beamio"
+	"kythe.io/kythe/go/serving/status"
 	"kythe.io/kythe/go/storage/gsutil"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/stream"
 	"kythe.io/kythe/go/util/flagutil"
+	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/profile"
 
+	stpb "kythe.io/kythe/proto/status_service_go_proto"
 	spb "kythe.io/kythe/proto/storage_go_proto"
 
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/x/beamx"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/services/graphstore/remote"
+	_ "kythe.io/kythe/go/storage/goleveldb"
 	_ "kythe.io/third_party/beam/sdks/go/pkg/beam/runners/disksort"
 )
 
 var (
-	gs          graphstore.Service
-	entriesFile = flag.String("entries", "", "Path to GraphStore-ordered entries file (mutually exclusive with --graphstore)")
+	gsSpec      = flag.String("graphstore", "", "GraphStore to read (mutually exclusive with --entries); the Beam pipeline requires a sharded GraphStore (e.g. leveldb:path)")
+	entriesFile = flag.String("entries", "", "Path to GraphStore-ordered entries file in any format accepted by stream.NewReader (mutually exclusive with --graphstore); the Beam pipeline also accepts a glob of sharded entries files")
 
 	tablePath = flag.String("out", "", "Directory path to output serving table")
 
@@ -56,16 +71,22 @@ var (
 	maxShardSize = flag.Int("max_shard_size", 32000,
 		"Maximum number of elements (edges, decoration fragments, etc.) to keep in-memory before flushing an intermediary data shard to disk.")
 
+	compilations          = flag.String("compilations", "", "Comma-separated list of kzip files whose compilation unit revisions are reported as the table's origins")
+	indexVersion          = flag.String("index_version", "", "Index version reported by the table's status (default: the current UTC time)")
+	experimentalLanguages = flag.String("experimental_languages", "", "Comma-separated list of languages whose support is reported as experimental")
+
+	update       = flag.Bool("update", false, "Whether to incrementally update the existing --out table using the given entries as a delta for each of their files (the table's file tree, identifiers, and status are left unchanged)")
+	changedFiles = flag.String("changed_files", "", "Comma-separated list of file tickets to replace with --update in addition to those in the delta; files without entries in the delta are removed")
+
 	verbose = flag.Bool("verbose", false, "Whether to emit extra, and possibly excessive, log messages")
 
 	experimentalBeamPipeline = flag.Bool("experimental_beam_pipeline", false, "Whether to use the Beam experimental pipeline implementation")
 )
 
 func init() {
-	gsutil.Flag(&gs, "graphstore", "GraphStore to read (mutually exclusive with --entries)")
 	flag.Usage = flagutil.SimpleUsage(
 		"Creates a combined xrefs/filetree/search serving table based on a given GraphStore or stream of GraphStore-ordered entries",
-		"(--graphstore spec | --entries path) --out path")
+		"(--graphstore spec | --entries path) --out path\n--update [--changed_files tickets] [--graphstore spec | --entries path] --out path")
 }
 
 func main() {
@@ -79,12 +100,20 @@ func main() {
 		return
 	}
 
-	if gs == nil && *entriesFile == "" {
-		flagutil.UsageError("missing --graphstore or --entries")
-	} else if gs != nil && *entriesFile != "" {
+	if *gsSpec != "" && *entriesFile != "" {
 		flagutil.UsageError("--graphstore and --entries are mutually exclusive")
+	} else if *gsSpec == "" && *entriesFile == "" && (!*update || *changedFiles == "") {
+		flagutil.UsageError("missing --graphstore or --entries")
 	} else if *tablePath == "" {
 		flagutil.UsageError("missing required --out flag")
+	} else if *changedFiles != "" && !*update {
+		flagutil.UsageError("--changed_files requires --update")
+	}
+
+	if *update {
+		if _, err := os.Stat(*tablePath); err != nil {
+			log.Fatalf("Error opening table to update: %v", err)
+		}
 	}
 
 	db, err := leveldb.Open(*tablePath, nil)
@@ -99,18 +128,51 @@ func main() {
 	defer profile.Stop()
 
 	var rd stream.EntryReader
-	if gs != nil {
+	if *gsSpec != "" {
+		gs, err := gsutil.ParseGraphStore(*gsSpec)
+		if err != nil {
+			log.Fatalf("Error opening GraphStore %q: %v", *gsSpec, err)
+		}
 		rd = func(f func(e *spb.Entry) error) error {
 			defer gs.Close(ctx)
 			return gs.Scan(ctx, &spb.ScanRequest{}, f)
 		}
-	} else {
+	} else if *entriesFile != "" {
 		f, err := vfs.Open(ctx, *entriesFile)
 		if err != nil {
 			log.Fatalf("Error opening %q: %v", *entriesFile, err)
 		}
 		defer f.Close()
 		rd = stream.NewReader(f)
+	} else {
+		rd = func(func(*spb.Entry) error) error { return nil }
+	}
+
+	if *update {
+		var changed []*spb.VName
+		if *changedFiles != "" {
+			for _, ticket := range strings.Split(*changedFiles, ",") {
+				file, err := kytheuri.ToVName(ticket)
+				if err != nil {
+					log.Fatalf("Invalid --changed_files ticket %q: %v", ticket, err)
+				}
+				changed = append(changed, file)
+			}
+		}
+		if err := pipeline.Update(ctx, rd, db, changed, &pipeline.Options{
+			Verbose:        *verbose,
+			MaxPageSize:    *maxPageSize,
+			CompressShards: *compressShards,
+			MaxShardSize:   *maxShardSize,
+		}); err != nil {
+			log.Fatal("FATAL ERROR: ", err)
+		}
+		return
+	}
+
+	st, err := statusBuilder()
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	if err := pipeline.Run(ctx, rd, db, &pipeline.Options{
@@ -118,30 +180,78 @@ func main() {
 		MaxPageSize:    *maxPageSize,
 		CompressShards: *compressShards,
 		MaxShardSize:   *maxShardSize,
+		Status:         st,
 	}); err != nil {
 		log.Fatal("FATAL ERROR: ", err)
 	}
 }
 
+// statusBuilder returns a status.Builder configured by the status flags and
+// populated with the compilation units of each --compilations kzip.
+func statusBuilder() (*status.Builder, error) {
+	b := &status.Builder{IndexVersion: *indexVersion}
+	if *experimentalLanguages != "" {
+		b.Support = make(map[string]stpb.StatusReply_Language_Support)
+		for _, lang := range strings.Split(*experimentalLanguages, ",") {
+			b.Support[lang] = stpb.StatusReply_Language_EXPERIMENTAL
+		}
+	}
+	if *compilations == "" {
+		return b, nil
+	}
+	for _, path := range strings.Split(*compilations, ",") {
+		if err := addUnits(b, path); err != nil {
+			return nil, fmt.Errorf("error reading compilations from %q: %v", path, err)
+		}
+	}
+	return b, nil
+}
+
+func addUnits(b *status.Builder, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return kzip.Scan(f, func(_ *kzip.Reader, unit *kzip.Unit) error {
+		b.AddUnit(unit.Proto, unit.Index.GetRevisions())
+		return nil
+	})
+}
+
 func runExperimentalBeamPipeline(ctx context.Context) error {
 	if runnerFlag := flag.Lookup("runner"); runnerFlag.Value.String() == "direct" {
 		runnerFlag.Value.Set("disksort")
 	}
 
-	if gs != nil {
-		return errors.New("--graphstore input not supported with --experimental_beam_pipeline")
-	} else if *entriesFile == "" {
-		return errors.New("--entries file path required")
+	if *update {
+		return errors.New("--update is not supported by the Beam pipeline")
+	} else if *gsSpec == "" && *entriesFile == "" {
+		return errors.New("--graphstore or --entries required")
+	} else if *gsSpec != "" && *entriesFile != "" {
+		return errors.New("--graphstore and --entries are mutually exclusive")
 	} else if *tablePath == "" {
 		return errors.New("--out table path required")
 	}
 
+	st, err := statusBuilder()
+	if err != nil {
+		return err
+	}
+
 	p, s := beam.NewPipelineWithRoot()
-	entries := beamio.ReadEntries(s, *entriesFile)
-	k := pipeline.FromEntries(s, entries)
 	shards := 8 // TODO(schroederc): better determine number of shards
+	var entries beam.PCollection
+	if *gsSpec != "" {
+		entries = beamio.ReadGraphStore(s, *gsSpec, shards)
+	} else {
+		entries = beamio.ReadEntries(s, *entriesFile)
+	}
+	k := pipeline.FromEntries(s, entries)
+	k.MaxPageSize = *maxPageSize
 	edgeSets, edgePages := k.Edges()
 	xrefSets, xrefPages := k.CrossReferences()
+	idMatches, idPostings := k.Identifiers()
 	beamio.WriteLevelDB(s, *tablePath, shards,
 		k.CorpusRoots(),
 		k.Decorations(),
@@ -149,6 +259,8 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 		k.Documents(),
 		xrefSets, xrefPages,
 		edgeSets, edgePages,
+		idMatches, idPostings,
+		k.Status(st),
 	)
 	return beamx.Run(ctx, p)
 }
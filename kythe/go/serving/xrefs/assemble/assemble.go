@@ -26,6 +26,8 @@ import (
 	"sort"
 	"strconv"
 
+	"bitbucket.org/creachadair/stringset"
+
 	"kythe.io/kythe/go/services/graphstore"
 	"kythe.io/kythe/go/services/xrefs"
 	"kythe.io/kythe/go/storage/stream"
@@ -538,18 +540,25 @@ func (b *CrossReferencesBuilder) constructPager() *pager.SetPager {
 				return nil
 			}
 			lg.Anchor = append(lg.Anchor, rg.Anchor...)
+			lg.RelatedNode = append(lg.RelatedNode, rg.RelatedNode...)
+			lg.Caller = append(lg.Caller, rg.Caller...)
 			return lg
 		},
 		Split: func(sz int, g pager.Group) (l, r pager.Group) {
+			// Each group is composed entirely of anchors, related nodes, or callers.
 			og := g.(*srvpb.PagedCrossReferences_Group)
-			ng := &srvpb.PagedCrossReferences_Group{
-				Kind:   og.Kind,
-				Anchor: og.Anchor[:sz],
+			ng := &srvpb.PagedCrossReferences_Group{Kind: og.Kind}
+			switch {
+			case len(og.RelatedNode) > 0:
+				ng.RelatedNode, og.RelatedNode = og.RelatedNode[:sz], og.RelatedNode[sz:]
+			case len(og.Caller) > 0:
+				ng.Caller, og.Caller = og.Caller[:sz], og.Caller[sz:]
+			default:
+				ng.Anchor, og.Anchor = og.Anchor[:sz], og.Anchor[sz:]
 			}
-			og.Anchor = og.Anchor[sz:]
 			return ng, og
 		},
-		Size: func(g pager.Group) int { return len(g.(*srvpb.PagedCrossReferences_Group).Anchor) },
+		Size: func(g pager.Group) int { return crossReferencesGroupSize(g.(*srvpb.PagedCrossReferences_Group)) },
 
 		OutputSet: func(ctx context.Context, total int, s pager.Set, grps []pager.Group) error {
 			xs := s.(*srvpb.PagedCrossReferences)
@@ -576,16 +585,52 @@ func (b *CrossReferencesBuilder) constructPager() *pager.SetPager {
 				SourceTicket: xs.SourceTicket,
 				Group:        xg,
 			}
-			xs.PageIndex = append(xs.PageIndex, &srvpb.PagedCrossReferences_PageIndex{
+			idx := &srvpb.PagedCrossReferences_PageIndex{
 				PageKey: key,
 				Kind:    xg.Kind,
-				Count:   int32(len(xg.Anchor)),
-			})
+				Count:   int32(crossReferencesGroupSize(xg)),
+			}
+			addPageLocations(idx, xg)
+			xs.PageIndex = append(xs.PageIndex, idx)
 			return b.OutputPage(ctx, pg)
 		},
 	}
 }
 
+// addPageLocations records the corpora, roots, and path range of the files
+// containing the anchors and callers of g into idx.
+func addPageLocations(idx *srvpb.PagedCrossReferences_PageIndex, g *srvpb.PagedCrossReferences_Group) {
+	corpora, roots := stringset.New(), stringset.New()
+	var seen bool
+	add := func(a *srvpb.ExpandedAnchor) {
+		if a == nil {
+			return
+		}
+		uri, err := kytheuri.Parse(a.Ticket)
+		if err != nil {
+			log.Printf("WARNING: invalid anchor ticket %q: %v", a.Ticket, err)
+			return
+		}
+		if !seen || uri.Path < idx.MinPath {
+			idx.MinPath = uri.Path
+		}
+		if !seen || uri.Path > idx.MaxPath {
+			idx.MaxPath = uri.Path
+		}
+		seen = true
+		corpora.Add(uri.Corpus)
+		roots.Add(uri.Root)
+	}
+	for _, a := range g.Anchor {
+		add(a)
+	}
+	for _, c := range g.Caller {
+		add(c.Caller)
+	}
+	idx.Corpus = corpora.Elements()
+	idx.Root = roots.Elements()
+}
+
 // StartSet begins a new *srvpb.PagedCrossReferences.  As a side-effect, a
 // previously-built srvpb.PagedCrossReferences may be emitted.
 func (b *CrossReferencesBuilder) StartSet(ctx context.Context, src *srvpb.Node) error {
@@ -606,6 +651,12 @@ func (b *CrossReferencesBuilder) AddGroup(ctx context.Context, g *srvpb.PagedCro
 // *srvpb.PagedCrossReferences_Page currently being built.
 func (b *CrossReferencesBuilder) Flush(ctx context.Context) error { return b.pager.Flush(ctx) }
 
+// crossReferencesGroupSize returns the number of anchors, related nodes, and
+// callers in g.
+func crossReferencesGroupSize(g *srvpb.PagedCrossReferences_Group) int {
+	return len(g.Anchor) + len(g.RelatedNode) + len(g.Caller)
+}
+
 func newPageKey(src string, n int) string { return fmt.Sprintf("%s.%.10d", src, n) }
 
 // CrossReference returns a (Referent, TargetAnchor) *ipb.CrossReference
@@ -380,3 +380,61 @@ func TestEdgeSetBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestCrossReferencesBuilderPageLocations(t *testing.T) {
+	var sets []*srvpb.PagedCrossReferences
+	b := &CrossReferencesBuilder{
+		MaxPageSize: 3,
+		Output: func(_ context.Context, s *srvpb.PagedCrossReferences) error {
+			sets = append(sets, s)
+			return nil
+		},
+		OutputPage: func(context.Context, *srvpb.PagedCrossReferences_Page) error { return nil },
+	}
+
+	testutil.FatalOnErrT(t, "Failure to StartSet: %v", b.StartSet(ctx, getNode("someSource")))
+	testutil.FatalOnErrT(t, "Failure to AddGroup: %v", b.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{
+		Kind: "#internal/ref/call/direct",
+		Caller: []*srvpb.PagedCrossReferences_Caller{
+			{Caller: &srvpb.ExpandedAnchor{Ticket: "kythe://corpus?path=a#1"}},
+			{Caller: &srvpb.ExpandedAnchor{Ticket: "kythe://corpus?path=b#2"}},
+			{Caller: &srvpb.ExpandedAnchor{Ticket: "kythe://corpus?path=c#3"}},
+		},
+	}))
+	testutil.FatalOnErrT(t, "Failure to AddGroup: %v", b.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{
+		Kind: "/kythe/edge/ref",
+		Anchor: []*srvpb.ExpandedAnchor{
+			{Ticket: "kythe://corpus?root=r?path=src/b#1"},
+			{Ticket: "kythe://other?path=lib/a#2"},
+			{Ticket: "kythe://corpus?root=r?path=src/c#3"},
+		},
+	}))
+	testutil.FatalOnErrT(t, "Failure to AddGroup: %v", b.AddGroup(ctx, &srvpb.PagedCrossReferences_Group{
+		Kind:   "/kythe/edge/defines/binding",
+		Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://corpus?path=def#1"}},
+	}))
+	testutil.FatalOnErrT(t, "Failure to Flush: %v", b.Flush(ctx))
+
+	if len(sets) != 1 {
+		t.Fatalf("Expected 1 PagedCrossReferences; found: %v", sets)
+	}
+	if err := testutil.DeepEqual([]*srvpb.PagedCrossReferences_PageIndex{{
+		PageKey: "someSource.0000000001",
+		Kind:    "/kythe/edge/ref",
+		Count:   3,
+		Corpus:  []string{"corpus", "other"},
+		Root:    []string{"", "r"},
+		MinPath: "lib/a",
+		MaxPath: "src/c",
+	}, {
+		PageKey: "someSource.0000000000",
+		Kind:    "#internal/ref/call/direct",
+		Count:   3,
+		Corpus:  []string{"corpus"},
+		Root:    []string{""},
+		MinPath: "a",
+		MaxPath: "c",
+	}}, sets[0].PageIndex); err != nil {
+		t.Error(err)
+	}
+}
@@ -452,6 +452,11 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 
 	patterns := xrefs.ConvertFilters(req.Filter)
 
+	cpFilter, err := newCorpusPathFilter(req.CorpusPathFilter)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid corpus_path_filter: %v", err)
+	}
+
 	nextPageToken := &ipb.PageToken{
 		SubTokens: make(map[string]string),
 		Indices:   make(map[string]int32),
@@ -483,6 +488,16 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 		}
 		foundCrossRefs = true
 
+		if cpFilter != nil {
+			incomplete := cr.Incomplete
+			cr = filterCrossReferences(ctx, cr, cpFilter, func(kind string) bool {
+				return xrefs.IsDefKind(req.DefinitionKind, kind, incomplete) ||
+					xrefs.IsDeclKind(req.DeclarationKind, kind, incomplete) ||
+					xrefs.IsRefKind(req.ReferenceKind, kind) ||
+					xrefs.IsCallerKind(req.CallerKind, kind)
+			})
+		}
+
 		// If this node is to be merged into another, we will use that node's ticket
 		// for all further book-keeping purposes.
 		ticket = mergeInto[ticket]
@@ -560,7 +575,7 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 					if err != nil {
 						return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
 					}
-					stats.addAnchors(&crs.Definition, p.Group, req.AnchorText)
+					stats.addAnchors(&crs.Definition, stats.filterPage(p.Group, cpFilter), req.AnchorText)
 				}
 			case xrefs.IsDeclKind(req.DeclarationKind, idx.Kind, cr.Incomplete):
 				reply.Total.Declarations += int64(idx.Count)
@@ -569,7 +584,7 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 					if err != nil {
 						return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
 					}
-					stats.addAnchors(&crs.Declaration, p.Group, req.AnchorText)
+					stats.addAnchors(&crs.Declaration, stats.filterPage(p.Group, cpFilter), req.AnchorText)
 				}
 			case xrefs.IsRefKind(req.ReferenceKind, idx.Kind):
 				reply.Total.References += int64(idx.Count)
@@ -578,7 +593,7 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 					if err != nil {
 						return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
 					}
-					stats.addAnchors(&crs.Reference, p.Group, req.AnchorText)
+					stats.addAnchors(&crs.Reference, stats.filterPage(p.Group, cpFilter), req.AnchorText)
 				}
 			case len(req.Filter) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, idx.Kind):
 				reply.Total.RelatedNodesByRelation[idx.Kind] += int64(idx.Count)
@@ -596,7 +611,7 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 					if err != nil {
 						return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
 					}
-					stats.addCallers(crs, p.Group)
+					stats.addCallers(crs, stats.filterPage(p.Group, cpFilter))
 				}
 			}
 		}
@@ -611,8 +626,8 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 		return &xpb.CrossReferencesReply{}, nil
 	}
 
-	if initialSkip+stats.total != sumTotalCrossRefs(reply.Total) && stats.total != 0 {
-		nextPageToken.Indices["skip"] = int32(initialSkip + stats.total)
+	if read := stats.total + stats.filtered; initialSkip+read != sumTotalCrossRefs(reply.Total) && read != 0 {
+		nextPageToken.Indices["skip"] = int32(initialSkip + read)
 	}
 
 	if _, skip := nextPageToken.Indices["skip"]; skip || nextPageToken.SubTokens["edges"] != "" {
@@ -646,6 +661,147 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 	return reply, nil
 }
 
+// filterCrossReferences returns a copy of cr whose groups of the kinds selected
+// by filterKind only contain the anchors and callers matching f.  Pages of
+// those kinds are dropped when their PageIndex shows that none of their
+// anchors can match; the remaining pages are filtered as they are read (see
+// refStats.filterPage).  All other groups and page indices are retained as-is.
+func filterCrossReferences(ctx context.Context, cr *srvpb.PagedCrossReferences, f *corpusPathFilter, filterKind func(string) bool) *srvpb.PagedCrossReferences {
+	res := &srvpb.PagedCrossReferences{
+		SourceTicket: cr.SourceTicket,
+		SourceNode:   cr.SourceNode,
+		MergeWith:    cr.MergeWith,
+		Incomplete:   cr.Incomplete,
+		MarkedSource: cr.MarkedSource,
+	}
+	for _, grp := range cr.Group {
+		if !filterKind(grp.Kind) {
+			res.Group = append(res.Group, grp)
+		} else if g := f.filterGroup(grp); g != nil {
+			res.Group = append(res.Group, g)
+		}
+	}
+	for _, idx := range cr.PageIndex {
+		if filterKind(idx.Kind) && !f.mayMatchPage(idx) {
+			tracePrintf(ctx, "Skipping filtered page: %s", idx.PageKey)
+			continue
+		}
+		res.PageIndex = append(res.PageIndex, idx)
+	}
+	return res
+}
+
+// A corpusPathFilter matches anchors by the corpus, root, and path of their
+// parent files.  A nil *regexp.Regexp matches everything.
+type corpusPathFilter struct {
+	corpus, root, path *regexp.Regexp
+
+	// pathPrefix is a literal prefix of every path matched by path.
+	pathPrefix string
+}
+
+// newCorpusPathFilter compiles the given filter.  If f is empty, a nil
+// *corpusPathFilter is returned.
+func newCorpusPathFilter(f *xpb.CrossReferencesRequest_CorpusPathFilter) (*corpusPathFilter, error) {
+	if f.GetCorpus() == "" && f.GetRoot() == "" && f.GetPath() == "" {
+		return nil, nil
+	}
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if pattern == "" {
+			return nil, nil
+		}
+		return regexp.Compile("^(?:" + pattern + ")$")
+	}
+	var (
+		cf  corpusPathFilter
+		err error
+	)
+	if cf.corpus, err = compile(f.Corpus); err != nil {
+		return nil, err
+	} else if cf.root, err = compile(f.Root); err != nil {
+		return nil, err
+	} else if cf.path, err = compile(f.Path); err != nil {
+		return nil, err
+	}
+	if cf.path != nil {
+		// The anchors added above hide the literal prefix of many patterns (e.g.
+		// "kythe/go/.*\.go") so it must be taken from the pattern itself.
+		if re, err := regexp.Compile(f.Path); err == nil {
+			cf.pathPrefix, _ = re.LiteralPrefix()
+		}
+	}
+	return &cf, nil
+}
+
+// matchesAnchor reports whether the given anchor is located within a file
+// matching f.
+func (f *corpusPathFilter) matchesAnchor(a *srvpb.ExpandedAnchor) bool {
+	if a == nil {
+		return false
+	}
+	uri, err := kytheuri.Parse(a.Ticket)
+	if err != nil {
+		log.Printf("Error parsing anchor ticket: %v", err)
+		return false
+	}
+	return (f.corpus == nil || f.corpus.MatchString(uri.Corpus)) &&
+		(f.root == nil || f.root.MatchString(uri.Root)) &&
+		(f.path == nil || f.path.MatchString(uri.Path))
+}
+
+// mayMatchPage reports whether any of the anchors on the referenced page may
+// match f.  Pages without recorded locations always may match.
+func (f *corpusPathFilter) mayMatchPage(idx *srvpb.PagedCrossReferences_PageIndex) bool {
+	if len(idx.Corpus) == 0 {
+		return true
+	}
+	if f.corpus != nil && !matchesAny(f.corpus, idx.Corpus) {
+		return false
+	} else if f.root != nil && !matchesAny(f.root, idx.Root) {
+		return false
+	}
+	if p := f.pathPrefix; p != "" {
+		// The paths with prefix p form a contiguous range starting at p.
+		if idx.MaxPath < p || (idx.MinPath > p && !strings.HasPrefix(idx.MinPath, p)) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(re *regexp.Regexp, ss []string) bool {
+	for _, s := range ss {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterGroup returns a copy of g containing only its anchors and callers
+// matching f.  Callers are matched by the anchor covering the caller.  Related
+// nodes are kept as-is.  If nothing in g matches, nil is returned.
+func (f *corpusPathFilter) filterGroup(g *srvpb.PagedCrossReferences_Group) *srvpb.PagedCrossReferences_Group {
+	res := &srvpb.PagedCrossReferences_Group{
+		Kind:        g.Kind,
+		RelatedNode: g.RelatedNode,
+	}
+	for _, a := range g.Anchor {
+		if f.matchesAnchor(a) {
+			res.Anchor = append(res.Anchor, a)
+		}
+	}
+	for _, c := range g.Caller {
+		if f.matchesAnchor(c.Caller) {
+			res.Caller = append(res.Caller, c)
+		}
+	}
+	if len(res.Anchor) == 0 && len(res.Caller) == 0 && len(res.RelatedNode) == 0 {
+		return nil
+	}
+	return res
+}
+
 func sumTotalCrossRefs(ts *xpb.CrossReferencesReply_Total) int {
 	var relatedNodes int
 	for _, cnt := range ts.RelatedNodesByRelation {
@@ -659,7 +815,9 @@ type refStats struct {
 	//   to skip (returned on previous pages)
 	//   max to return (the page size)
 	//   total (count of refs so far read for current page)
-	skip, total, max int
+	//   filtered (count of refs so far read for current page, but dropped by a
+	//             corpusPathFilter)
+	skip, total, max, filtered int
 }
 
 func (s *refStats) skipPage(idx *srvpb.PagedCrossReferences_PageIndex) bool {
@@ -670,6 +828,49 @@ func (s *refStats) skipPage(idx *srvpb.PagedCrossReferences_PageIndex) bool {
 	return s.total >= s.max
 }
 
+// filterPage returns the anchors and callers of a page's group g that match f.
+// The first s.skip anchors and callers of g are skipped whether or not they
+// match and reading stops as soon as the current page of results is full; the
+// non-matching anchors and callers read in between are counted as filtered so
+// that the next page token resumes right after the last one read.  If f is nil,
+// g is returned as-is.
+func (s *refStats) filterPage(g *srvpb.PagedCrossReferences_Group, f *corpusPathFilter) *srvpb.PagedCrossReferences_Group {
+	if f == nil {
+		return g
+	}
+	res := &srvpb.PagedCrossReferences_Group{Kind: g.Kind}
+	room := s.max - s.total
+	for _, a := range g.Anchor {
+		if s.skip > 0 {
+			s.skip--
+			continue
+		} else if room == 0 {
+			break
+		}
+		if f.matchesAnchor(a) {
+			res.Anchor = append(res.Anchor, a)
+			room--
+		} else {
+			s.filtered++
+		}
+	}
+	for _, c := range g.Caller {
+		if s.skip > 0 {
+			s.skip--
+			continue
+		} else if room == 0 {
+			break
+		}
+		if f.matchesAnchor(c.Caller) {
+			res.Caller = append(res.Caller, c)
+			room--
+		} else {
+			s.filtered++
+		}
+	}
+	return res
+}
+
 func (s *refStats) addCallers(crs *xpb.CrossReferencesReply_CrossReferenceSet, grp *srvpb.PagedCrossReferences_Group) bool {
 	cs := grp.Caller
 
@@ -1055,6 +1055,175 @@ func TestCrossReferencesOverrideCallers(t *testing.T) {
 	}
 }
 
+func TestCrossReferencesCorpusPathFilter(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:           []string{ticket},
+		DefinitionKind:   xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+		CorpusPathFilter: &xpb.CrossReferencesRequest_CorpusPathFilter{Corpus: "c", Path: "/a/.*"},
+	})
+	testutil.FatalOnErrT(t, "CrossReferencesRequest error: %v", err)
+
+	// The reference page may match the filter so all of its anchors are counted.
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+		Definitions: 1,
+		References:  2,
+	}, reply.Total); err != nil {
+		t.Error(err)
+	}
+
+	xr := reply.CrossReferences[ticket]
+	if xr == nil {
+		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+	}
+	if err := testutil.DeepEqual([]string{"kythe://c?lang=otpl?path=/a/path#27-33"}, anchorTickets(xr.Definition)); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.DeepEqual([]string{"kythe://c?lang=otpl?path=/a/path#51-55"}, anchorTickets(xr.Reference)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossReferencesCorpusPathFilterCallers(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#withCallers"
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:           []string{ticket},
+		CallerKind:       xpb.CrossReferencesRequest_OVERRIDE_CALLERS,
+		CorpusPathFilter: &xpb.CrossReferencesRequest_CorpusPathFilter{Path: "otherFile"},
+	})
+	testutil.FatalOnErrT(t, "CrossReferencesRequest error: %v", err)
+
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{}, reply.Total); err != nil {
+		t.Error(err)
+	}
+	if xr := reply.CrossReferences[ticket]; xr != nil {
+		t.Errorf("Unexpected CrossReferences: %v", xr)
+	}
+}
+
+func TestCrossReferencesCorpusPathFilterPages(t *testing.T) {
+	ticket := "kythe://corpus?lang=otpl#sig"
+	ref := func(path string) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{Ticket: "kythe://corpus?lang=otpl?path=" + path + "#ref"}
+	}
+	// The "vendor" and "other" pages are not written to the table; the filter
+	// must skip them based solely on their PageIndex.
+	tt := &testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{ref("src/inline"), ref("vendor/inline")},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				PageKey: "vendor",
+				Kind:    "%/kythe/edge/ref",
+				Count:   2,
+				Corpus:  []string{"corpus"},
+				Root:    []string{""},
+				MinPath: "vendor/a",
+				MaxPath: "vendor/b",
+			}, {
+				PageKey: "src",
+				Kind:    "%/kythe/edge/ref",
+				Count:   2,
+				Corpus:  []string{"corpus"},
+				Root:    []string{""},
+				MinPath: "lib/a",
+				MaxPath: "src/a",
+			}, {
+				PageKey: "other",
+				Kind:    "%/kythe/edge/ref",
+				Count:   1,
+				Corpus:  []string{"other"},
+				Root:    []string{""},
+				MinPath: "src/b",
+				MaxPath: "src/b",
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "src",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{ref("lib/a"), ref("src/a")},
+			},
+		}},
+	}
+	st := tt.Construct(t)
+
+	// Only the first pattern's literal prefix survives anchoring in the compiled
+	// regexp; both must skip the "vendor" page.
+	for _, path := range []string{"src/.*", "src/.*[a-z]"} {
+		req := &xpb.CrossReferencesRequest{
+			Ticket:           []string{ticket},
+			ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+			CorpusPathFilter: &xpb.CrossReferencesRequest_CorpusPathFilter{Corpus: "corpus", Path: path},
+			PageSize:         1,
+		}
+		var refs []string
+		for {
+			reply, err := st.CrossReferences(ctx, req)
+			testutil.FatalOnErrT(t, "CrossReferencesRequest error: %v", err)
+			// The "src" page is counted whole: 1 inline match + 2 paged anchors.
+			if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{References: 3}, reply.Total); err != nil {
+				t.Fatalf("%q: %v", path, err)
+			}
+			if xr := reply.CrossReferences[ticket]; xr != nil {
+				refs = append(refs, anchorTickets(xr.Reference)...)
+			}
+			if reply.NextPageToken == "" {
+				break
+			}
+			req.PageToken = reply.NextPageToken
+		}
+
+		if err := testutil.DeepEqual([]string{
+			"kythe://corpus?lang=otpl?path=src/inline#ref",
+			"kythe://corpus?lang=otpl?path=src/a#ref",
+		}, refs); err != nil {
+			t.Errorf("%q: %v", path, err)
+		}
+	}
+
+	// The first page of results is filled by the inline anchor, so the "src"
+	// page must not be read until the next one.
+	tt.RefPages = nil
+	reply, err := tt.Construct(t).CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:           []string{ticket},
+		ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+		CorpusPathFilter: &xpb.CrossReferencesRequest_CorpusPathFilter{Path: "src/.*"},
+		PageSize:         1,
+	})
+	testutil.FatalOnErrT(t, "CrossReferencesRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Error("Missing NextPageToken")
+	}
+}
+
+func TestCrossReferencesCorpusPathFilterInvalid(t *testing.T) {
+	st := tbl.Construct(t)
+	if reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:           []string{"kythe://someCorpus?lang=otpl#signature"},
+		ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+		CorpusPathFilter: &xpb.CrossReferencesRequest_CorpusPathFilter{Path: "("},
+	}); err == nil {
+		t.Errorf("Expected error for invalid filter; found reply: %v", reply)
+	}
+}
+
+func anchorTickets(ras []*xpb.CrossReferencesReply_RelatedAnchor) []string {
+	var res []string
+	for _, ra := range ras {
+		res = append(res, ra.Anchor.Ticket)
+	}
+	return res
+}
+
 func nodeInfos(nss ...[]*srvpb.Node) map[string]*cpb.NodeInfo {
 	m := make(map[string]*cpb.NodeInfo)
 	for _, ns := range nss {
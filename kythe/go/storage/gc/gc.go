@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gc finds and removes dangling entries from a GraphStore.
+//
+// A node exists in a GraphStore only if it has at least one fact.  Edges from
+// or to a node without facts are garbage: they are left behind, for instance,
+// when the facts of a removed file's nodes are deleted.  Removing the edges
+// from such a node removes the node itself, since edges are its only entries.
+package gc
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/graphstore"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// maxBatchSize is the maximum number of entries given to a single Delete call.
+const maxBatchSize = 1024
+
+// vnameKey is a comparable representation of a VName.
+type vnameKey struct{ signature, corpus, root, path, language string }
+
+func keyOf(v *spb.VName) vnameKey {
+	return vnameKey{v.GetSignature(), v.GetCorpus(), v.GetRoot(), v.GetPath(), v.GetLanguage()}
+}
+
+// Find scans gs and calls f, in GraphStore order, with each edge whose source
+// or target has no facts.  The set of nodes is kept in memory.
+func Find(ctx context.Context, gs graphstore.Service, f graphstore.EntryFunc) error {
+	nodes := make(map[vnameKey]bool)
+	if err := gs.Scan(ctx, new(spb.ScanRequest), func(e *spb.Entry) error {
+		if graphstore.IsNodeFact(e) {
+			nodes[keyOf(e.Source)] = true
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return gs.Scan(ctx, new(spb.ScanRequest), func(e *spb.Entry) error {
+		if graphstore.IsEdge(e) && (!nodes[keyOf(e.Source)] || !nodes[keyOf(e.Target)]) {
+			return f(e)
+		}
+		return nil
+	})
+}
+
+// Collect removes each entry found by Find from gs and returns the number of
+// entries removed, even if an error occurs partway; the entries are gathered
+// before any are deleted, so gs is never written to while it is being scanned.
+func Collect(ctx context.Context, gs graphstore.Deleter) (int, error) {
+	var garbage []*spb.Entry
+	if err := Find(ctx, gs, func(e *spb.Entry) error {
+		garbage = append(garbage, e)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	entries := make(chan *spb.Entry)
+	go func() {
+		defer close(entries)
+		for _, e := range garbage {
+			entries <- e
+		}
+	}()
+	var removed int
+	var err error
+	for req := range graphstore.BatchWrites(entries, maxBatchSize) {
+		if err != nil {
+			continue // drain the remaining batches
+		} else if err = gs.Delete(ctx, req); err == nil {
+			removed += len(req.Update)
+		}
+	}
+	return removed, err
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gc
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/test/testutil"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	file := &spb.VName{Signature: "file", Corpus: "c"}
+	anchor := &spb.VName{Signature: "anchor", Corpus: "c"}
+	removed := &spb.VName{Signature: "removed", Corpus: "c"}
+	orphan := &spb.VName{Signature: "orphan", Corpus: "c"}
+
+	gs := new(inmemory.GraphStore)
+	write := func(src *spb.VName, updates ...*spb.WriteRequest_Update) {
+		testutil.FatalOnErrT(t, "Write error: %v", gs.Write(ctx, &spb.WriteRequest{Source: src, Update: updates}))
+	}
+	fact := &spb.WriteRequest_Update{FactName: "/kythe/node/kind", FactValue: []byte("test")}
+	edge := func(kind string, target *spb.VName) *spb.WriteRequest_Update {
+		return &spb.WriteRequest_Update{EdgeKind: kind, Target: target, FactName: "/"}
+	}
+	write(file, fact)
+	write(anchor, fact, edge("/kythe/edge/childof", file), edge("/kythe/edge/ref", removed))
+	write(orphan, edge("/kythe/edge/childof", file), edge("/kythe/edge/ref", anchor))
+
+	n, err := Collect(ctx, gs)
+	testutil.FatalOnErrT(t, "Collect error: %v", err)
+	if n != 3 {
+		t.Errorf("Collect: removed %d entries; want %d", n, 3)
+	}
+
+	var got []string
+	testutil.FatalOnErrT(t, "Scan error: %v", gs.Scan(ctx, new(spb.ScanRequest), func(e *spb.Entry) error {
+		label := e.FactName
+		if graphstore.IsEdge(e) {
+			label = e.EdgeKind + " " + e.Target.Signature
+		}
+		got = append(got, e.Source.Signature+" "+label)
+		return nil
+	}))
+	want := []string{
+		"anchor /kythe/node/kind",
+		"anchor /kythe/edge/childof file",
+		"file /kythe/node/kind",
+	}
+	if err := testutil.DeepEqual(want, got); err != nil {
+		t.Error(err)
+	}
+
+	// A second collection finds nothing more to remove.
+	if n, err := Collect(ctx, gs); err != nil || n != 0 {
+		t.Errorf("Second Collect: got (%d, %v); want (0, <nil>)", n, err)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package goleveldb implements a graphstore.Service and keyvalue.DB using a
+// pure-Go LevelDB backend database.  Unlike package leveldb, it does not
+// require cgo.  The on-disk format is compatible with LevelDB, so databases
+// written by either package may be read by the other.
+package goleveldb
+
+import (
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func init() {
+	gsutil.Register("goleveldb", func(spec string) (graphstore.Service, error) { return OpenGraphStore(spec, nil) })
+}
+
+// levelDB is a wrapper around a leveldb.DB that implements keyvalue.DB
+type levelDB struct {
+	db *leveldb.DB
+
+	readOpts      *opt.ReadOptions
+	largeReadOpts *opt.ReadOptions
+}
+
+// DefaultOptions is the default Options struct passed to Open when not
+// otherwise given one.
+var DefaultOptions = &Options{
+	CacheCapacity:   512 * 1024 * 1024, // 512mb
+	WriteBufferSize: 60 * 1024 * 1024,  // 60mb
+}
+
+// Options for customizing a pure-Go LevelDB backend.  Zero values select the
+// defaults of the underlying implementation.
+type Options struct {
+	// CacheCapacity is the caching capacity (in bytes) used for the LevelDB.
+	CacheCapacity int
+
+	// CacheLargeReads determines whether to use the cache for large reads. This
+	// is usually discouraged but may be useful when the entire LevelDB is known
+	// to fit into the cache.
+	CacheLargeReads bool
+
+	// WriteBufferSize is the number of bytes the database will build up in memory
+	// (backed by a disk log) before writing to the on-disk table.
+	WriteBufferSize int
+
+	// MustExist ensures that the given database exists before opening it.  If
+	// false and the database does not exist, it will be created.
+	MustExist bool
+
+	// OpenFilesCacheCapacity is the maximum number of table files the database
+	// will hold open at once.  A negative value disables the cache, so that
+	// table files are opened only as needed.
+	OpenFilesCacheCapacity int
+
+	// CompactionTableSize is the size (in bytes) of the table files produced by
+	// compaction at the first level; deeper levels use larger files.  Larger
+	// tables reduce the number of files in the database.
+	CompactionTableSize int
+}
+
+// OpenGraphStore returns a graphstore.Service backed by a LevelDB database at
+// the given filepath.  If opts==nil, the DefaultOptions are used.
+func OpenGraphStore(path string, opts *Options) (graphstore.Service, error) {
+	db, err := Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return keyvalue.NewGraphStore(db), nil
+}
+
+// Open returns a keyvalue DB backed by a LevelDB database at the given
+// filepath.  If opts==nil, the DefaultOptions are used.
+func Open(path string, opts *Options) (keyvalue.DB, error) {
+	if opts == nil {
+		opts = DefaultOptions
+	}
+	db, err := leveldb.OpenFile(path, &opt.Options{
+		BlockCacheCapacity:     opts.CacheCapacity,
+		WriteBuffer:            opts.WriteBufferSize,
+		ErrorIfMissing:         opts.MustExist,
+		OpenFilesCacheCapacity: opts.OpenFilesCacheCapacity,
+		CompactionTableSize:    opts.CompactionTableSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not open LevelDB at %q: %v", path, err)
+	}
+	return &levelDB{
+		db:            db,
+		readOpts:      new(opt.ReadOptions),
+		largeReadOpts: &opt.ReadOptions{DontFillCache: !opts.CacheLargeReads},
+	}, nil
+}
+
+// Close will close the underlying LevelDB database.
+func (s *levelDB) Close() error { return s.db.Close() }
+
+type snapshot struct {
+	s   *leveldb.Snapshot
+	err error // error from acquiring the snapshot, reported on use
+}
+
+// NewSnapshot implements part of the keyvalue.DB interface.
+func (s *levelDB) NewSnapshot() keyvalue.Snapshot {
+	snap, err := s.db.GetSnapshot()
+	return &snapshot{snap, err}
+}
+
+// Close implements part of the keyvalue.Snapshot interface.
+func (s *snapshot) Close() error {
+	if s.s != nil {
+		s.s.Release()
+	}
+	return nil
+}
+
+// Writer implements part of the keyvalue.DB interface.
+func (s *levelDB) Writer() (keyvalue.Writer, error) {
+	return &writer{s, new(leveldb.Batch)}, nil
+}
+
+// Get implements part of the keyvalue.DB interface.
+func (s *levelDB) Get(key []byte, opts *keyvalue.Options) ([]byte, error) {
+	ro := s.readOptions(opts)
+	var v []byte
+	var err error
+	if snap := opts.GetSnapshot(); snap != nil {
+		snap := snap.(*snapshot)
+		if snap.err != nil {
+			return nil, snap.err
+		}
+		v, err = snap.s.Get(key, ro)
+	} else {
+		v, err = s.db.Get(key, ro)
+	}
+	if err == leveldb.ErrNotFound {
+		return nil, io.EOF
+	}
+	return v, err
+}
+
+// ScanPrefix implements part of the keyvalue.DB interface.
+func (s *levelDB) ScanPrefix(prefix []byte, opts *keyvalue.Options) (keyvalue.Iterator, error) {
+	return s.iterator(util.BytesPrefix(prefix), opts)
+}
+
+// ScanRange implements part of the keyvalue.DB interface.
+func (s *levelDB) ScanRange(r *keyvalue.Range, opts *keyvalue.Options) (keyvalue.Iterator, error) {
+	return s.iterator(&util.Range{Start: r.Start, Limit: r.End}, opts)
+}
+
+func (s *levelDB) readOptions(opts *keyvalue.Options) *opt.ReadOptions {
+	if opts.IsLargeRead() {
+		return s.largeReadOpts
+	}
+	return s.readOpts
+}
+
+// iterator returns an iterator over the keys in r, reading from the snapshot
+// given in opts (if any).
+func (s *levelDB) iterator(r *util.Range, opts *keyvalue.Options) (keyvalue.Iterator, error) {
+	ro := s.readOptions(opts)
+	if snap := opts.GetSnapshot(); snap != nil {
+		snap := snap.(*snapshot)
+		if snap.err != nil {
+			return nil, snap.err
+		}
+		return iter{snap.s.NewIterator(r, ro)}, nil
+	}
+	return iter{s.db.NewIterator(r, ro)}, nil
+}
+
+type writer struct {
+	s *levelDB
+	*leveldb.Batch
+}
+
+// Write implements part of the keyvalue.Writer interface.
+func (w *writer) Write(key, val []byte) error {
+	w.Put(key, val)
+	return nil
+}
+
+// Delete implements part of the keyvalue.Writer interface.
+func (w *writer) Delete(key []byte) error {
+	w.Batch.Delete(key)
+	return nil
+}
+
+// Close implements part of the keyvalue.Writer interface.
+func (w *writer) Close() error { return w.s.db.Write(w.Batch, nil) }
+
+// Discard implements part of the keyvalue.Discarder interface.
+func (w *writer) Discard() error {
+	w.Batch.Reset()
+	return nil
+}
+
+type iter struct{ it iterator.Iterator }
+
+// Close implements part of the keyvalue.Iterator interface.
+func (i iter) Close() error {
+	i.it.Release()
+	return nil
+}
+
+// Next implements part of the keyvalue.Iterator interface.
+func (i iter) Next() ([]byte, []byte, error) {
+	if !i.it.Next() {
+		if err := i.it.Error(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, io.EOF
+	}
+	// The iterator may reuse its buffers, so the key and value must be copied.
+	key := append([]byte(nil), i.it.Key()...)
+	val := append([]byte(nil), i.it.Value()...)
+	return key, val, nil
+}
@@ -31,7 +31,7 @@ import (
 	spb "kythe.io/kythe/proto/storage_go_proto"
 )
 
-// GraphStore implements the graphstore.Service interface. A zero of this type
+// GraphStore implements the graphstore.Deleter interface. A zero of this type
 // is ready for use, and is safe for access by concurrent goroutines.
 type GraphStore struct {
 	mu      sync.RWMutex
@@ -57,6 +57,45 @@ func (s *GraphStore) Write(ctx context.Context, req *spb.WriteRequest) error {
 	return nil
 }
 
+// Delete implements part of the graphstore.Deleter interface.
+func (s *GraphStore) Delete(ctx context.Context, req *spb.WriteRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range req.Update {
+		key := &spb.Entry{
+			Source:   req.Source,
+			EdgeKind: u.EdgeKind,
+			Target:   u.Target,
+			FactName: u.FactName,
+		}
+		s.remove(func(e *spb.Entry) bool { return compare.Entries(e, key) == compare.EQ })
+	}
+	return nil
+}
+
+// DeletePrefix implements part of the graphstore.Deleter interface.
+func (s *GraphStore) DeletePrefix(ctx context.Context, prefix *spb.VName) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(func(e *spb.Entry) bool { return graphstore.VNameHasPrefix(e.Source, prefix) })
+	return nil
+}
+
+// remove drops each entry for which match returns true, preserving the order
+// of the remaining entries.
+func (s *GraphStore) remove(match func(*spb.Entry) bool) {
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if !match(e) {
+			kept = append(kept, e)
+		}
+	}
+	for i := len(kept); i < len(s.entries); i++ {
+		s.entries[i] = nil // allow the removed entries to be collected
+	}
+	s.entries = kept
+}
+
 func (s *GraphStore) insert(e *spb.Entry) {
 	i := sort.Search(len(s.entries), func(i int) bool {
 		return compare.Entries(e, s.entries[i]) == compare.LT
@@ -41,9 +41,10 @@ const debug = false
 type Store struct {
 	db DB
 
-	shardMu        sync.Mutex // guards shardTables/shardSnapshots during construction
-	shardTables    map[int64][]shard
-	shardSnapshots map[int64]Snapshot
+	shardMu     sync.Mutex            // guards shardTables and their reference counts
+	shardTables map[int64]*shardTable // cached partitionings by number of shards
+
+	unitMu sync.Mutex // serializes changes to the unit index (see units.go)
 }
 
 // Range is section of contiguous keys, including Start and excluding End.
@@ -56,6 +57,17 @@ type shard struct {
 	count int64
 }
 
+// A shardTable is a partitioning of a Store's entries, computed from a
+// snapshot of its DB.  Tables are discarded when the Store is written, but
+// their snapshots are kept until no Shard calls are reading from them.
+type shardTable struct {
+	shards   []shard
+	snapshot Snapshot
+
+	refs  int  // number of users of snapshot
+	stale bool // whether the table has been discarded
+}
+
 // NewGraphStore returns a graphstore.Service backed by the given keyvalue DB.
 func NewGraphStore(db DB) *Store {
 	return &Store{db: db}
@@ -133,6 +145,20 @@ type Writer interface {
 	// Write writes a key-value entry to the DB. Writes may be batched until the
 	// Writer is Closed.
 	Write(key, val []byte) error
+
+	// Delete removes the entry for key from the DB, if one exists.  Deletions
+	// may be batched with writes until the Writer is Closed.
+	Delete(key []byte) error
+}
+
+// A Discarder is a Writer whose batched writes and deletions can be dropped
+// without being applied to the DB.
+type Discarder interface {
+	Writer
+
+	// Discard releases the Writer without applying any of its pending writes or
+	// deletions.  The Writer must not be used (or Closed) afterwards.
+	Discard() error
 }
 
 // WritePool is a wrapper around a DB that automatically creates and flushes
@@ -199,6 +225,27 @@ func (p *WritePool) Write(key, val []byte) error {
 	return nil
 }
 
+// Delete buffers the deletion of key until the pool becomes too large or Flush
+// is called.
+func (p *WritePool) Delete(key []byte) error {
+	if p.wr == nil {
+		wr, err := p.db.Writer()
+		if err != nil {
+			return err
+		}
+		p.wr = wr
+	}
+	if err := p.wr.Delete(key); err != nil {
+		return err
+	}
+	p.size += uint64(len(key))
+	p.writes++
+	if p.opts.maxWrites() <= p.writes || p.opts.maxSize() <= p.size {
+		return p.Flush()
+	}
+	return nil
+}
+
 // Flush ensures that all buffered writes are applied to the underlying DB.
 func (p *WritePool) Flush() error {
 	if p.wr == nil {
@@ -251,7 +298,8 @@ func streamEntries(iter Iterator, f graphstore.EntryFunc) error {
 
 // Write implements part of the GraphStore interface.
 func (s *Store) Write(ctx context.Context, req *spb.WriteRequest) (err error) {
-	// TODO(schroederc): fix shardTables to include new entries
+	// Discard any partitionings once the new entries are visible.
+	defer s.invalidateShards()
 
 	wr, err := s.db.Writer()
 	if err != nil {
@@ -278,6 +326,76 @@ func (s *Store) Write(ctx context.Context, req *spb.WriteRequest) (err error) {
 	return nil
 }
 
+// Delete implements part of the graphstore.Deleter interface.
+func (s *Store) Delete(ctx context.Context, req *spb.WriteRequest) (err error) {
+	defer s.invalidateShards()
+
+	wr, err := s.db.Writer()
+	if err != nil {
+		return fmt.Errorf("db writer error: %v", err)
+	}
+	defer func() {
+		cErr := wr.Close()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("db writer close error: %v", cErr)
+		}
+	}()
+	for _, update := range req.Update {
+		key, err := EncodeKey(req.Source, update.FactName, update.EdgeKind, update.Target)
+		if err != nil {
+			return fmt.Errorf("encoding error: %v", err)
+		}
+		if err := wr.Delete(key); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		}
+	}
+	return nil
+}
+
+// DeletePrefix implements part of the graphstore.Deleter interface.
+func (s *Store) DeletePrefix(ctx context.Context, prefix *spb.VName) (err error) {
+	defer s.invalidateShards()
+
+	// Encoded keys begin with the source signature, which limits the scan when
+	// one is given.
+	keyPrefix := append(append([]byte(nil), entryKeyPrefixBytes...), prefix.GetSignature()...)
+	snapshot := s.db.NewSnapshot()
+	defer snapshot.Close()
+	iter, err := s.db.ScanPrefix(keyPrefix, &Options{
+		LargeRead: true,
+		Snapshot:  snapshot,
+	})
+	if err != nil {
+		return fmt.Errorf("db seek error: %v", err)
+	}
+	defer iter.Close()
+
+	wr := NewPool(s.db, nil)
+	defer func() {
+		fErr := wr.Flush()
+		if err == nil && fErr != nil {
+			err = fmt.Errorf("db writer flush error: %v", fErr)
+		}
+	}()
+	for {
+		key, val, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("db iteration error: %v", err)
+		}
+		entry, err := Entry(key, val)
+		if err != nil {
+			return fmt.Errorf("invalid key/value entry: %v", err)
+		}
+		if !graphstore.VNameHasPrefix(entry.Source, prefix) {
+			continue
+		} else if err := wr.Delete(key); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		}
+	}
+}
+
 // Scan implements part of the graphstore.Service interface.
 func (s *Store) Scan(ctx context.Context, req *spb.ScanRequest, f graphstore.EntryFunc) error {
 	iter, err := s.db.ScanPrefix(entryKeyPrefixBytes, &Options{LargeRead: true})
@@ -312,38 +430,55 @@ func (s *Store) Close(ctx context.Context) error { return s.db.Close() }
 
 // Count implements part of the graphstore.Sharded interface.
 func (s *Store) Count(ctx context.Context, req *spb.CountRequest) (int64, error) {
-	if req.Shards < 1 {
-		return 0, fmt.Errorf("invalid number of shards: %d", req.Shards)
-	} else if req.Index < 0 || req.Index >= req.Shards {
-		return 0, fmt.Errorf("invalid index for %d shards: %d", req.Shards, req.Index)
+	if err := checkShard(req.Index, req.Shards); err != nil {
+		return 0, err
 	}
-
-	tbl, _, err := s.constructShards(req.Shards)
+	tbl, err := s.acquireShards(req.Shards)
 	if err != nil {
 		return 0, err
 	}
-	return tbl[req.Index].count, nil
+	defer s.releaseShards(tbl)
+	return tbl.shards[req.Index].count, nil
 }
 
-// Shard implements part of the graphstore.Sharded interface.
+// Shard implements part of the graphstore.Sharded interface.  If req has
+// explicit boundaries, as returned by Shards, the entries currently stored
+// within them are returned.  Otherwise, the shard is determined by a
+// partitioning of a snapshot of the store taken after its latest write.
 func (s *Store) Shard(ctx context.Context, req *spb.ShardRequest, f graphstore.EntryFunc) error {
-	if req.Shards < 1 {
-		return fmt.Errorf("invalid number of shards: %d", req.Shards)
-	} else if req.Index < 0 || req.Index >= req.Shards {
-		return fmt.Errorf("invalid index for %d shards: %d", req.Shards, req.Index)
+	if len(req.End) > 0 {
+		r := Range{Start: req.Start, End: req.End}
+		if bytes.Compare(r.Start, entryKeyPrefixBytes) < 0 {
+			r.Start = entryKeyPrefixBytes
+		}
+		if bytes.Compare(r.End, entryKeyPrefixEndRange) > 0 {
+			r.End = entryKeyPrefixEndRange
+		}
+		if bytes.Compare(r.Start, r.End) >= 0 {
+			return nil
+		}
+		iter, err := s.db.ScanRange(&r, &Options{LargeRead: true})
+		if err != nil {
+			return err
+		}
+		return streamEntries(iter, f)
 	}
 
-	tbl, snapshot, err := s.constructShards(req.Shards)
+	if err := checkShard(req.Index, req.Shards); err != nil {
+		return err
+	}
+	tbl, err := s.acquireShards(req.Shards)
 	if err != nil {
 		return err
 	}
-	if tbl[req.Index].count == 0 {
+	defer s.releaseShards(tbl)
+	shard := tbl.shards[req.Index]
+	if shard.count == 0 {
 		return nil
 	}
-	shard := tbl[req.Index]
 	iter, err := s.db.ScanRange(&shard.Range, &Options{
 		LargeRead: true,
-		Snapshot:  snapshot,
+		Snapshot:  tbl.snapshot,
 	})
 	if err != nil {
 		return err
@@ -351,39 +486,120 @@ func (s *Store) Shard(ctx context.Context, req *spb.ShardRequest, f graphstore.E
 	return streamEntries(iter, f)
 }
 
-func (s *Store) constructShards(num int64) ([]shard, Snapshot, error) {
+// Shards returns a ShardRequest with explicit boundaries for each of the given
+// number of shards.  Together, the requests partition the entire store: each
+// entry, including those written after Shards returns, is in exactly one
+// shard.  The requests may be distributed to independent readers of the store.
+func (s *Store) Shards(ctx context.Context, shards int64) ([]*spb.ShardRequest, error) {
+	if err := checkShard(0, shards); err != nil {
+		return nil, err
+	}
+	tbl, err := s.acquireShards(shards)
+	if err != nil {
+		return nil, err
+	}
+	defer s.releaseShards(tbl)
+	reqs := make([]*spb.ShardRequest, len(tbl.shards))
+	for i, shard := range tbl.shards {
+		reqs[i] = &spb.ShardRequest{
+			Index:  int64(i),
+			Shards: shards,
+			Start:  shard.Start,
+			End:    shard.End,
+		}
+	}
+	return reqs, nil
+}
+
+func checkShard(index, shards int64) error {
+	if shards < 1 {
+		return fmt.Errorf("invalid number of shards: %d", shards)
+	} else if index < 0 || index >= shards {
+		return fmt.Errorf("invalid index for %d shards: %d", shards, index)
+	}
+	return nil
+}
+
+// acquireShards returns the current partitioning of the store into num
+// shards, computing it if necessary.  The caller must release the table when
+// it is no longer in use.
+func (s *Store) acquireShards(num int64) (*shardTable, error) {
 	s.shardMu.Lock()
 	defer s.shardMu.Unlock()
-	if s.shardTables == nil {
-		s.shardTables = make(map[int64][]shard)
-		s.shardSnapshots = make(map[int64]Snapshot)
+	tbl, ok := s.shardTables[num]
+	if !ok {
+		var err error
+		tbl, err = s.constructShards(num)
+		if err != nil {
+			return nil, err
+		}
+		if s.shardTables == nil {
+			s.shardTables = make(map[int64]*shardTable)
+		}
+		s.shardTables[num] = tbl
 	}
-	if tbl, ok := s.shardTables[num]; ok {
-		return tbl, s.shardSnapshots[num], nil
+	tbl.refs++
+	return tbl, nil
+}
+
+// releaseShards releases a table returned by acquireShards, closing its
+// snapshot if it has been discarded and has no other users.
+func (s *Store) releaseShards(tbl *shardTable) {
+	s.shardMu.Lock()
+	defer s.shardMu.Unlock()
+	tbl.refs--
+	if tbl.stale && tbl.refs == 0 {
+		tbl.snapshot.Close()
 	}
+}
+
+// invalidateShards discards all current partitionings of the store, so that
+// later calls recompute them to include any new entries.
+func (s *Store) invalidateShards() {
+	s.shardMu.Lock()
+	defer s.shardMu.Unlock()
+	for _, tbl := range s.shardTables {
+		tbl.stale = true
+		if tbl.refs == 0 {
+			tbl.snapshot.Close()
+		}
+	}
+	s.shardTables = nil
+}
+
+// constructShards partitions the store into num shards.  The caller must hold
+// s.shardMu.
+func (s *Store) constructShards(num int64) (_ *shardTable, err error) {
 	snapshot := s.db.NewSnapshot()
-	iters := make([]Iterator, num)
-	for i := range iters {
-		var err error
-		iters[i], err = s.db.ScanPrefix(entryKeyPrefixBytes, &Options{
+	defer func() {
+		if err != nil {
+			snapshot.Close()
+		}
+	}()
+	scan := func() (Iterator, error) {
+		return s.db.ScanPrefix(entryKeyPrefixBytes, &Options{
 			LargeRead: true,
 			Snapshot:  snapshot,
 		})
+	}
+	iters := make([]Iterator, num)
+	for i := range iters {
+		iters[i], err = scan()
 		if err != nil {
-			snapshot.Close()
-			return nil, nil, fmt.Errorf("error creating iterator: %v", err)
+			closeAll(iters[:i])
+			return nil, fmt.Errorf("error creating iterator: %v", err)
 		}
 	}
+	defer closeAll(iters)
 
-	// This loop determines the ending key to each shard's range and the number of
-	// entries each iterator has passed.  Each iterator always represents the
-	// current ending key to each shard and is moved in (i+1) groups of entries
-	// where i is its index in iters/tbl.  If a group consisted of a single entry,
-	// this staggered iteration evenly distribute the iterators across the entire
-	// GraphStore.  However, this loop iterates past groups of entries sharing the
-	// same (source+edgeKind) at a time to ensure the property that no node/edge
-	// crosses a shard boundary.  This also means that the shards will be less
-	// evenly distributed.
+	// This loop determines the ending key to each shard's range.  Each iterator
+	// always represents the current ending key to each shard and is moved in
+	// (i+1) groups of entries where i is its index in iters/tbl.  If a group
+	// consisted of a single entry, this staggered iteration evenly distribute
+	// the iterators across the entire GraphStore.  However, this loop iterates
+	// past groups of entries sharing the same (source+edgeKind) at a time to
+	// ensure the property that no node/edge crosses a shard boundary.  This also
+	// means that the shards will be less evenly distributed.
 	tbl := make([]shard, num)
 loop:
 	for { // Until an iterator (usually iters[num-1]) reaches io.EOF
@@ -395,11 +611,9 @@ loop:
 				if err == io.EOF {
 					break loop
 				} else if err != nil {
-					snapshot.Close()
-					return nil, nil, err
+					return nil, err
 				}
 				prefix := sourceKindPrefix(k)
-				tbl[i].count++
 				tbl[i].End = k
 
 				// Iterate past all entries with the same source+kind prefix as k
@@ -408,10 +622,8 @@ loop:
 					if err == io.EOF {
 						break loop
 					} else if err != nil {
-						snapshot.Close()
-						return nil, nil, err
+						return nil, err
 					}
-					tbl[i].count++
 					tbl[i].End = k
 					if !bytes.HasPrefix(k, prefix) {
 						break
@@ -421,24 +633,47 @@ loop:
 		}
 	}
 
-	// Fix up the border shards
-	tbl[0].Start = entryKeyPrefixBytes
+	// Make the shards tile the entire key space: each starts where the previous
+	// one ends.  Shards whose iterator did not advance far enough (or at all)
+	// before the loop ended are left empty.
 	tbl[num-1].End = entryKeyPrefixEndRange
-	tbl[0].count--
-	tbl[num-1].count++
-
-	// Set the starting keys to each shard.
-	for i := int64(1); i < num; i++ {
-		tbl[i].Start = tbl[i-1].End
+	for i := range tbl {
+		if i == 0 {
+			tbl[i].Start = entryKeyPrefixBytes
+		} else {
+			tbl[i].Start = tbl[i-1].End
+		}
+		if bytes.Compare(tbl[i].End, tbl[i].Start) < 0 {
+			tbl[i].End = tbl[i].Start
+		}
 	}
+
 	// Determine the size of each shard.
-	for i := num - 1; i > 0; i-- {
-		tbl[i].count -= tbl[i-1].count
+	iter, err := scan()
+	if err != nil {
+		return nil, fmt.Errorf("error creating iterator: %v", err)
 	}
+	defer iter.Close()
+	for i := 0; ; {
+		k, _, err := iter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		for bytes.Compare(k, tbl[i].End) >= 0 {
+			i++
+		}
+		tbl[i].count++
+	}
+
+	return &shardTable{shards: tbl, snapshot: snapshot}, nil
+}
 
-	s.shardTables[num] = tbl
-	s.shardSnapshots[num] = snapshot
-	return tbl, snapshot, nil
+func closeAll(iters []Iterator) {
+	for _, it := range iters {
+		it.Close()
+	}
 }
 
 func sourceKindPrefix(key []byte) []byte {
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keyvalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// Unit Index Implementation Details:
+//   Alongside its entries, a Store records which units wrote each entry in two
+//   indices, both with empty values:
+//     "unit:<unit>_<entryKey>"   lists the entries written by a unit
+//     "owner:<entryKey>_<unit>"  lists the units that wrote an entry
+//   where:
+//     "_" == entryKeySep
+//     <entryKey> is the entry's key as encoded by EncodeKey
+//
+//   Encoded entry keys never contain entryKeySep after their target, and units
+//   may not contain it at all, so both encodings are unambiguous.  The indices
+//   are not updated by Write or the graphstore.Deleter methods: entries written
+//   outside of a unit may be removed when a unit that also wrote them is
+//   replaced.
+
+const (
+	unitKeyPrefix  = "unit:"
+	ownerKeyPrefix = "owner:"
+)
+
+func unitIndexPrefix(unit string) []byte {
+	return []byte(unitKeyPrefix + unit + entryKeySepStr)
+}
+
+func ownerIndexPrefix(entryKey []byte) []byte {
+	return []byte(ownerKeyPrefix + string(entryKey) + entryKeySepStr)
+}
+
+// ReplaceUnit implements part of the graphstore.UnitReplacer interface.  All
+// of the changes are applied in a single DB write.
+func (s *Store) ReplaceUnit(ctx context.Context, unit string, reqs <-chan *spb.WriteRequest) (err error) {
+	defer func() {
+		for range reqs {
+			// Drain reqs so that its producer is not blocked.
+		}
+	}()
+	if unit == "" {
+		return errors.New("missing unit")
+	} else if strings.Contains(unit, entryKeySepStr) {
+		return fmt.Errorf("invalid unit %q: contains key separator", unit)
+	}
+
+	// Encode every new entry before anything is changed, so that an invalid
+	// request leaves the store untouched.
+	type keyValue struct{ key, val []byte }
+	var written []keyValue
+	isWritten := make(map[string]bool)
+	for req := range reqs {
+		for _, update := range req.Update {
+			if update.FactName == "" {
+				return errors.New("invalid WriteRequest: Update missing FactName")
+			}
+			key, err := EncodeKey(req.Source, update.FactName, update.EdgeKind, update.Target)
+			if err != nil {
+				return fmt.Errorf("encoding error: %v", err)
+			}
+			written = append(written, keyValue{key, update.FactValue})
+			isWritten[string(key)] = true
+		}
+	}
+
+	s.unitMu.Lock()
+	defer s.unitMu.Unlock()
+	defer s.invalidateShards()
+
+	// Determine which of the unit's previous entries are no longer written by
+	// it and whether any other unit still writes them.
+	unitPrefix := unitIndexPrefix(unit)
+	iter, err := s.db.ScanPrefix(unitPrefix, nil)
+	if err != nil {
+		return fmt.Errorf("db seek error: %v", err)
+	}
+	var stale, orphaned [][]byte
+	for {
+		k, _, err := iter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			iter.Close()
+			return fmt.Errorf("db iteration error: %v", err)
+		}
+		key := k[len(unitPrefix):]
+		if isWritten[string(key)] {
+			continue
+		}
+		stale = append(stale, key)
+		if shared, err := s.hasOtherOwner(key, unit); err != nil {
+			iter.Close()
+			return err
+		} else if !shared {
+			orphaned = append(orphaned, key)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("db iterator close error: %v", err)
+	}
+
+	wr, err := s.db.Writer()
+	if err != nil {
+		return fmt.Errorf("db writer error: %v", err)
+	}
+	defer func() {
+		cErr := wr.Close()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("db writer close error: %v", cErr)
+		}
+	}()
+	for _, key := range stale {
+		if err := wr.Delete(append(unitIndexPrefix(unit), key...)); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		} else if err := wr.Delete(append(ownerIndexPrefix(key), unit...)); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		}
+	}
+	for _, key := range orphaned {
+		if err := wr.Delete(key); err != nil {
+			return fmt.Errorf("db delete error: %v", err)
+		}
+	}
+	for _, kv := range written {
+		if err := wr.Write(kv.key, kv.val); err != nil {
+			return fmt.Errorf("db write error: %v", err)
+		} else if err := wr.Write(append(unitIndexPrefix(unit), kv.key...), nil); err != nil {
+			return fmt.Errorf("db write error: %v", err)
+		} else if err := wr.Write(append(ownerIndexPrefix(kv.key), unit...), nil); err != nil {
+			return fmt.Errorf("db write error: %v", err)
+		}
+	}
+	return nil
+}
+
+// DeleteUnit implements part of the graphstore.UnitReplacer interface.
+func (s *Store) DeleteUnit(ctx context.Context, unit string) error {
+	reqs := make(chan *spb.WriteRequest)
+	close(reqs)
+	return s.ReplaceUnit(ctx, unit, reqs)
+}
+
+// hasOtherOwner reports whether any unit other than the given one has written
+// the entry with the given key.
+func (s *Store) hasOtherOwner(entryKey []byte, unit string) (bool, error) {
+	prefix := ownerIndexPrefix(entryKey)
+	iter, err := s.db.ScanPrefix(prefix, nil)
+	if err != nil {
+		return false, fmt.Errorf("db seek error: %v", err)
+	}
+	defer iter.Close()
+	for {
+		k, _, err := iter.Next()
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("db iteration error: %v", err)
+		} else if string(k[len(prefix):]) != unit {
+			return true, nil
+		}
+	}
+}
@@ -218,6 +218,12 @@ func (w *writer) Write(key, val []byte) error {
 	return nil
 }
 
+// Delete implements part of the keyvalue.Writer interface.
+func (w *writer) Delete(key []byte) error {
+	w.WriteBatch.Delete(key)
+	return nil
+}
+
 // Close implements part of the keyvalue.Writer interface.
 func (w *writer) Close() error {
 	if err := w.s.db.Write(w.s.writeOpts, w.WriteBatch); err != nil {
@@ -227,6 +233,12 @@ func (w *writer) Close() error {
 	return nil
 }
 
+// Discard implements part of the keyvalue.Discarder interface.
+func (w *writer) Discard() error {
+	w.WriteBatch.Close()
+	return nil
+}
+
 type iterator struct {
 	it   *levigo.Iterator
 	opts *levigo.ReadOptions
@@ -77,6 +77,13 @@ func BenchmarkGSWriteBatchLrg(b *testing.B) {
 	graphstore.BatchWriteBenchmark(b, tempGS, largeBatchSize)
 }
 
+func TestReadWrite(t *testing.T) { keyvalue.ReadWriteTest(t, tempDB) }
+func TestScan(t *testing.T)      { keyvalue.ScanTest(t, tempDB) }
+func TestSnapshot(t *testing.T)  { keyvalue.SnapshotTest(t, tempDB) }
+func TestShard(t *testing.T)     { keyvalue.ShardTest(t, tempDB) }
+func TestDelete(t *testing.T)    { keyvalue.DeleteTest(t, tempDB) }
+func TestUnit(t *testing.T)      { keyvalue.UnitTest(t, tempDB) }
+
 func TestOrder(t *testing.T) {
 	graphstore.OrderTest(t, tempGS, largeBatchSize)
 }
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+
+	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/util/riegeli"
+
+	"github.com/golang/protobuf/proto"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// Format is the encoding of an entry stream.
+type Format int
+
+// Entry stream formats detected by DetectFormat.
+const (
+	// DelimitedFormat is a stream of varint-delimited Entry protobufs.
+	DelimitedFormat Format = iota
+
+	// JSONFormat is a stream of JSON-encoded Entry messages.
+	JSONFormat
+
+	// RiegeliFormat is a Riegeli file of Entry protobuf records.
+	RiegeliFormat
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case DelimitedFormat:
+		return "delimited"
+	case JSONFormat:
+		return "json"
+	case RiegeliFormat:
+		return "riegeli"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// sniffSize is the maximum number of bytes inspected to detect a stream's
+// format.
+const sniffSize = 16 << 10
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// DetectFormat determines the format of the entry stream in r from its first
+// bytes.  The returned io.Reader reads the whole stream, decompressing it if
+// it is gzip-compressed.
+func DetectFormat(r io.Reader) (Format, io.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffSize)
+	if prefix, _ := br.Peek(len(gzipMagic)); bytes.Equal(prefix, gzipMagic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading gzip header: %v", err)
+		}
+		br = bufio.NewReaderSize(gz, sniffSize)
+	}
+
+	prefix, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return 0, nil, fmt.Errorf("error reading stream: %v", err)
+	}
+	switch {
+	case riegeli.HasSignature(prefix):
+		return RiegeliFormat, br, nil
+	case isJSON(prefix):
+		return JSONFormat, br, nil
+	default:
+		return DelimitedFormat, br, nil
+	}
+}
+
+// isJSON reports whether the given stream prefix begins with a JSON-encoded
+// Entry.  A delimited stream may also begin with '{' (the length of its first
+// record) followed by arbitrary bytes, so the guess is confirmed by decoding
+// the first JSON object.  If the prefix is too short to hold that object, the
+// stream is only considered JSON if its prefix does not begin with a valid
+// delimited Entry.
+func isJSON(prefix []byte) bool {
+	trimmed := bytes.TrimLeft(prefix, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return false
+	}
+	var entry spb.Entry
+	if err := json.NewDecoder(bytes.NewReader(trimmed)).Decode(&entry); err == nil {
+		return true
+	} else if err != io.ErrUnexpectedEOF {
+		return false
+	}
+	return !isDelimitedEntry(prefix)
+}
+
+// isDelimitedEntry reports whether the given stream prefix begins with a
+// complete varint-delimited Entry protobuf.
+func isDelimitedEntry(prefix []byte) bool {
+	size, n := binary.Uvarint(prefix)
+	if n <= 0 || uint64(len(prefix)-n) < size {
+		return false
+	}
+	var entry spb.Entry
+	return proto.Unmarshal(prefix[n:n+int(size)], &entry) == nil
+}
+
+// NewReader reads a stream of entries from r.  The stream may be in any Format
+// and may be gzip-compressed; the format is detected from the stream's first
+// bytes.
+func NewReader(r io.Reader) EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		format, r, err := DetectFormat(r)
+		if err != nil {
+			return err
+		}
+		switch format {
+		case JSONFormat:
+			return NewJSONReader(r)(f)
+		case RiegeliFormat:
+			return NewRiegeliReader(r)(f)
+		default:
+			return NewDelimitedReader(r)(f)
+		}
+	}
+}
+
+// NewDelimitedReader reads a stream of delimited Entry protobufs from r.
+func NewDelimitedReader(r io.Reader) EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		rd := delimited.NewReader(r)
+		for {
+			var entry spb.Entry
+			if err := rd.NextProto(&entry); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("error decoding Entry: %v", err)
+			}
+			if err := f(&entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// NewRiegeliReader reads a Riegeli file of Entry protobufs from r.
+func NewRiegeliReader(r io.Reader) EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		rd := riegeli.NewReaderWithOptions(r, &riegeli.ReaderOptions{Parallelism: runtime.NumCPU()})
+		for {
+			var entry spb.Entry
+			if err := rd.NextProto(&entry); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("error decoding Riegeli Entry: %v", err)
+			}
+			if err := f(&entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// NewRecordReader returns a delimited.Source of the serialized records of the
+// stream in r.  The stream may be in any format accepted by NewReader.  Records
+// of delimited and Riegeli streams are returned unchanged; JSON entries are
+// returned as serialized Entry protobufs.
+func NewRecordReader(r io.Reader) (delimited.Source, error) {
+	format, r, err := DetectFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case JSONFormat:
+		return &jsonRecordReader{json.NewDecoder(r)}, nil
+	case RiegeliFormat:
+		return riegeli.NewReaderWithOptions(r, &riegeli.ReaderOptions{Parallelism: runtime.NumCPU()}), nil
+	default:
+		return delimited.NewReader(r), nil
+	}
+}
+
+// A jsonRecordReader serializes the entries of a JSON stream.
+type jsonRecordReader struct{ de *json.Decoder }
+
+// Next implements the delimited.Source interface.
+func (j *jsonRecordReader) Next() ([]byte, error) {
+	var entry spb.Entry
+	if err := j.de.Decode(&entry); err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("error decoding JSON Entry: %v", err)
+	}
+	return proto.Marshal(&entry)
+}
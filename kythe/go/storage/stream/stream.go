@@ -24,7 +24,6 @@ import (
 	"io"
 	"log"
 
-	"kythe.io/kythe/go/platform/delimited"
 	"kythe.io/kythe/go/util/schema/facts"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -38,7 +37,8 @@ import (
 // function.
 type EntryReader func(func(*spb.Entry) error) error
 
-// ReadEntries reads a stream of Entry protobufs from r.
+// ReadEntries reads a stream of entries from r in any format accepted by
+// NewReader.
 func ReadEntries(r io.Reader) <-chan *spb.Entry {
 	ch := make(chan *spb.Entry)
 	go func() {
@@ -53,24 +53,6 @@ func ReadEntries(r io.Reader) <-chan *spb.Entry {
 	return ch
 }
 
-// NewReader reads a stream of Entry protobufs from r.
-func NewReader(r io.Reader) EntryReader {
-	return func(f func(*spb.Entry) error) error {
-		rd := delimited.NewReader(r)
-		for {
-			var entry spb.Entry
-			if err := rd.NextProto(&entry); err == io.EOF {
-				return nil
-			} else if err != nil {
-				return fmt.Errorf("error decoding Entry: %v", err)
-			}
-			if err := f((*spb.Entry)(&entry)); err != nil {
-				return err
-			}
-		}
-	}
-}
-
 // ReadJSONEntries reads a JSON stream of Entry protobufs from r.
 func ReadJSONEntries(r io.Reader) <-chan *spb.Entry {
 	ch := make(chan *spb.Entry)
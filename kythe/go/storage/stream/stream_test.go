@@ -18,12 +18,16 @@ package stream
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"kythe.io/kythe/go/platform/delimited"
 	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/riegeli"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
@@ -70,6 +74,120 @@ func TestJSONReader(t *testing.T) {
 	}
 }
 
+func TestReaderFormats(t *testing.T) {
+	for _, test := range []struct {
+		format Format
+		buf    *bytes.Buffer
+	}{
+		{DelimitedFormat, testBuffer(testEntries)},
+		{JSONFormat, testJSONBuffer(testEntries)},
+		{RiegeliFormat, testRiegeliBuffer(testEntries)},
+	} {
+		for _, compressed := range []bool{false, true} {
+			name := test.format.String()
+			data := test.buf.Bytes()
+			if compressed {
+				name += ".gz"
+				data = gzipBytes(data)
+			}
+			t.Run(name, func(t *testing.T) {
+				if format, _, err := DetectFormat(bytes.NewReader(data)); err != nil {
+					t.Fatalf("DetectFormat error: %v", err)
+				} else if format != test.format {
+					t.Errorf("DetectFormat: found %v; expected %v", format, test.format)
+				}
+
+				var i int
+				if err := NewReader(bytes.NewReader(data))(func(e *spb.Entry) error {
+					if i >= len(testEntries) {
+						return fmt.Errorf("unexpected entry: %v", e)
+					} else if err := testutil.DeepEqual(testEntries[i], e); err != nil {
+						t.Errorf("testEntries[%d]: %v", i, err)
+					}
+					i++
+					return nil
+				}); err != nil {
+					t.Fatal(err)
+				} else if i != len(testEntries) {
+					t.Fatalf("Missing %d entries", len(testEntries)-i)
+				}
+
+				src, err := NewRecordReader(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("NewRecordReader error: %v", err)
+				}
+				for i := 0; ; i++ {
+					rec, err := src.Next()
+					if err == io.EOF {
+						if i != len(testEntries) {
+							t.Errorf("Missing %d records", len(testEntries)-i)
+						}
+						break
+					} else if err != nil {
+						t.Fatalf("Record error: %v", err)
+					}
+					var e spb.Entry
+					if err := proto.Unmarshal(rec, &e); err != nil {
+						t.Fatalf("Error unmarshaling record: %v", err)
+					} else if err := testutil.DeepEqual(testEntries[i], &e); err != nil {
+						t.Errorf("testEntries[%d]: %v", i, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDetectDelimitedBrace(t *testing.T) {
+	// An entry encoded in exactly '{' bytes.
+	e := fact("node", "fact", "")
+	for size := proto.Size(e); size < '{'; size = proto.Size(e) {
+		e.FactValue = append(e.FactValue, 'x')
+	}
+	buf := testBuffer([]*spb.Entry{e})
+	if b := buf.Bytes()[0]; b != '{' {
+		t.Fatalf("Bad test entry size: %q", b)
+	}
+
+	if format, _, err := DetectFormat(buf); err != nil {
+		t.Fatalf("DetectFormat error: %v", err)
+	} else if format != DelimitedFormat {
+		t.Errorf("DetectFormat: found %v; expected %v", format, DelimitedFormat)
+	}
+}
+
+func TestDetectDelimitedBraceQuote(t *testing.T) {
+	// An entry encoded in exactly '{' bytes whose source VName is encoded in
+	// exactly '"' bytes; the stream begins with "{\n\"".
+	e := &spb.Entry{
+		Source:   &spb.VName{Signature: strings.Repeat("s", '"'-2)},
+		FactName: "fact",
+	}
+	for size := proto.Size(e); size < '{'; size = proto.Size(e) {
+		e.FactValue = append(e.FactValue, 'x')
+	}
+	data := testBuffer([]*spb.Entry{e}).Bytes()
+	if prefix := data[:3]; !bytes.Equal(prefix, []byte{0x7b, 0x0a, 0x22}) {
+		t.Fatalf("Bad test entry encoding: %q", prefix)
+	}
+
+	if format, _, err := DetectFormat(bytes.NewReader(data)); err != nil {
+		t.Fatalf("DetectFormat error: %v", err)
+	} else if format != DelimitedFormat {
+		t.Errorf("DetectFormat: found %v; expected %v", format, DelimitedFormat)
+	}
+
+	var found []*spb.Entry
+	if err := NewReader(bytes.NewReader(data))(func(e *spb.Entry) error {
+		found = append(found, e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if err := testutil.DeepEqual([]*spb.Entry{e}, found); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestStructuredEntry(t *testing.T) {
 	ms := &cpb.MarkedSource{PreText: "hi"}
 	pbms, err := proto.Marshal(ms)
@@ -155,6 +273,31 @@ func testBuffer(entries []*spb.Entry) *bytes.Buffer {
 	return buf
 }
 
+func testRiegeliBuffer(entries []*spb.Entry) *bytes.Buffer {
+	buf := bytes.NewBuffer(nil)
+	wr := riegeli.NewWriter(buf, nil)
+	for _, e := range entries {
+		if err := wr.PutProto(e); err != nil {
+			panic(err)
+		}
+	}
+	if err := wr.Close(); err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+func gzipBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		panic(err)
+	} else if err := gz.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
 func testJSONBuffer(entries []*spb.Entry) *bytes.Buffer {
 	buf := bytes.NewBuffer(nil)
 	wr := json.NewEncoder(buf)
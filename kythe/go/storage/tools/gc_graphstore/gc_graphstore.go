@@ -0,0 +1,83 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Binary gc_graphstore removes dangling edges from a GraphStore: edges whose
+// source or target node has no facts.  With --dry_run, the edges are instead
+// emitted to stdout as a delimited entry stream.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/storage/gc"
+	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/util/flagutil"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+
+	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/storage/goleveldb"
+	_ "kythe.io/kythe/go/storage/leveldb"
+)
+
+var (
+	gs graphstore.Service
+
+	dryRun = flag.Bool("dry_run", false, "Emit the dangling edges to stdout instead of removing them")
+)
+
+func init() {
+	gsutil.Flag(&gs, "graphstore", "GraphStore to garbage-collect")
+	flag.Usage = flagutil.SimpleUsage("Removes edges to or from nodes without facts in a GraphStore",
+		"--graphstore spec [--dry_run]")
+}
+
+func main() {
+	flag.Parse()
+	if gs == nil {
+		flagutil.UsageError("missing --graphstore")
+	} else if len(flag.Args()) > 0 {
+		flagutil.UsageErrorf("unknown arguments: %v", flag.Args())
+	}
+	defer gsutil.LogClose(context.Background(), gs)
+	gsutil.EnsureGracefulExit(gs)
+
+	ctx := context.Background()
+	if *dryRun {
+		wr := delimited.NewWriter(os.Stdout)
+		if err := gc.Find(ctx, gs, func(e *spb.Entry) error {
+			return wr.PutProto(e)
+		}); err != nil {
+			log.Fatalf("Error scanning GraphStore: %v", err)
+		}
+		return
+	}
+
+	d, ok := gs.(graphstore.Deleter)
+	if !ok {
+		log.Fatalf("GraphStore does not support deletion: %T", gs)
+	}
+	n, err := gc.Collect(ctx, d)
+	log.Printf("Removed %d dangling edges", n)
+	if err != nil {
+		log.Fatalf("Error removing dangling edges: %v", err)
+	}
+}
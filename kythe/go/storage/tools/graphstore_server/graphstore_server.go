@@ -0,0 +1,69 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Binary graphstore_server exposes a GraphStore as a gRPC service, so that it
+// may be shared by tools given a "grpc:host:port" GraphStore specification.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/services/graphstore/remote"
+	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/util/flagutil"
+
+	"google.golang.org/grpc"
+
+	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/storage/goleveldb"
+	_ "kythe.io/kythe/go/storage/leveldb"
+)
+
+var (
+	gs graphstore.Service
+
+	listen = flag.String("listen", "localhost:8080", "Address on which to serve the GraphStore")
+)
+
+func init() {
+	gsutil.Flag(&gs, "graphstore", "GraphStore to serve")
+	flag.Usage = flagutil.SimpleUsage("Serves a GraphStore over gRPC",
+		"--graphstore spec [--listen addr]")
+}
+
+func main() {
+	flag.Parse()
+	if gs == nil {
+		flagutil.UsageError("missing --graphstore")
+	} else if len(flag.Args()) > 0 {
+		flagutil.UsageErrorf("unknown arguments: %v", flag.Args())
+	}
+	gsutil.EnsureGracefulExit(gs)
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatalf("Error listening on %q: %v", *listen, err)
+	}
+	srv := grpc.NewServer()
+	remote.Register(srv, gs)
+	log.Printf("GraphStore server listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("Error serving GraphStore: %v", err)
+	}
+}
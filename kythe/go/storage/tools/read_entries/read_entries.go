@@ -36,6 +36,8 @@ import (
 	spb "kythe.io/kythe/proto/storage_go_proto"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/services/graphstore/remote"
+	_ "kythe.io/kythe/go/storage/goleveldb"
 	_ "kythe.io/kythe/go/storage/leveldb"
 )
 
@@ -114,27 +116,38 @@ func main() {
 		fmt.Println(cnt)
 		return
 	} else if *shardsToFiles != "" {
+		reqs := make([]*spb.ShardRequest, *shards)
+		if p, ok := sgs.(graphstore.Partitioner); ok {
+			// Use a fixed partitioning so that concurrent writes cannot cause an
+			// entry to be missed or emitted twice.
+			var err error
+			reqs, err = p.Shards(ctx, *shards)
+			if err != nil {
+				log.Fatalf("GraphStore partitioning error: %v", err)
+			}
+		} else {
+			for i := range reqs {
+				reqs[i] = &spb.ShardRequest{Index: int64(i), Shards: *shards}
+			}
+		}
 		var wg sync.WaitGroup
-		wg.Add(int(*shards))
-		for i := int64(0); i < *shards; i++ {
-			go func(i int64) {
+		wg.Add(len(reqs))
+		for _, req := range reqs {
+			go func(req *spb.ShardRequest) {
 				defer wg.Done()
-				path := fmt.Sprintf("%s-%.5d-of-%.5d", *shardsToFiles, i, *shards)
+				path := fmt.Sprintf("%s-%.5d-of-%.5d", *shardsToFiles, req.Index, *shards)
 				f, err := vfs.Create(ctx, path)
 				if err != nil {
 					log.Fatalf("Failed to create file %q: %v", path, err)
 				}
 				defer f.Close()
 				wr := delimited.NewWriter(f)
-				if err := sgs.Shard(ctx, &spb.ShardRequest{
-					Index:  i,
-					Shards: *shards,
-				}, func(entry *spb.Entry) error {
+				if err := sgs.Shard(ctx, req, func(entry *spb.Entry) error {
 					return wr.PutProto(entry)
 				}); err != nil {
 					log.Fatalf("GraphStore shard scan error: %v", err)
 				}
-			}(i)
+			}(req)
 		}
 		wg.Wait()
 		return
@@ -15,6 +15,7 @@
  */
 
 // Binary triples implements a converter from an Entry stream to a stream of triples.
+// The Entry stream may be delimited, JSON or Riegeli encoded and may be gzip-compressed.
 //
 // Examples:
 //   triples < entries > triples.nq
@@ -47,6 +48,8 @@ import (
 	spb "kythe.io/kythe/proto/storage_go_proto"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/services/graphstore/remote"
+	_ "kythe.io/kythe/go/storage/goleveldb"
 	_ "kythe.io/kythe/go/storage/leveldb"
 )
 
@@ -28,6 +28,10 @@
 //
 // Example:
 //   zcat entries.gz | write_entries --graphstore gs/leveldb
+//
+// Example:
+//   # Replace the entries last written for a re-analyzed compilation unit.
+//   zcat unit.entries.gz | write_entries --unit $UNIT_DIGEST --graphstore gs/leveldb
 package main
 
 import (
@@ -47,19 +51,22 @@ import (
 	spb "kythe.io/kythe/proto/storage_go_proto"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
+	_ "kythe.io/kythe/go/services/graphstore/remote"
+	_ "kythe.io/kythe/go/storage/goleveldb"
 	_ "kythe.io/kythe/go/storage/leveldb"
 )
 
 var (
 	batchSize  = flag.Int("batch_size", 1024, "Maximum entries per write for consecutive entries with the same source")
 	numWorkers = flag.Int("workers", 1, "Number of concurrent workers writing to the GraphStore")
+	unit       = flag.String("unit", "", "If given, atomically replace the entries last written for this unit (e.g. a compilation unit digest) with the entry stream")
 
 	gs graphstore.Service
 )
 
 func init() {
-	flag.Usage = flagutil.SimpleUsage("Write a delimited stream of entries from stdin to a GraphStore",
-		"[--batch_size entries] [--workers n] --graphstore spec")
+	flag.Usage = flagutil.SimpleUsage("Write a stream of entries from stdin to a GraphStore (delimited, JSON or Riegeli; optionally gzipped)",
+		"[--batch_size entries] [--workers n | --unit id] --graphstore spec")
 	gsutil.Flag(&gs, "graphstore", "GraphStore to which to write the entry stream")
 }
 
@@ -71,6 +78,8 @@ func main() {
 		flagutil.UsageErrorf("Invalid number of --workers %d (must be ≥ 1)", *numWorkers)
 	} else if *batchSize < 1 {
 		flagutil.UsageErrorf("Invalid --batch_size %d (must be ≥ 1)", *batchSize)
+	} else if *unit != "" && *numWorkers > 1 {
+		flagutil.UsageError("--unit and --workers are mutually exclusive")
 	} else if gs == nil {
 		flagutil.UsageError("Missing --graphstore")
 	}
@@ -87,6 +96,18 @@ func main() {
 
 	writes := graphstore.BatchWrites(stream.ReadEntries(os.Stdin), *batchSize)
 
+	if *unit != "" {
+		r, ok := gs.(graphstore.UnitReplacer)
+		if !ok {
+			log.Fatalf("GraphStore does not support replacing units: %T", gs)
+		}
+		if err := r.ReplaceUnit(ctx, *unit, writes); err != nil {
+			log.Fatalf("Error replacing unit %q: %v", *unit, err)
+		}
+		log.Printf("Replaced entries for unit %q", *unit)
+		return
+	}
+
 	var (
 		wg         sync.WaitGroup
 		numEntries uint64
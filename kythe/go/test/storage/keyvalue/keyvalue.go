@@ -18,10 +18,15 @@
 package keyvalue
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"testing"
 
 	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/test/testutil"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
 )
 
 // DB re-exports keyvalue.DB for tests
@@ -96,3 +101,373 @@ func BatchWriteParallelBenchmark(b *testing.B, create CreateFunc, batchSize int)
 		}
 	})
 }
+
+// ReadWriteTest tests the Get method of the given keyvalue.DB after a sequence
+// of batched writes and deletions.
+func ReadWriteTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+
+	writeKeys(t, db, map[string]string{"a": "1", "b": "2", "c": "3"}, nil)
+	writeKeys(t, db, map[string]string{"b": "4"}, []string{"c", "nonexistent"})
+
+	for key, want := range map[string]string{"a": "1", "b": "4"} {
+		if got, err := db.Get([]byte(key), nil); err != nil {
+			t.Errorf("Get(%q) error: %v", key, err)
+		} else if string(got) != want {
+			t.Errorf("Get(%q): got %q, want %q", key, got, want)
+		}
+	}
+	for _, key := range []string{"c", "d"} {
+		if got, err := db.Get([]byte(key), nil); err != io.EOF {
+			t.Errorf("Get(%q): got (%q, %v), want io.EOF", key, got, err)
+		}
+	}
+}
+
+// ScanTest tests the ScanPrefix and ScanRange methods of the given
+// keyvalue.DB.
+func ScanTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+
+	writeKeys(t, db, map[string]string{
+		"a": "0", "ab": "1", "abc": "2", "abd": "3", "b": "4", "ba": "5",
+	}, nil)
+
+	tests := []struct {
+		desc string
+		scan func() (keyvalue.Iterator, error)
+		want []string
+	}{
+		{"prefix a", func() (keyvalue.Iterator, error) { return db.ScanPrefix([]byte("a"), nil) },
+			[]string{"a=0", "ab=1", "abc=2", "abd=3"}},
+		{"prefix ab", func() (keyvalue.Iterator, error) { return db.ScanPrefix([]byte("ab"), nil) },
+			[]string{"ab=1", "abc=2", "abd=3"}},
+		{"prefix c", func() (keyvalue.Iterator, error) { return db.ScanPrefix([]byte("c"), nil) }, nil},
+		{"empty prefix", func() (keyvalue.Iterator, error) { return db.ScanPrefix(nil, &keyvalue.Options{LargeRead: true}) },
+			[]string{"a=0", "ab=1", "abc=2", "abd=3", "b=4", "ba=5"}},
+		{"range [ab, b)", func() (keyvalue.Iterator, error) {
+			return db.ScanRange(&keyvalue.Range{Start: []byte("ab"), End: []byte("b")}, nil)
+		}, []string{"ab=1", "abc=2", "abd=3"}},
+		{"range [aa, ba]", func() (keyvalue.Iterator, error) {
+			return db.ScanRange(&keyvalue.Range{Start: []byte("aa"), End: []byte("ba\x00")}, nil)
+		}, []string{"ab=1", "abc=2", "abd=3", "b=4", "ba=5"}},
+		{"range [c, d)", func() (keyvalue.Iterator, error) {
+			return db.ScanRange(&keyvalue.Range{Start: []byte("c"), End: []byte("d")}, nil)
+		}, nil},
+	}
+	for _, test := range tests {
+		it, err := test.scan()
+		if err != nil {
+			t.Errorf("Scan %s error: %v", test.desc, err)
+			continue
+		}
+		if err := testutil.DeepEqual(test.want, readAll(t, it)); err != nil {
+			t.Errorf("Scan %s: %v", test.desc, err)
+		}
+	}
+}
+
+// SnapshotTest tests that reads from a snapshot of the given keyvalue.DB are
+// unaffected by later writes.
+func SnapshotTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+
+	writeKeys(t, db, map[string]string{"a": "1", "b": "2"}, nil)
+	snap := db.NewSnapshot()
+	defer func() { testutil.FatalOnErrT(t, "snapshot close error: %v", snap.Close()) }()
+	writeKeys(t, db, map[string]string{"a": "3", "c": "4"}, []string{"b"})
+
+	opts := &keyvalue.Options{Snapshot: snap}
+	if got, err := db.Get([]byte("a"), opts); err != nil || string(got) != "1" {
+		t.Errorf("Get(a) from snapshot: got (%q, %v), want %q", got, err, "1")
+	}
+	if got, err := db.Get([]byte("c"), opts); err != io.EOF {
+		t.Errorf("Get(c) from snapshot: got (%q, %v), want io.EOF", got, err)
+	}
+	it, err := db.ScanPrefix(nil, opts)
+	testutil.FatalOnErrT(t, "ScanPrefix error: %v", err)
+	if err := testutil.DeepEqual([]string{"a=1", "b=2"}, readAll(t, it)); err != nil {
+		t.Errorf("ScanPrefix from snapshot: %v", err)
+	}
+	it, err = db.ScanRange(&keyvalue.Range{Start: []byte("b"), End: []byte("d")}, opts)
+	testutil.FatalOnErrT(t, "ScanRange error: %v", err)
+	if err := testutil.DeepEqual([]string{"b=2"}, readAll(t, it)); err != nil {
+		t.Errorf("ScanRange from snapshot: %v", err)
+	}
+
+	// Reads without the snapshot see the latest writes.
+	it, err = db.ScanPrefix(nil, nil)
+	testutil.FatalOnErrT(t, "ScanPrefix error: %v", err)
+	if err := testutil.DeepEqual([]string{"a=3", "c=4"}, readAll(t, it)); err != nil {
+		t.Errorf("ScanPrefix: %v", err)
+	}
+}
+
+// ShardTest tests that the sharding of a keyvalue GraphStore over the given
+// keyvalue.DB reflects writes made after the shards are first computed, and
+// that explicit shard boundaries partition the store even as it changes.
+func ShardTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+	gs := keyvalue.NewGraphStore(db)
+	ctx := context.Background()
+
+	const shards = 3
+	writeFacts(ctx, t, gs, 0, 20)
+	if got := countAll(ctx, t, gs, shards); got != 20 {
+		t.Errorf("Total count: got %d; want %d", got, 20)
+	}
+
+	reqs, err := gs.Shards(ctx, shards)
+	testutil.FatalOnErrT(t, "Shards error: %v", err)
+	if len(reqs) != shards {
+		t.Fatalf("Shards: got %d requests; want %d", len(reqs), shards)
+	}
+	writeFacts(ctx, t, gs, 20, 30)
+
+	if got := countAll(ctx, t, gs, shards); got != 30 {
+		t.Errorf("Total count after write: got %d; want %d", got, 30)
+	}
+	var implicit []*spb.ShardRequest
+	for i := int64(0); i < shards; i++ {
+		implicit = append(implicit, &spb.ShardRequest{Index: i, Shards: shards})
+	}
+	for _, reqs := range [][]*spb.ShardRequest{reqs, implicit} {
+		seen := make(map[string]int)
+		for _, req := range reqs {
+			testutil.FatalOnErrT(t, "Shard error: %v", gs.Shard(ctx, req, func(e *spb.Entry) error {
+				seen[e.Source.Signature]++
+				return nil
+			}))
+		}
+		if len(seen) != 30 {
+			t.Errorf("Shards %v: got %d distinct entries; want %d", reqs, len(seen), 30)
+		}
+		for sig, n := range seen {
+			if n != 1 {
+				t.Errorf("Shards %v: entry %q found %d times", reqs, sig, n)
+			}
+		}
+	}
+}
+
+// DeleteTest tests the graphstore.Deleter methods of a keyvalue GraphStore over
+// the given keyvalue.DB.
+func DeleteTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+	gs := keyvalue.NewGraphStore(db)
+	ctx := context.Background()
+
+	file := func(path string) *spb.VName { return &spb.VName{Corpus: "c", Path: path} }
+	anchor := func(path, sig string) *spb.VName { return &spb.VName{Signature: sig, Corpus: "c", Path: path} }
+	for _, v := range []*spb.VName{file("a"), anchor("a", "@1"), anchor("a", "@2"), file("b"), anchor("b", "@1")} {
+		testutil.FatalOnErrT(t, "write error: %v", gs.Write(ctx, &spb.WriteRequest{
+			Source: v,
+			Update: []*spb.WriteRequest_Update{
+				{FactName: "/kythe/node/kind", FactValue: []byte("test")},
+				{EdgeKind: "/kythe/edge/childof", Target: file(v.Path), FactName: "/"},
+			},
+		}))
+	}
+
+	testutil.FatalOnErrT(t, "Delete error: %v", gs.Delete(ctx, &spb.WriteRequest{
+		Source: anchor("b", "@1"),
+		Update: []*spb.WriteRequest_Update{
+			{EdgeKind: "/kythe/edge/childof", Target: file("b"), FactName: "/"},
+			{FactName: "/kythe/missing"},
+		},
+	}))
+	if got, want := scanSources(ctx, t, gs), []string{
+		"@1 a /kythe/edge/childof", "@1 a /kythe/node/kind",
+		"@1 b /kythe/node/kind",
+		"@2 a /kythe/edge/childof", "@2 a /kythe/node/kind",
+		" a /kythe/edge/childof", " a /kythe/node/kind",
+		" b /kythe/edge/childof", " b /kythe/node/kind",
+	}; !sameSet(got, want) {
+		t.Errorf("Entries after Delete: got %v; want %v", got, want)
+	}
+
+	testutil.FatalOnErrT(t, "DeletePrefix error: %v", gs.DeletePrefix(ctx, &spb.VName{Corpus: "c", Path: "a"}))
+	if got, want := scanSources(ctx, t, gs), []string{
+		"@1 b /kythe/node/kind",
+		" b /kythe/edge/childof", " b /kythe/node/kind",
+	}; !sameSet(got, want) {
+		t.Errorf("Entries after DeletePrefix: got %v; want %v", got, want)
+	}
+
+	testutil.FatalOnErrT(t, "DeletePrefix error: %v", gs.DeletePrefix(ctx, &spb.VName{Signature: "@"}))
+	if got, want := scanSources(ctx, t, gs), []string{
+		" b /kythe/edge/childof", " b /kythe/node/kind",
+	}; !sameSet(got, want) {
+		t.Errorf("Entries after DeletePrefix: got %v; want %v", got, want)
+	}
+	if got := countAll(ctx, t, gs, 1); got != 2 {
+		t.Errorf("Count after deletions: got %d; want %d", got, 2)
+	}
+}
+
+// UnitTest tests the graphstore.UnitReplacer methods of a keyvalue GraphStore
+// over the given keyvalue.DB.
+func UnitTest(t *testing.T, create CreateFunc) {
+	db, destroy := mustCreate(t, create)
+	defer destroy()
+	gs := keyvalue.NewGraphStore(db)
+	ctx := context.Background()
+
+	node := func(sig string) *spb.VName { return &spb.VName{Signature: sig} }
+	fact := func(sig, val string) *spb.WriteRequest {
+		return &spb.WriteRequest{
+			Source: node(sig),
+			Update: []*spb.WriteRequest_Update{{FactName: "/kythe/text", FactValue: []byte(val)}},
+		}
+	}
+	edge := &spb.WriteRequest{
+		Source: node("x"),
+		Update: []*spb.WriteRequest_Update{{EdgeKind: "/kythe/edge/ref", Target: node("y"), FactName: "/"}},
+	}
+	replace := func(unit string, reqs ...*spb.WriteRequest) error {
+		ch := make(chan *spb.WriteRequest)
+		go func() {
+			defer close(ch)
+			for _, req := range reqs {
+				ch <- req
+			}
+		}()
+		return gs.ReplaceUnit(ctx, unit, ch)
+	}
+	check := func(desc string, want ...string) {
+		t.Helper()
+		var got []string
+		testutil.FatalOnErrT(t, "Scan error: %v", gs.Scan(ctx, new(spb.ScanRequest), func(e *spb.Entry) error {
+			got = append(got, fmt.Sprintf("%s %s%s=%s", e.Source.Signature, e.EdgeKind, e.FactName, e.FactValue))
+			return nil
+		}))
+		if err := testutil.DeepEqual(want, got); err != nil {
+			t.Errorf("Entries after %s: %v", desc, err)
+		}
+	}
+
+	testutil.FatalOnErrT(t, "ReplaceUnit error: %v", replace("A", fact("x", "1"), edge, fact("y", "1")))
+	testutil.FatalOnErrT(t, "ReplaceUnit error: %v", replace("B", fact("y", "1"), fact("z", "1")))
+	check("writing units", "x /kythe/text=1", "x /kythe/edge/ref/=", "y /kythe/text=1", "z /kythe/text=1")
+
+	testutil.FatalOnErrT(t, "ReplaceUnit error: %v", replace("A", fact("x", "2"), fact("w", "2")))
+	check("replacing A", "w /kythe/text=2", "x /kythe/text=2", "y /kythe/text=1", "z /kythe/text=1")
+
+	// Invalid replacements leave the store untouched.
+	if err := replace("A", &spb.WriteRequest{Source: node("v"), Update: []*spb.WriteRequest_Update{{}}}); err == nil {
+		t.Error("ReplaceUnit with invalid update: unexpected success")
+	}
+	if err := replace("bad\nunit", fact("v", "1")); err == nil {
+		t.Error("ReplaceUnit with invalid unit: unexpected success")
+	}
+	check("invalid replacements", "w /kythe/text=2", "x /kythe/text=2", "y /kythe/text=1", "z /kythe/text=1")
+
+	testutil.FatalOnErrT(t, "DeleteUnit error: %v", gs.DeleteUnit(ctx, "B"))
+	check("deleting B", "w /kythe/text=2", "x /kythe/text=2")
+
+	testutil.FatalOnErrT(t, "DeleteUnit error: %v", gs.DeleteUnit(ctx, "A"))
+	check("deleting A")
+	it, err := db.ScanPrefix(nil, nil)
+	testutil.FatalOnErrT(t, "ScanPrefix error: %v", err)
+	if kvs := readAll(t, it); len(kvs) != 0 {
+		t.Errorf("Keys remaining after deleting all units: %q", kvs)
+	}
+}
+
+// scanSources returns a "<signature> <path> <edge kind or fact name>"
+// description of each entry in gs.
+func scanSources(ctx context.Context, t *testing.T, gs *keyvalue.Store) []string {
+	var res []string
+	testutil.FatalOnErrT(t, "Scan error: %v", gs.Scan(ctx, new(spb.ScanRequest), func(e *spb.Entry) error {
+		label := e.EdgeKind
+		if label == "" {
+			label = e.FactName
+		}
+		res = append(res, fmt.Sprintf("%s %s %s", e.Source.Signature, e.Source.Path, label))
+		return nil
+	}))
+	return res
+}
+
+// sameSet reports whether a and b contain the same strings, ignoring order.
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s]--; counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// writeFacts writes a single fact for each of the nodes numbered [from, to).
+func writeFacts(ctx context.Context, t *testing.T, gs *keyvalue.Store, from, to int) {
+	for i := from; i < to; i++ {
+		testutil.FatalOnErrT(t, "write error: %v", gs.Write(ctx, &spb.WriteRequest{
+			Source: &spb.VName{Signature: fmt.Sprintf("node%02d", i)},
+			Update: []*spb.WriteRequest_Update{{
+				FactName:  "/kythe/node/kind",
+				FactValue: []byte("test"),
+			}},
+		}))
+	}
+}
+
+// countAll returns the sum of the counts of each of the given number of shards.
+func countAll(ctx context.Context, t *testing.T, gs *keyvalue.Store, shards int64) int64 {
+	var total int64
+	for i := int64(0); i < shards; i++ {
+		n, err := gs.Count(ctx, &spb.CountRequest{Index: i, Shards: shards})
+		testutil.FatalOnErrT(t, "Count error: %v", err)
+		total += n
+	}
+	return total
+}
+
+func mustCreate(t *testing.T, create CreateFunc) (DB, func()) {
+	db, destroy, err := create()
+	testutil.FatalOnErrT(t, "CreateFunc error: %v", err)
+	return db, func() {
+		testutil.FatalOnErrT(t, "db close error: %v", db.Close())
+		testutil.FatalOnErrT(t, "DestroyFunc error: %v", destroy())
+	}
+}
+
+// writeKeys writes the given key-value pairs and deletes the given keys from db
+// in a single batch.
+func writeKeys(t *testing.T, db DB, kvs map[string]string, deletes []string) {
+	wr, err := db.Writer()
+	testutil.FatalOnErrT(t, "writer error: %v", err)
+	for k, v := range kvs {
+		testutil.FatalOnErrT(t, "write error: %v", wr.Write([]byte(k), []byte(v)))
+	}
+	for _, k := range deletes {
+		testutil.FatalOnErrT(t, "delete error: %v", wr.Delete([]byte(k)))
+	}
+	testutil.FatalOnErrT(t, "writer close error: %v", wr.Close())
+}
+
+// readAll returns the "key=value" pairs from it and closes it.
+func readAll(t *testing.T, it keyvalue.Iterator) []string {
+	defer func() { testutil.FatalOnErrT(t, "iterator close error: %v", it.Close()) }()
+	var kvs []string
+	for {
+		k, v, err := it.Next()
+		if err == io.EOF {
+			return kvs
+		}
+		testutil.FatalOnErrT(t, "iterator error: %v", err)
+		kvs = append(kvs, fmt.Sprintf("%s=%s", k, v))
+	}
+}
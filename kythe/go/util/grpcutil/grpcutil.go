@@ -0,0 +1,58 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package grpcutil provides utilities for serving and calling Kythe's gRPC
+// services without generated service stubs.
+package grpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// A UnaryHandler handles a single request to a unary method of srv, the
+// service implementation registered with a grpc.Server.
+type UnaryHandler func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error)
+
+// MethodName returns the full name of the given method of the named service,
+// as passed to grpc.ClientConn.Invoke.
+func MethodName(serviceName, methodName string) string {
+	return "/" + serviceName + "/" + methodName
+}
+
+// UnaryMethod returns a grpc.MethodDesc for a unary method of the named
+// service.  Each request is decoded into a message returned by newRequest and
+// then passed to handle, through the server's interceptor if it has one.
+func UnaryMethod(serviceName, methodName string, newRequest func() interface{}, handle UnaryHandler) grpc.MethodDesc {
+	fullMethod := MethodName(serviceName, methodName)
+	return grpc.MethodDesc{
+		MethodName: methodName,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newRequest()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return handle(srv, ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+			return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return handle(srv, ctx, req)
+			})
+		},
+	}
+}
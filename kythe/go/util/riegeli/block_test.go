@@ -222,3 +222,33 @@ func TestBlockReader_sequential(t *testing.T) {
 		t.Fatalf("Unexpected read of %d bytes past end (err=%v): %s", n, err, hex.EncodeToString(found))
 	}
 }
+
+func TestBlockReader_seekIntoHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := &blockWriter{w: &buf}
+
+	const numChunks = 20
+	const chunkSize = usableBlockSize / 10
+	for i := 0; i < numChunks; i++ {
+		if _, err := w.WriteChunk(bytes.Repeat([]byte{byte(i)}, chunkSize)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every position within the second block's header is moved past it.
+	r := &blockReader{r: bytes.NewReader(buf.Bytes())}
+	expected := byte(usableBlockSize / chunkSize)
+	for pos := int64(blockSize); pos <= blockSize+blockHeaderSize; pos++ {
+		if err := r.Seek(pos); err != nil {
+			t.Fatalf("Error seeking to %d: %v", pos, err)
+		} else if found := r.Position(); found != blockSize+blockHeaderSize {
+			t.Errorf("Unexpected position after seeking to %d: found: %d; expected: %d", pos, found, blockSize+blockHeaderSize)
+		}
+		found := make([]byte, 1)
+		if _, err := io.ReadFull(r, found); err != nil {
+			t.Fatal(err)
+		} else if found[0] != expected {
+			t.Errorf("Unexpected byte after seeking to %d: found: %d; expected: %d", pos, found[0], expected)
+		}
+	}
+}
@@ -87,6 +87,19 @@ type sizePrefixedWriterTo struct {
 	buf *bytes.Buffer
 	io.WriteCloser
 	prefix []byte
+
+	// decompressedSize is the total size of the data written to the compressor.
+	// It is used as the block's varint size prefix, as required by the file
+	// format: https://github.com/google/riegeli/blob/master/doc/riegeli_records_file_format.md#chunk-data
+	// (see TestCompressedSizePrefix for the C++ golden files that agree).
+	decompressedSize uint64
+}
+
+// Write implements part of the compressor interface.
+func (w *sizePrefixedWriterTo) Write(b []byte) (int, error) {
+	n, err := w.WriteCloser.Write(b)
+	w.decompressedSize += uint64(n)
+	return n, err
 }
 
 // Close implements part of the compressor interface.
@@ -96,7 +109,7 @@ func (w *sizePrefixedWriterTo) Close() error {
 	}
 
 	w.prefix = make([]byte, binary.MaxVarintLen64)
-	n := int64(binary.PutUvarint(w.prefix[:], uint64(w.buf.Len())))
+	n := int64(binary.PutUvarint(w.prefix[:], w.decompressedSize))
 	w.prefix = w.prefix[:n]
 
 	return nil
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package riegeli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/DataDog/zstd"
+	"github.com/google/brotli/go/cbrotli"
+)
+
+// sizePrefix returns the varint size prefix of a compressed block, checking
+// that it is the size of the block's decompressed data.
+func sizePrefix(block []byte, c compressionType) (uint64, error) {
+	r := bytes.NewReader(block)
+	prefix, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("reading size prefix: %v", err)
+	}
+	var dec io.ReadCloser
+	switch c {
+	case brotliCompression:
+		dec = cbrotli.NewReader(r)
+	case zstdCompression:
+		dec = zstd.NewReader(r)
+	default:
+		return 0, fmt.Errorf("unexpected compression type %v", c)
+	}
+	defer dec.Close()
+	data, err := ioutil.ReadAll(dec)
+	if err != nil {
+		return 0, fmt.Errorf("decompressing block: %v", err)
+	} else if prefix != uint64(len(data)) {
+		return 0, fmt.Errorf("size prefix %d; decompressed size %d", prefix, len(data))
+	}
+	return prefix, nil
+}
+
+// checkRecordChunkSizePrefixes checks the size prefixes of each compressed
+// record chunk in the Riegeli file read from r.  It returns the number of
+// compressed chunks checked.
+func checkRecordChunkSizePrefixes(t *testing.T, r io.ReadSeeker) int {
+	t.Helper()
+	cr := &chunkReader{r: &blockReader{r: r}}
+	var checked int
+	for {
+		c, _, err := cr.Next()
+		if err == io.EOF {
+			return checked
+		} else if err != nil {
+			t.Fatalf("Error reading chunk: %v", err)
+		}
+		if c.Header.ChunkType != recordChunkType {
+			continue
+		}
+		rec, err := decodeRecordChunk(c)
+		if err != nil {
+			t.Fatalf("Error decoding record chunk: %v", err)
+		} else if rec.CompressionType == noCompression {
+			continue
+		}
+		if _, err := sizePrefix(rec.CompressedSizes, rec.CompressionType); err != nil {
+			t.Errorf("Compressed sizes: %v", err)
+		}
+		// The values decompress to the concatenated records, whose total size
+		// is also recorded in the chunk header.
+		if size, err := sizePrefix(rec.CompressedValues, rec.CompressionType); err != nil {
+			t.Errorf("Compressed values: %v", err)
+		} else if size != c.Header.DecodedDataSize {
+			t.Errorf("Compressed values: size prefix %d; chunk decoded_data_size %d", size, c.Header.DecodedDataSize)
+		}
+		checked++
+	}
+}
+
+// TestCompressedSizePrefix checks that compressed blocks are prefixed by their
+// decompressed size, both in files written by the C++ implementation and in
+// files written by this package.
+func TestCompressedSizePrefix(t *testing.T) {
+	// The golden files were written by the C++ implementation.
+	for _, variant := range []string{"brotli", "zstd"} {
+		f, err := os.Open("testdata/golden.entries." + variant + ".riegeli")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := checkRecordChunkSizePrefixes(t, f); n == 0 {
+			t.Errorf("No compressed record chunks found in %s golden file", variant)
+		}
+		f.Close()
+	}
+
+	for _, opts := range []*WriterOptions{
+		{Compression: BrotliCompression(-1)},
+		{Compression: ZSTDCompression(-1)},
+	} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, opts)
+		for i := 0; i < 1000; i++ {
+			if err := w.Put([]byte(fmt.Sprintf("record %d", i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if n := checkRecordChunkSizePrefixes(t, bytes.NewReader(buf.Bytes())); n == 0 {
+			t.Errorf("No compressed record chunks written with %s", opts)
+		}
+	}
+}
@@ -95,6 +95,55 @@ func TestGoldenTestData(t *testing.T) {
 	}
 }
 
+func TestGoldenRoundtrip(t *testing.T) {
+	mdTextProto, err := ioutil.ReadFile(goldenMetadataFile)
+	if err != nil {
+		t.Fatalf("Error reading %s: %v", goldenMetadataFile, err)
+	}
+	var md rmpb.RecordsMetadata
+	if err := proto.UnmarshalText(string(mdTextProto), &md); err != nil {
+		t.Fatalf("Error unmarshaling %s: %v", goldenMetadataFile, err)
+	}
+	// Let the Writer encode its own options.
+	md.RecordWriterOptions = nil
+
+	for _, variant := range goldenRiegeliFileVariants {
+		opts := strings.Replace(variant, "_", ",", -1)
+		t.Run(variant, func(t *testing.T) {
+			wrOpts, err := riegeli.ParseOptions(opts)
+			if err != nil {
+				t.Fatalf("Error parsing options %q: %v", opts, err)
+			}
+			wrOpts.Metadata = &md
+
+			f, err := ioutil.TempFile("", "golden."+variant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(f.Name())
+			defer f.Close()
+
+			jsonFile, err := os.Open(goldenJSONFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer jsonFile.Close()
+
+			wr := riegeli.NewWriter(f, wrOpts)
+			for e := range stream.ReadJSONEntries(jsonFile) {
+				if err := wr.PutProto(e); err != nil {
+					t.Fatalf("Error writing entry: %v", err)
+				}
+			}
+			if err := wr.Close(); err != nil {
+				t.Fatalf("Error closing writer: %v", err)
+			}
+
+			checkGoldenData(t, f.Name(), opts)
+		})
+	}
+}
+
 // checkGoldenData ensures that the given Riegeli file contains the exact
 // same records as the goldenJSONFile.  It also checks the RecordsMetadata
 // against goldenMetadataFile and the given expectedOptions.  RecordsMetadata
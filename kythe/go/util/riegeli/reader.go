@@ -29,13 +29,21 @@ import (
 )
 
 type reader struct {
-	r *chunkReader
+	r    *chunkReader
+	opts *ReaderOptions
+
+	// recovered is the number of regions skipped by a recovering reader.
+	recovered int
 
 	verified bool
 	metadata *rmpb.RecordsMetadata
 
 	recordReader recordReader
+	chunkBegin   int64
 	chunkSize    int64
+
+	// prefetched holds the chunks read ahead of the current chunk, in order.
+	prefetched []*decodedChunk
 }
 
 // RecordsMetadata implements part of the Reader interface.
@@ -69,8 +77,15 @@ func (r *reader) Position() (RecordPosition, error) {
 		return RecordPosition{}, fmt.Errorf("error verifying file: %v", err)
 	}
 
+	// Ensure the position is at the beginning of the next available record.
+	if err := r.ensureRecordReader(); err == io.EOF {
+		return RecordPosition{ChunkBegin: r.r.r.Position()}, nil
+	} else if err != nil {
+		return RecordPosition{}, err
+	}
+
 	return RecordPosition{
-		ChunkBegin:  r.r.Position(),
+		ChunkBegin:  r.chunkBegin,
 		RecordIndex: int64(r.recordReader.Index()),
 	}, nil
 }
@@ -82,14 +97,19 @@ func (r *reader) SeekToRecord(pos RecordPosition) error {
 		return fmt.Errorf("error verifying file: %v", err)
 	}
 
-	if r.r.Position() != pos.ChunkBegin {
+	if r.recordReader == nil || r.chunkBegin != pos.ChunkBegin {
 		// We're seeking outside of the current chunk.
-		if err := r.r.Seek(pos.ChunkBegin); err != nil {
+		if err := r.discardPrefetched(); err != nil {
+			return err
+		} else if err := r.r.Seek(pos.ChunkBegin); err != nil {
 			return err
 		}
 		r.recordReader = nil
+		recovered := r.recovered
 		if err := r.ensureRecordReader(); err != nil {
 			return err
+		} else if r.recovered != recovered {
+			return fmt.Errorf("unreadable chunk at %d", pos.ChunkBegin)
 		}
 	}
 
@@ -104,14 +124,11 @@ func (r *reader) Seek(pos int64) error {
 		return fmt.Errorf("error verifying file: %v", err)
 	}
 
-	blockStart := (pos / blockSize) * blockSize
-	if pos-blockStart < blockHeaderSize {
-		pos += blockHeaderSize
-	}
-
-	if pos < r.r.Position() || pos >= r.r.Position()+r.chunkSize {
+	if r.recordReader == nil || pos < r.chunkBegin || pos >= r.chunkBegin+r.chunkSize {
 		// We're seeking outside of the current chunk.
-		if err := r.r.SeekToChunkContaining(pos); err != nil {
+		if err := r.discardPrefetched(); err != nil {
+			return err
+		} else if err := r.r.SeekToChunkContaining(pos); err != nil {
 			return fmt.Errorf("failed to seek to enclosing chunk: %v", err)
 		}
 		r.recordReader = nil
@@ -122,7 +139,7 @@ func (r *reader) Seek(pos int64) error {
 			return err
 		}
 	}
-	recordIndex := int(pos - r.r.Position())
+	recordIndex := int(pos - r.chunkBegin)
 	r.recordReader.Seek(recordIndex)
 	return nil
 }
@@ -143,57 +160,179 @@ func (r *reader) ensureRecordReader() error {
 	}
 
 	for r.recordReader == nil {
-		c, size, err := r.r.Next()
-		if err != nil {
-			return err
-		} else if c.Header.NumRecords == 0 && c.Header.ChunkType != fileSignatureChunkType && c.Header.ChunkType != fileMetadataChunkType {
+		d := r.nextChunk()
+		if d.readErr == io.EOF {
+			return io.EOF
+		} else if d.readErr != nil {
+			if err := r.recover(d, d.readErr); err != nil {
+				return err
+			}
+			continue
+		} else if !d.hasRecords() {
 			// ignore chunks with no records; even for unknown chunk types
 			continue
 		}
-		r.chunkSize = size
 
-		switch c.Header.ChunkType {
-		case fileSignatureChunkType:
-			// TODO(schroederc): verify once at beginning of reader
-			if err := verifySignature(c); err != nil {
+		if d.done != nil {
+			<-d.done
+		}
+		if d.err != nil {
+			if err := r.recover(d, d.err); err != nil {
 				return err
 			}
-		case fileMetadataChunkType:
-			rd, err := newTransposedRecordReader(c)
-			if err != nil {
-				return fmt.Errorf("bad transpose chunk: %v", err)
-			} else if rd.Len() != 1 {
-				return fmt.Errorf("didn't find single RecordsMetadata record: found %d", rd.Len())
-			}
-			rec, err := rd.Next()
-			cErr := rd.Close()
-			if err != nil {
-				return fmt.Errorf("reading RecordsMetadata: %v", err)
-			} else if cErr != nil {
-				return fmt.Errorf("closing RecordsMetadata reader: %v", err)
-			}
-			r.metadata = new(rmpb.RecordsMetadata)
-			if err := proto.Unmarshal(rec, r.metadata); err != nil {
-				return fmt.Errorf("bad RecordsMetadata: %v", err)
-			}
-		case transposedChunkType:
-			r.recordReader, err = newTransposedRecordReader(c)
-			if err != nil {
-				return fmt.Errorf("bad transpose chunk: %v", err)
-			} else if uint64(r.recordReader.Len()) != c.Header.NumRecords {
-				return fmt.Errorf("mismatching number of transposed records: found: %d; expected: %d", r.recordReader.Len(), c.Header.NumRecords)
-			}
-		case recordChunkType:
-			r.recordReader, err = newRecordChunkReader(c)
-			if err != nil {
-				return fmt.Errorf("bad record chunk: %v", err)
-			} else if uint64(r.recordReader.Len()) != c.Header.NumRecords {
-				return fmt.Errorf("mismatching number of records: found: %d; expected: %d", r.recordReader.Len(), c.Header.NumRecords)
+			continue
+		}
+		if d.metadata != nil {
+			r.metadata = d.metadata
+		}
+		r.chunkBegin, r.chunkSize = d.begin, d.size
+		r.recordReader = d.rd
+	}
+	return nil
+}
+
+// A decodedChunk is a chunk read from the file along with the result of
+// decoding it.  If the chunk is being decoded concurrently, done is closed
+// once decoding completes.
+type decodedChunk struct {
+	begin, size int64
+	c           *chunk
+	readErr     error
+
+	done     chan struct{}
+	rd       recordReader
+	metadata *rmpb.RecordsMetadata
+	err      error
+}
+
+// hasRecords reports whether the chunk must be decoded by the reader.
+func (d *decodedChunk) hasRecords() bool {
+	t := d.c.Header.ChunkType
+	return d.c.Header.NumRecords != 0 || t == fileSignatureChunkType || t == fileMetadataChunkType
+}
+
+func (d *decodedChunk) decode() {
+	if d.done != nil {
+		defer close(d.done)
+	}
+	d.rd, d.metadata, d.err = decodeChunk(d.c)
+}
+
+// nextChunk returns the next chunk in the file.  If the reader prefetches
+// chunks, the following chunks are read and decoded concurrently.  Chunks
+// following a read error are not prefetched so that the reader may recover
+// from the error.
+func (r *reader) nextChunk() *decodedChunk {
+	n := r.opts.parallelism()
+	if n <= 1 {
+		d := r.readChunk()
+		if d.readErr == nil && d.hasRecords() {
+			d.decode()
+		}
+		return d
+	}
+
+	for len(r.prefetched) < n && (len(r.prefetched) == 0 || r.prefetched[len(r.prefetched)-1].readErr == nil) {
+		d := r.readChunk()
+		if d.readErr == nil && d.hasRecords() {
+			d.done = make(chan struct{})
+			go d.decode()
+		}
+		r.prefetched = append(r.prefetched, d)
+	}
+	d := r.prefetched[0]
+	r.prefetched = r.prefetched[1:]
+	return d
+}
+
+// readChunk reads the next chunk from the file without decoding it.
+func (r *reader) readChunk() *decodedChunk {
+	c, size, err := r.r.Next()
+	return &decodedChunk{begin: r.r.Position(), size: size, c: c, readErr: err}
+}
+
+// discardPrefetched releases all prefetched chunks so that the reader may be
+// repositioned.
+func (r *reader) discardPrefetched() error {
+	for _, d := range r.prefetched {
+		if d.done != nil {
+			<-d.done
+		}
+		if d.rd != nil {
+			if err := d.rd.Close(); err != nil {
+				return fmt.Errorf("error closing record reader: %v", err)
 			}
-		default:
-			return fmt.Errorf("unsupported read of chunk_type: '%s'", []byte{byte(c.Header.ChunkType)})
 		}
 	}
+	r.prefetched = nil
+	return nil
+}
+
+// decodeChunk interprets the given chunk, returning a recordReader if it
+// contains records or the RecordsMetadata it contains.
+func decodeChunk(c *chunk) (recordReader, *rmpb.RecordsMetadata, error) {
+	switch c.Header.ChunkType {
+	case fileSignatureChunkType:
+		// TODO(schroederc): verify once at beginning of reader
+		return nil, nil, verifySignature(c)
+	case fileMetadataChunkType:
+		rd, err := newTransposedRecordReader(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad transpose chunk: %v", err)
+		} else if rd.Len() != 1 {
+			return nil, nil, fmt.Errorf("didn't find single RecordsMetadata record: found %d", rd.Len())
+		}
+		rec, err := rd.Next()
+		cErr := rd.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading RecordsMetadata: %v", err)
+		} else if cErr != nil {
+			return nil, nil, fmt.Errorf("closing RecordsMetadata reader: %v", err)
+		}
+		md := new(rmpb.RecordsMetadata)
+		if err := proto.Unmarshal(rec, md); err != nil {
+			return nil, nil, fmt.Errorf("bad RecordsMetadata: %v", err)
+		}
+		return nil, md, nil
+	case transposedChunkType:
+		rd, err := newTransposedRecordReader(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad transpose chunk: %v", err)
+		} else if uint64(rd.Len()) != c.Header.NumRecords {
+			return nil, nil, fmt.Errorf("mismatching number of transposed records: found: %d; expected: %d", rd.Len(), c.Header.NumRecords)
+		}
+		return rd, nil, nil
+	case recordChunkType:
+		rd, err := newRecordChunkReader(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad record chunk: %v", err)
+		} else if uint64(rd.Len()) != c.Header.NumRecords {
+			return nil, nil, fmt.Errorf("mismatching number of records: found: %d; expected: %d", rd.Len(), c.Header.NumRecords)
+		}
+		return rd, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported read of chunk_type: '%s'", []byte{byte(c.Header.ChunkType)})
+	}
+}
+
+// recover skips over a chunk that could not be read, if the reader is
+// recovering from corruption.  Otherwise, err is returned.  If the chunk's
+// header could not be read, the chunk's boundaries are unknown and reading
+// resumes at the next chunk beginning in a later block.
+func (r *reader) recover(d *decodedChunk, err error) error {
+	if r.opts == nil || r.opts.Recover == nil {
+		return err
+	}
+	r.recordReader = nil
+	region := SkippedRegion{Begin: d.begin, End: d.begin + d.size, Err: err}
+	if d.c == nil {
+		if err := r.r.SkipToNextBlock(); err != nil && err != io.EOF {
+			return err
+		}
+		region.End = r.r.r.Position()
+	}
+	r.recovered++
+	r.opts.Recover(region)
 	return nil
 }
 
@@ -325,6 +464,10 @@ type blockReader struct {
 
 	header   *blockHeader
 	position int64
+
+	// blockStart is the starting offset of the current block and offset is the
+	// offset of the next block to be read from r.
+	blockStart, offset int64
 }
 
 // Read implements the io.Reader interface by skipping over the interleaven
@@ -345,19 +488,65 @@ func (b *blockReader) Read(bs []byte) (int, error) {
 // Next reads the next full block of data.
 func (b *blockReader) Next() ([]byte, error) {
 	var block [blockSize]byte
-	if n, err := io.ReadFull(b.r, block[:]); err == io.EOF {
+	blockStart := b.offset
+	n, err := io.ReadFull(b.r, block[:])
+	b.offset += int64(n)
+	if err == io.EOF {
 		return nil, io.EOF
 	} else if err != nil && err != io.ErrUnexpectedEOF {
 		return nil, fmt.Errorf("reading block: %v", err)
-	} else if n < blockHeaderSize {
+	}
+
+	// The block has been consumed; any further failure is due to corruption.
+	b.header, b.position = nil, b.offset
+	if n < blockHeaderSize {
 		return nil, fmt.Errorf("short read for block header: %d", n)
-	} else if hdr, err := decodeBlockHeader(bytes.NewReader(block[:blockHeaderSize])); err != nil {
-		// TODO(schroederc): recover to next block on failure here
+	}
+	hdr, err := decodeBlockHeader(bytes.NewReader(block[:blockHeaderSize]))
+	if err != nil {
 		return nil, fmt.Errorf("decoding block header: %v", err)
-	} else {
-		b.header = hdr
-		b.position += blockHeaderSize
-		return block[blockHeaderSize:n], nil
+	}
+	b.header = hdr
+	b.blockStart = blockStart
+	b.position = blockStart + blockHeaderSize
+	return block[blockHeaderSize:n], nil
+}
+
+// SkipToChunkAfter positions the reader at the beginning of the first chunk
+// that, according to the block headers, begins in a block after pos.  Blocks
+// with corrupt headers are skipped.
+func (b *blockReader) SkipToChunkAfter(pos int64) error {
+	for {
+		if b.buf != nil && b.header != nil && b.blockStart > pos {
+			// A chunk either begins at the block boundary or the block header
+			// points to the beginning of the next chunk.
+			var offset int64
+			if b.header.PreviousChunk != 0 {
+				offset = int64(b.header.NextChunk) - blockHeaderSize
+			}
+			if offset >= 0 && offset < usableBlockSize {
+				if _, err := b.buf.Seek(offset, io.SeekStart); err != nil {
+					return err
+				}
+				b.position = b.blockStart + blockHeaderSize + offset
+				return nil
+			}
+		}
+
+		prev := b.offset
+		block, err := b.Next()
+		if err == io.EOF {
+			b.buf = nil
+			return io.EOF
+		} else if err != nil {
+			b.buf = nil
+			if b.offset == prev {
+				// No progress can be made.
+				return err
+			}
+			continue
+		}
+		b.buf = bytes.NewReader(block)
 	}
 }
 
@@ -368,8 +557,11 @@ func (b *blockReader) Position() int64 { return b.position }
 func (b *blockReader) Seek(pos int64) error {
 	blockStart := (pos / blockSize) * blockSize
 	if pos-blockStart < blockHeaderSize {
-		return fmt.Errorf("attempting to seek into block header: %d", pos)
-	} else if err := b.readBlock(blockStart); err != nil {
+		// Positions within a block's header (including a chunk beginning at the
+		// block boundary) are moved past the header.
+		pos = blockStart + blockHeaderSize
+	}
+	if err := b.readBlock(blockStart); err != nil {
 		return err
 	} else if _, err := b.buf.Seek(pos-(blockStart+blockHeaderSize), io.SeekStart); err != nil {
 		return err
@@ -379,14 +571,15 @@ func (b *blockReader) Seek(pos int64) error {
 }
 
 func (b *blockReader) readBlock(blockStart int64) error {
-	if b.buf != nil && b.position >= blockStart && b.position < blockStart+blockSize {
+	if b.buf != nil && b.header != nil && b.blockStart == blockStart {
+		// The block is already loaded.
 		return nil
 	}
 	_, err := b.r.Seek(blockStart, io.SeekStart)
 	if err != nil {
 		return fmt.Errorf("failed to seek to beginning of block: %v", err)
 	}
-	b.position = blockStart
+	b.position, b.offset = blockStart, blockStart
 	block, err := b.Next()
 	if err != nil {
 		return err
@@ -419,9 +612,10 @@ type chunkReader struct {
 	position int64
 }
 
-// Next reads the next full chunk.
+// Next reads the next full chunk.  If the chunk's header can be read, but
+// not the rest of the chunk, the chunk is returned along with the error.
 func (c *chunkReader) Next() (*chunk, int64, error) {
-	c.position = c.r.Position()
+	c.position = c.chunkBegin()
 	h, err := decodeChunkHeader(c.r)
 	if err == io.EOF {
 		return nil, 0, io.EOF
@@ -457,7 +651,7 @@ func (c *chunkReader) SeekToChunkContaining(pos int64) error {
 		return err
 	}
 
-	if pos < c.r.Position() {
+	if pos < c.chunkBegin() {
 		// Chunk starts in previous block
 		blockStart -= blockSize
 		if err := c.r.SeekToNextChunkInBlock(blockStart); err != nil {
@@ -468,7 +662,7 @@ func (c *chunkReader) SeekToChunkContaining(pos int64) error {
 	var h *chunkHeader
 	var err error
 	for h == nil || pos >= c.position+chunkHeaderSize+int64(h.DataSize)+int64(paddingSize(int(c.position), h)) {
-		c.position = c.r.Position()
+		c.position = c.chunkBegin()
 		h, err = decodeChunkHeader(c.r)
 		if err == io.EOF {
 			return io.EOF
@@ -482,6 +676,21 @@ func (c *chunkReader) SeekToChunkContaining(pos int64) error {
 // Position returns the position of the current chunk.
 func (c *chunkReader) Position() int64 { return c.position }
 
+// chunkBegin returns the beginning of the chunk starting at the blockReader's
+// current position.  A chunk beginning at a block boundary begins before the
+// block's header.
+func (c *chunkReader) chunkBegin() int64 {
+	pos := c.r.Position()
+	if pos%blockSize == blockHeaderSize && c.r.header != nil && c.r.header.PreviousChunk == 0 {
+		return pos - blockHeaderSize
+	}
+	return pos
+}
+
+// SkipToNextBlock skips to the first chunk beginning in a block after the
+// beginning of the current chunk.
+func (c *chunkReader) SkipToNextBlock() error { return c.r.SkipToChunkAfter(c.position) }
+
 func decodeRecordChunk(c *chunk) (*recordChunk, error) {
 	r := bytes.NewReader(c.Data)
 	ct, err := r.ReadByte()
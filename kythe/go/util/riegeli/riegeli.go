@@ -22,9 +22,12 @@
 package riegeli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 
@@ -84,9 +87,119 @@ type WriterOptions struct {
 
 	// Compression is the type of compression used for encoding chunks.
 	Compression CompressionType
+
+	// Transpose determines whether records are encoded in transposed chunks.
+	// Transposition groups the values of each protocol buffer field together,
+	// which usually compresses much better than simple chunks.  Records that
+	// are not protocol buffers are still supported, but gain nothing.
+	Transpose bool
+
+	// Metadata is written to the beginning of the file, if non-nil.  Unless
+	// already set, its RecordWriterOptions field is set to the String encoding
+	// of these options.  See ProtoMetadata.
+	Metadata *rmpb.RecordsMetadata
+
+	// Parallelism is the maximum number of chunks encoded concurrently.  Chunks
+	// are still written in order.  If Parallelism <= 1, each chunk is encoded
+	// when it is flushed.
+	Parallelism int
+}
+
+// String returns the options in the string format understood by ParseOptions
+// and by the C++ Riegeli implementation.
+func (o *WriterOptions) String() string {
+	var opts []string
+	switch o.compressionType() {
+	case noCompression:
+		opts = append(opts, "uncompressed")
+	case brotliCompression:
+		opts = append(opts, compressionOption("brotli", o.compressionLevel(), DefaultBrotliLevel))
+	case zstdCompression:
+		opts = append(opts, compressionOption("zstd", o.compressionLevel(), DefaultZSTDLevel))
+	}
+	if o.transpose() {
+		opts = append(opts, "transpose")
+	}
+	if size := o.chunkSize(); size != DefaultChunkSize {
+		opts = append(opts, fmt.Sprintf("chunk_size:%d", size))
+	}
+	if n := o.parallelism(); n > 1 {
+		opts = append(opts, fmt.Sprintf("parallelism:%d", n))
+	}
+	return strings.Join(opts, ",")
 }
 
-// TODO(schroederc): encode/decode options as a string for RecordsMetadata
+func compressionOption(name string, level, defaultLevel int) string {
+	if level == defaultLevel {
+		return name
+	}
+	return fmt.Sprintf("%s:%d", name, level)
+}
+
+// ParseOptions parses WriterOptions from a comma-separated list of options in
+// the format used by the C++ Riegeli implementation:
+//
+//   options     ::= option? ("," option?)*
+//   option      ::= "default" | "uncompressed" | "brotli" (":" level)? |
+//                   "zstd" (":" level)? | "transpose" (":" ("true" | "false"))? |
+//                   "chunk_size" ":" size | "parallelism" ":" n
+//
+// Options not listed take their default values.
+func ParseOptions(s string) (*WriterOptions, error) {
+	opts := new(WriterOptions)
+	for _, opt := range strings.Split(s, ",") {
+		if opt == "" {
+			continue
+		}
+		key, val := opt, ""
+		hasVal := false
+		if i := strings.Index(opt, ":"); i >= 0 {
+			key, val, hasVal = opt[:i], opt[i+1:], true
+		}
+		level := -1
+		if hasVal && (key == "brotli" || key == "zstd") {
+			l, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s level %q: %v", key, val, err)
+			}
+			level = l
+		}
+		switch {
+		case key == "default" && !hasVal:
+			opts = new(WriterOptions)
+		case key == "uncompressed" && !hasVal:
+			opts.Compression = NoCompression
+		case key == "brotli":
+			opts.Compression = BrotliCompression(level)
+		case key == "zstd":
+			opts.Compression = ZSTDCompression(level)
+		case key == "transpose":
+			switch val {
+			case "", "true":
+				opts.Transpose = true
+			case "false":
+				opts.Transpose = false
+			default:
+				return nil, fmt.Errorf("invalid transpose value: %q", val)
+			}
+		case key == "chunk_size" && hasVal:
+			size, err := strconv.ParseUint(val, 10, 64)
+			if err != nil || size == 0 {
+				return nil, fmt.Errorf("invalid chunk_size: %q", val)
+			}
+			opts.ChunkSize = size
+		case key == "parallelism" && hasVal:
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid parallelism: %q", val)
+			}
+			opts.Parallelism = n
+		default:
+			return nil, fmt.Errorf("unknown option: %q", opt)
+		}
+	}
+	return opts, nil
+}
 
 func (o *WriterOptions) compressionType() compressionType {
 	c := DefaultCompression
@@ -104,6 +217,15 @@ func (o *WriterOptions) compressionLevel() int {
 	return c.(*compressionLevel).level
 }
 
+func (o *WriterOptions) transpose() bool { return o != nil && o.Transpose }
+
+func (o *WriterOptions) parallelism() int {
+	if o == nil {
+		return 0
+	}
+	return o.Parallelism
+}
+
 func (o *WriterOptions) chunkSize() uint64 {
 	if o == nil || o.ChunkSize == 0 {
 		return DefaultChunkSize
@@ -125,14 +247,12 @@ func NewWriterAt(w io.Writer, pos int, opts *WriterOptions) *Writer {
 }
 
 // Writer is a Riegeli records file writer.
-//
-// TODO(schroederc): add support for writing RecordsMetadata
-// TODO(schroederc): add support for tranposed records
 type Writer struct {
 	opts *WriterOptions
 	w    *blockWriter
 
-	recordWriter *recordChunkWriter
+	recordWriter chunkWriter
+	pending      []*pendingChunk
 
 	fileHeaderWritten bool
 }
@@ -145,7 +265,7 @@ func (w *Writer) Put(rec []byte) error {
 	}
 
 	if w.recordWriter == nil {
-		w.recordWriter, err = newRecordChunkWriter(w.opts)
+		w.recordWriter, err = newChunkWriter(w.opts)
 		if err != nil {
 			return err
 		}
@@ -153,8 +273,8 @@ func (w *Writer) Put(rec []byte) error {
 
 	if err := w.recordWriter.put(rec); err != nil {
 		return err
-	} else if w.recordWriter.decodedSize >= w.opts.chunkSize() {
-		return w.Flush()
+	} else if _, size := w.recordWriter.size(); size >= w.opts.chunkSize() {
+		return w.flushRecord()
 	}
 	return nil
 }
@@ -168,12 +288,15 @@ func (w *Writer) PutProto(msg proto.Message) error {
 	return w.Put(rec)
 }
 
-// Flush writes any buffered records to the underlying io.Writer.
+// Flush writes any buffered records to the underlying io.Writer.  Flush waits
+// for all chunks being encoded concurrently to be written.
 func (w *Writer) Flush() error {
 	if err := w.ensureFileHeader(); err != nil {
 		return err
+	} else if err := w.flushRecord(); err != nil {
+		return err
 	}
-	return w.flushRecord()
+	return w.writeAllPending()
 }
 
 // Close releases all resources associated with Writer.  Any buffered records
@@ -188,8 +311,33 @@ func (w *Writer) Close() error {
 	return nil
 }
 
+// Position returns the position of the next record to be written.  The file
+// header and any chunks being encoded concurrently are written, if necessary,
+// before returning the position.
+func (w *Writer) Position() (RecordPosition, error) {
+	if err := w.ensureFileHeader(); err != nil {
+		return RecordPosition{}, err
+	} else if err := w.writeAllPending(); err != nil {
+		return RecordPosition{}, err
+	}
+	var index uint64
+	if w.recordWriter != nil {
+		index, _ = w.recordWriter.size()
+	}
+	return RecordPosition{
+		ChunkBegin:  int64(w.w.pos),
+		RecordIndex: int64(index),
+	}, nil
+}
+
 // TODO(schroederc): add concatenation function
-// TODO(schroederc): return positions from Writer
+
+// SignatureSize is the size of the signature at the beginning of every Riegeli
+// file.
+const SignatureSize = blockHeaderSize + chunkHeaderSize
+
+// HasSignature reports whether b begins with the Riegeli file signature.
+func HasSignature(b []byte) bool { return bytes.HasPrefix(b, fileSignature) }
 
 // A RecordPosition is a pointer to the starting offset of a record within a
 // Riegeli file.
@@ -231,10 +379,46 @@ type ReadSeeker interface {
 	// position the reader to the next record in the file.
 	Seek(pos int64) error
 
-	// SeekToRecord seeks to the given RecordPosition.
+	// SeekToRecord seeks to the given RecordPosition, as returned by either
+	// Reader.Position or Writer.Position.
 	SeekToRecord(pos RecordPosition) error
 }
 
+// ReaderOptions control the behavior of a Reader.
+type ReaderOptions struct {
+	// Recover, if non-nil, enables the recovery of a Reader from corrupt or
+	// truncated files.  Rather than returning an error, the Reader skips each
+	// region of the file that cannot be read, resuming at the next valid chunk,
+	// and calls Recover with the skipped region.
+	Recover func(SkippedRegion)
+
+	// Parallelism is the number of chunks read ahead of the current chunk and
+	// decoded concurrently.  If Parallelism <= 1, each chunk is decoded when its
+	// first record is read.
+	Parallelism int
+}
+
+func (o *ReaderOptions) parallelism() int {
+	if o == nil {
+		return 0
+	}
+	return o.Parallelism
+}
+
+// A SkippedRegion is a region of a Riegeli file skipped by a recovering Reader.
+type SkippedRegion struct {
+	// Begin and End are the byte offsets of the region within the file.
+	Begin, End int64
+
+	// Err is the reason the region was skipped.
+	Err error
+}
+
+// String implements the fmt.Stringer interface.
+func (r SkippedRegion) String() string {
+	return fmt.Sprintf("[%d, %d): %v", r.Begin, r.End, r.Err)
+}
+
 type errSeeker struct{ io.Reader }
 
 // Seek implements the io.Seeker interface.
@@ -243,7 +427,18 @@ func (errSeeker) Seek(offset int64, whence int) (int64, error) {
 }
 
 // NewReader returns a Riegeli Reader for r.
-func NewReader(r io.Reader) Reader { return NewReadSeeker(&errSeeker{r}) }
+func NewReader(r io.Reader) Reader { return NewReaderWithOptions(r, nil) }
 
 // NewReadSeeker returns a Riegeli ReadSeeker for r.
-func NewReadSeeker(r io.ReadSeeker) ReadSeeker { return &reader{r: &chunkReader{r: &blockReader{r: r}}} }
+func NewReadSeeker(r io.ReadSeeker) ReadSeeker { return NewReadSeekerWithOptions(r, nil) }
+
+// NewReaderWithOptions returns a Riegeli Reader for r with the given options.
+func NewReaderWithOptions(r io.Reader, opts *ReaderOptions) Reader {
+	return NewReadSeekerWithOptions(&errSeeker{r}, opts)
+}
+
+// NewReadSeekerWithOptions returns a Riegeli ReadSeeker for r with the given
+// options.
+func NewReadSeekerWithOptions(r io.ReadSeeker, opts *ReaderOptions) ReadSeeker {
+	return &reader{r: &chunkReader{r: &blockReader{r: r}}, opts: opts}
+}
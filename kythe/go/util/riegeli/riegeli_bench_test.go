@@ -70,13 +70,20 @@ func BenchmarkWriteRandUncompressed(b *testing.B) { benchWrite(b, ioutil.Discard
 func BenchmarkWriteRandBrotli(b *testing.B) {
 	benchWrite(b, ioutil.Discard, 0, &WriterOptions{Compression: BrotliCompression(-1)}, genRand(0))
 }
+func BenchmarkWriteRandBrotliParallel(b *testing.B) {
+	benchWrite(b, ioutil.Discard, 0, &WriterOptions{Compression: BrotliCompression(-1), Parallelism: 4}, genRand(0))
+}
 
 func benchRead(b *testing.B, opts *WriterOptions, gen func(*testing.B) []byte) {
+	benchReadWithOptions(b, opts, nil, gen)
+}
+
+func benchReadWithOptions(b *testing.B, opts *WriterOptions, readOpts *ReaderOptions, gen func(*testing.B) []byte) {
 	buf := bytes.NewBuffer(nil)
 	benchWrite(b, buf, 0, opts, gen)
 	b.ResetTimer()
 
-	r := NewReader(buf)
+	r := NewReaderWithOptions(buf, readOpts)
 	for i := 0; i < b.N; i++ {
 		rec, err := r.Next()
 		if err != nil {
@@ -95,3 +102,6 @@ func BenchmarkReadRandUncompressed(b *testing.B) { benchRead(b, nil, genRand(0))
 func BenchmarkReadRandBrotli(b *testing.B) {
 	benchRead(b, &WriterOptions{Compression: BrotliCompression(-1)}, genRand(0))
 }
+func BenchmarkReadRandBrotliParallel(b *testing.B) {
+	benchReadWithOptions(b, &WriterOptions{Compression: BrotliCompression(-1)}, &ReaderOptions{Parallelism: 4}, genRand(0))
+}
@@ -22,6 +22,10 @@ import (
 	"fmt"
 	"io"
 	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	rmpb "kythe.io/third_party/riegeli/records_metadata_go_proto"
 )
 
 func TestWriteEmpty(t *testing.T) {
@@ -44,6 +48,13 @@ func TestWriteEmpty(t *testing.T) {
 	if found := buf.Bytes(); !bytes.Equal(found, expected) {
 		t.Errorf("Found: %s; expected: %s", hex.EncodeToString(found), hex.EncodeToString(expected))
 	}
+	if !HasSignature(expected) {
+		t.Error("HasSignature(header) == false")
+	} else if len(expected) != SignatureSize {
+		t.Errorf("Signature size: found %d; expected %d", SignatureSize, len(expected))
+	} else if HasSignature(expected[:SignatureSize-1]) {
+		t.Error("HasSignature(truncated header) == true")
+	}
 }
 
 func TestReadWriteStringsDefaults(t *testing.T) { testReadWriteStrings(t, nil) }
@@ -229,6 +240,412 @@ func TestReaderSeekAllPositions(t *testing.T) {
 	}
 }
 
-// TODO(schroederc): test transposed chunks
-// TODO(schroederc): test RecordsMetadata
+func TestReadWriteStringsTranspose(t *testing.T) {
+	testReadWriteStrings(t, &WriterOptions{Transpose: true})
+}
+
+func TestReadWriteProtosTranspose(t *testing.T) {
+	for _, opts := range transposeOptions {
+		opts := &WriterOptions{Compression: opts.Compression, Transpose: true, ChunkSize: 1 << 10}
+		t.Run(opts.String(), func(t *testing.T) {
+			const N = 1e4
+			var buf bytes.Buffer
+			wr := NewWriter(&buf, opts)
+			for i := 0; i < N; i++ {
+				rec := concat(varintField(1, uint64(i)), bytesField(2, []byte(fmt.Sprintf("%d", i))))
+				if err := wr.Put(rec); err != nil {
+					t.Fatalf("Error Put(%d): %v", i, err)
+				}
+			}
+			if err := wr.Close(); err != nil {
+				t.Fatalf("Close error: %v", err)
+			}
+
+			rd := NewReader(bytes.NewReader(buf.Bytes()))
+			for i := 0; i < N; i++ {
+				expected := concat(varintField(1, uint64(i)), bytesField(2, []byte(fmt.Sprintf("%d", i))))
+				if rec, err := rd.Next(); err != nil {
+					t.Fatalf("Read error: %v", err)
+				} else if !bytes.Equal(rec, expected) {
+					t.Errorf("Found: %s; expected: %s", hex.EncodeToString(rec), hex.EncodeToString(expected))
+				}
+			}
+			if rec, err := rd.Next(); err != io.EOF {
+				t.Errorf("Unexpected final Read: %q %v", hex.EncodeToString(rec), err)
+			}
+		})
+	}
+}
+
+func TestRecordsMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	wr := NewWriter(&buf, &WriterOptions{
+		Compression: ZSTDCompression(3),
+		Transpose:   true,
+		Metadata:    &rmpb.RecordsMetadata{RecordTypeName: proto.String("kythe.proto.Entry")},
+	})
+	if err := wr.Put([]byte("record")); err != nil {
+		t.Fatal(err)
+	} else if err := wr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rd := NewReader(bytes.NewReader(buf.Bytes()))
+	md, err := rd.RecordsMetadata()
+	if err != nil {
+		t.Fatalf("Error reading RecordsMetadata: %v", err)
+	}
+	expected := &rmpb.RecordsMetadata{
+		RecordTypeName:      proto.String("kythe.proto.Entry"),
+		RecordWriterOptions: proto.String("zstd:3,transpose"),
+	}
+	if !proto.Equal(md, expected) {
+		t.Errorf("Found RecordsMetadata: {%v}; expected: {%v}", md, expected)
+	}
+	if rec, err := rd.Next(); err != nil {
+		t.Fatalf("Read error: %v", err)
+	} else if string(rec) != "record" {
+		t.Errorf("Found record: %q; expected: %q", rec, "record")
+	}
+	if rec, err := rd.Next(); err != io.EOF {
+		t.Errorf("Unexpected final Read: %q %v", hex.EncodeToString(rec), err)
+	}
+}
+
+func TestWriterOptionsString(t *testing.T) {
+	tests := []struct {
+		opts     *WriterOptions
+		expected string
+	}{
+		{nil, "brotli"},
+		{&WriterOptions{}, "brotli"},
+		{&WriterOptions{Compression: NoCompression}, "uncompressed"},
+		{&WriterOptions{Compression: BrotliCompression(4)}, "brotli:4"},
+		{&WriterOptions{Compression: ZSTDCompression(-1)}, "zstd"},
+		{&WriterOptions{Compression: ZSTDCompression(1), Transpose: true}, "zstd:1,transpose"},
+		{&WriterOptions{Transpose: true, ChunkSize: 1024}, "brotli,transpose,chunk_size:1024"},
+		{&WriterOptions{Compression: NoCompression, Parallelism: 4}, "uncompressed,parallelism:4"},
+	}
+	for _, test := range tests {
+		if found := test.opts.String(); found != test.expected {
+			t.Errorf("%+v.String(): found %q; expected %q", test.opts, found, test.expected)
+		}
+
+		opts, err := ParseOptions(test.expected)
+		if err != nil {
+			t.Errorf("ParseOptions(%q): %v", test.expected, err)
+		} else if found := opts.String(); found != test.expected {
+			t.Errorf("ParseOptions(%q).String(): found %q", test.expected, found)
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		opts     string
+		expected string
+	}{
+		{"", "brotli"},
+		{"default", "brotli"},
+		{"zstd,default", "brotli"},
+		{"uncompressed,,transpose", "uncompressed,transpose"},
+		{"transpose:true,transpose:false", "brotli"},
+		{"brotli:11,chunk_size:100", "brotli:11,chunk_size:100"},
+		{"parallelism:1,zstd", "zstd"},
+		{"zstd:3,parallelism:8", "zstd:3,parallelism:8"},
+	}
+	for _, test := range tests {
+		if opts, err := ParseOptions(test.opts); err != nil {
+			t.Errorf("ParseOptions(%q): %v", test.opts, err)
+		} else if found := opts.String(); found != test.expected {
+			t.Errorf("ParseOptions(%q).String(): found %q; expected %q", test.opts, found, test.expected)
+		}
+	}
+
+	for _, bad := range []string{"gzip", "brotli:x", "uncompressed:1", "transpose:maybe", "chunk_size", "chunk_size:0", "parallelism:x", "parallelism:-1"} {
+		if opts, err := ParseOptions(bad); err == nil {
+			t.Errorf("ParseOptions(%q): expected error; found %v", bad, opts)
+		}
+	}
+}
+
+func TestWriterPosition(t *testing.T) {
+	const N = 1e4
+	var buf bytes.Buffer
+	wr := NewWriter(&buf, &WriterOptions{ChunkSize: 1 << 10})
+	var positions []RecordPosition
+	for i := 0; i < N; i++ {
+		pos, err := wr.Position()
+		if err != nil {
+			t.Fatalf("Error getting writer position: %v", err)
+		} else if err := wr.Put([]byte(fmt.Sprintf("%d", i))); err != nil {
+			t.Fatalf("Error Put(%d): %v", i, err)
+		}
+		positions = append(positions, pos)
+	}
+	if err := wr.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	rd := NewReadSeeker(bytes.NewReader(buf.Bytes()))
+	for i, expected := range positions {
+		if pos, err := rd.Position(); err != nil {
+			t.Fatalf("Error getting reader position: %v", err)
+		} else if pos != expected {
+			t.Fatalf("Record %d: reader position %v; writer position %v", i, pos, expected)
+		} else if _, err := rd.Next(); err != nil {
+			t.Fatalf("Read error: %v", err)
+		}
+	}
+
+	for i := len(positions) - 1; i >= 0; i-- {
+		if err := rd.SeekToRecord(positions[i]); err != nil {
+			t.Fatalf("Error seeking to %v: %v", positions[i], err)
+		} else if rec, err := rd.Next(); err != nil {
+			t.Fatalf("Read error at %v: %v", positions[i], err)
+		} else if string(rec) != fmt.Sprintf("%d", i) {
+			t.Errorf("At %v found: %q; expected: %d", positions[i], rec, i)
+		}
+	}
+}
+
+func TestChunkAtBlockBoundary(t *testing.T) {
+	var buf bytes.Buffer
+	wr := NewWriter(&buf, &WriterOptions{Compression: NoCompression})
+
+	// The file header is 64 bytes and a simple chunk with a single record of
+	// this size (uncompressed) fills the rest of the first block exactly.
+	const size = blockSize - 64 - chunkHeaderSize - 5
+	first := bytes.Repeat([]byte{'a'}, size)
+	if err := wr.Put(first); err != nil {
+		t.Fatal(err)
+	} else if err := wr.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	pos, err := wr.Position()
+	if err != nil {
+		t.Fatal(err)
+	} else if expected := (RecordPosition{ChunkBegin: blockSize}); pos != expected {
+		t.Fatalf("Found writer position %v; expected %v", pos, expected)
+	}
+	if err := wr.Put([]byte("second")); err != nil {
+		t.Fatal(err)
+	} else if err := wr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rd := NewReadSeeker(bytes.NewReader(buf.Bytes()))
+	if rec, err := rd.Next(); err != nil || !bytes.Equal(rec, first) {
+		t.Fatalf("Bad first record: %d bytes; %v", len(rec), err)
+	} else if found, err := rd.Position(); err != nil || found != pos {
+		t.Fatalf("Found reader position %v (err: %v); expected %v", found, err, pos)
+	}
+
+	for i, seek := range []func() error{
+		func() error { return rd.SeekToRecord(pos) },
+		func() error { return rd.Seek(pos.index()) },
+	} {
+		if err := rd.SeekToRecord(RecordPosition{}); err != nil {
+			t.Fatal(err)
+		} else if err := seek(); err != nil {
+			t.Fatalf("Error seeking to %v: %v", pos, err)
+		} else if rec, err := rd.Next(); err != nil || string(rec) != "second" {
+			t.Fatalf("Seek %d: found %d byte record (err: %v); expected %q", i, len(rec), err, "second")
+		}
+	}
+}
+
+// writeRecoveryFile returns a file with many small chunks spanning many blocks.
+func writeRecoveryFile(t *testing.T, n int) []byte {
+	return writeStrings(t, &WriterOptions{Compression: NoCompression, ChunkSize: 1 << 9}, n).Bytes()
+}
+
+// readRecovering reads all records from the given file, recovering from any
+// corruption.
+func readRecovering(t *testing.T, file []byte, parallelism int) ([]string, []SkippedRegion) {
+	var skipped []SkippedRegion
+	rd := NewReaderWithOptions(bytes.NewReader(file), &ReaderOptions{
+		Recover:     func(r SkippedRegion) { skipped = append(skipped, r) },
+		Parallelism: parallelism,
+	})
+	var recs []string
+	for {
+		rec, err := rd.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Read error: %v", err)
+		}
+		recs = append(recs, string(rec))
+	}
+	return recs, skipped
+}
+
+// checkRecovered ensures that recs is an ordered subset of the n strings
+// written by writeStrings and returns the number of missing records.
+func checkRecovered(t *testing.T, recs []string, n int) int {
+	t.Helper()
+	var next int
+	for _, rec := range recs {
+		for next < n && fmt.Sprintf("%d", next) != rec {
+			next++
+		}
+		if next == n {
+			t.Fatalf("Unexpected record: %q", rec)
+		}
+		next++
+	}
+	return n - len(recs)
+}
+
+func TestRecoverCorruptChunk(t *testing.T) { testRecoverCorruptChunk(t, 0) }
+
+func TestRecoverCorruptChunkParallel(t *testing.T) { testRecoverCorruptChunk(t, 4) }
+
+func testRecoverCorruptChunk(t *testing.T, parallelism int) {
+	const N = 1e5
+	file := writeRecoveryFile(t, N)
+
+	// Corrupt data in the middle of the second block and the header of the
+	// fourth block.
+	file[blockSize+blockSize/2] ^= 0xff
+	file[3*blockSize] ^= 0xff
+
+	rd := NewReader(bytes.NewReader(file))
+	var err error
+	for err == nil {
+		_, err = rd.Next()
+	}
+	if err == io.EOF {
+		t.Fatal("Expected error reading corrupt file")
+	}
+
+	recs, skipped := readRecovering(t, file, parallelism)
+	if len(skipped) != 2 {
+		t.Errorf("Expected 2 skipped regions; found %v", skipped)
+	}
+	for _, r := range skipped {
+		if r.Begin >= r.End || r.Err == nil {
+			t.Errorf("Invalid skipped region: %v", r)
+		}
+	}
+	// Roughly a block of records is lost with the corrupt block header.
+	if missing := checkRecovered(t, recs, N); missing == 0 || missing > N/4 {
+		t.Errorf("Unexpected number of missing records: %d", missing)
+	}
+	if last := recs[len(recs)-1]; last != fmt.Sprintf("%d", int(N)-1) {
+		t.Errorf("Found last record %q; expected %d", last, int(N)-1)
+	}
+}
+
+func TestRecoverTruncated(t *testing.T) {
+	const N = 1e5
+	file := writeRecoveryFile(t, N)
+	file = file[:len(file)-100]
+
+	recs, skipped := readRecovering(t, file, 0)
+	if len(skipped) != 1 {
+		t.Errorf("Expected 1 skipped region; found %v", skipped)
+	}
+	if missing := checkRecovered(t, recs, N); missing == 0 || missing > 100 {
+		t.Errorf("Unexpected number of missing records: %d", missing)
+	}
+	if first := recs[0]; first != "0" {
+		t.Errorf("Found first record %q; expected 0", first)
+	}
+}
+
+func TestParallelWriter(t *testing.T) {
+	const N = 1e5
+	for _, opts := range []*WriterOptions{
+		{ChunkSize: 1 << 12},
+		{ChunkSize: 1 << 12, Compression: ZSTDCompression(-1), Transpose: true},
+	} {
+		t.Run(opts.String(), func(t *testing.T) {
+			expected := writeStrings(t, opts, N)
+
+			parallel := *opts
+			parallel.Parallelism = 4
+			found := writeStrings(t, &parallel, N)
+
+			// Concurrent encoding must not change the file.
+			if !bytes.Equal(found.Bytes(), expected.Bytes()) {
+				t.Errorf("Parallel writer output differs: %d bytes vs %d bytes", found.Len(), expected.Len())
+			}
+		})
+	}
+}
+
+func TestParallelWriterPosition(t *testing.T) {
+	const N = 1e4
+	var buf bytes.Buffer
+	wr := NewWriter(&buf, &WriterOptions{ChunkSize: 1 << 10, Parallelism: 4})
+
+	var positions []RecordPosition
+	for i := 0; i < N; i++ {
+		if i%100 == 0 {
+			pos, err := wr.Position()
+			if err != nil {
+				t.Fatalf("Error getting position: %v", err)
+			}
+			positions = append(positions, pos)
+		}
+		if err := wr.Put([]byte(fmt.Sprintf("%d", i))); err != nil {
+			t.Fatalf("Error Put(%d): %v", i, err)
+		}
+	}
+	if err := wr.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	rd := NewReadSeeker(bytes.NewReader(buf.Bytes()))
+	for i, pos := range positions {
+		if err := rd.SeekToRecord(pos); err != nil {
+			t.Fatalf("Error seeking to %v: %v", pos, err)
+		} else if rec, err := rd.Next(); err != nil {
+			t.Fatalf("Read error at %v: %v", pos, err)
+		} else if expected := fmt.Sprintf("%d", i*100); string(rec) != expected {
+			t.Errorf("At %v found: %q; expected: %q", pos, rec, expected)
+		}
+	}
+}
+
+func TestParallelReader(t *testing.T) {
+	const N = 1e5
+	buf := writeStrings(t, &WriterOptions{ChunkSize: 1 << 12}, N)
+	rd := NewReadSeekerWithOptions(bytes.NewReader(buf.Bytes()), &ReaderOptions{Parallelism: 4})
+
+	var positions []RecordPosition
+	for i := 0; i < N; i++ {
+		pos, err := rd.Position()
+		if err != nil {
+			t.Fatalf("Error getting position: %v", err)
+		}
+		positions = append(positions, pos)
+		if rec, err := rd.Next(); err != nil {
+			t.Fatalf("Read error: %v", err)
+		} else if string(rec) != fmt.Sprintf("%d", i) {
+			t.Errorf("Found: %q; expected: %d", rec, i)
+		}
+	}
+	if rec, err := rd.Next(); err != io.EOF {
+		t.Fatalf("Unexpected final Read: %q %v", rec, err)
+	}
+
+	// Seeking discards any prefetched chunks.
+	for i := int(N - 1); i >= 0; i -= 997 {
+		p := positions[i]
+		if err := rd.SeekToRecord(p); err != nil {
+			t.Fatalf("Error seeking to record %d at %v: %v", i, p, err)
+		}
+		for j := i; j < i+10 && j < N; j++ {
+			if rec, err := rd.Next(); err != nil {
+				t.Fatalf("Read error at %v: %v", p, err)
+			} else if string(rec) != fmt.Sprintf("%d", j) {
+				t.Errorf("After %v found: %q; expected: %d", p, rec, j)
+			}
+		}
+	}
+}
+
 // TODO(schroederc): test padding
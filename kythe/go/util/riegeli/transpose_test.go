@@ -17,14 +17,174 @@
 package riegeli
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"testing"
+
+	"github.com/golang/protobuf/proto"
 )
 
-// TODO(schroederc): add more tests once an encoder is available
+// Helpers to construct wire-encoded protocol buffer fields.
+func varintField(field int, n uint64) []byte {
+	return append(proto.EncodeVarint(uint64(field<<3)|proto.WireVarint), proto.EncodeVarint(n)...)
+}
+
+func bytesField(field int, val []byte) []byte {
+	b := proto.EncodeVarint(uint64(field<<3) | proto.WireBytes)
+	b = append(b, proto.EncodeVarint(uint64(len(val)))...)
+	return append(b, val...)
+}
+
+func fixed32Field(field int, n uint32) []byte {
+	b := proto.EncodeVarint(uint64(field<<3) | proto.WireFixed32)
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func fixed64Field(field int, n uint64) []byte {
+	b := proto.EncodeVarint(uint64(field<<3) | proto.WireFixed64)
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func groupField(field int, contents []byte) []byte {
+	b := proto.EncodeVarint(uint64(field<<3) | proto.WireStartGroup)
+	b = append(b, contents...)
+	return append(b, proto.EncodeVarint(uint64(field<<3)|proto.WireEndGroup)...)
+}
+
+func concat(bs ...[]byte) []byte { return bytes.Join(bs, nil) }
+
+func testTransposeRoundtrip(t *testing.T, opts *WriterOptions, records [][]byte) {
+	t.Helper()
+	data, err := encodeTransposedChunk(opts, records)
+	if err != nil {
+		t.Fatalf("Error encoding transposed chunk: %v", err)
+	}
+	rd, err := newTransposedRecordReader(&chunk{
+		Header: chunkHeader{
+			ChunkType:  transposedChunkType,
+			DataSize:   uint64(len(data)),
+			NumRecords: uint64(len(records)),
+		},
+		Data: data,
+	})
+	if err != nil {
+		t.Fatalf("Error decoding transposed chunk: %v", err)
+	}
+	defer rd.Close()
+	if rd.Len() != len(records) {
+		t.Fatalf("Found %d records; expected %d", rd.Len(), len(records))
+	}
+	for i, expected := range records {
+		rec, err := rd.Next()
+		if err != nil {
+			t.Fatalf("Error reading record %d: %v", i, err)
+		} else if !bytes.Equal(rec, expected) {
+			t.Errorf("Record %d: found %s; expected %s", i, hex.EncodeToString(rec), hex.EncodeToString(expected))
+		}
+	}
+}
+
+var transposeOptions = []*WriterOptions{
+	{Compression: NoCompression},
+	{Compression: BrotliCompression(-1)},
+	{Compression: ZSTDCompression(-1)},
+}
 
-// TODO(schroederc): test non-proto records support
-// TODO(schroederc): test deprecated protobuf "groups" support
+func TestTransposeRoundtrip(t *testing.T) {
+	nested := concat(varintField(1, 5), bytesField(2, []byte("nested")))
+	tests := []struct {
+		name    string
+		records [][]byte
+	}{
+		{"empty", [][]byte{{}}},
+		{"varints", [][]byte{
+			varintField(1, 0),
+			varintField(1, 127),
+			varintField(1, 128),
+			varintField(1, 1<<63),
+			concat(varintField(2, 1), varintField(1, 300), varintField(2, 1)),
+		}},
+		{"noncanonical_varint", [][]byte{concat(varintField(1, 1), []byte{0x08, 0x80, 0x00})}},
+		{"fixed", [][]byte{
+			concat(fixed32Field(1, 0xdeadbeef), fixed64Field(2, 1<<60)),
+			fixed32Field(1, 0),
+		}},
+		{"strings", [][]byte{
+			bytesField(1, nil),
+			bytesField(1, []byte("a string")),
+			bytesField(1, []byte{0xff}),
+			concat(bytesField(1, []byte("first")), bytesField(2, []byte("second"))),
+		}},
+		{"submessages", [][]byte{
+			bytesField(1, nested),
+			concat(varintField(3, 1), bytesField(1, bytesField(1, nested)), bytesField(1, nested)),
+			bytesField(1, concat(varintField(1, 2), bytesField(3, bytesField(4, []byte("deep"))))),
+		}},
+		{"groups", [][]byte{
+			groupField(1, nil),
+			concat(varintField(2, 2), groupField(1, concat(varintField(2, 3), groupField(3, bytesField(4, []byte("g")))))),
+		}},
+		{"non_proto", [][]byte{
+			[]byte("not a proto"),
+			[]byte{0x08},
+			{0x0c},
+			{0x00, 0x01},
+		}},
+		{"mixed", [][]byte{
+			varintField(1, 1),
+			[]byte("not a proto"),
+			{},
+			bytesField(1, nested),
+			[]byte("still not a proto"),
+			concat(varintField(1, 1), []byte{0x0f}),
+		}},
+		{"many_states", manyFieldRecords(1000)},
+		{"max_states", manyFieldRecords(maxTransitionTargets - 1)},
+	}
+	for _, test := range tests {
+		for _, opts := range transposeOptions {
+			t.Run(fmt.Sprintf("%s_%s", test.name, opts), func(t *testing.T) {
+				testTransposeRoundtrip(t, opts, test.records)
+			})
+		}
+	}
+}
+
+// manyFieldRecords returns n records, each with a distinct field.
+func manyFieldRecords(n int) [][]byte {
+	recs := make([][]byte, n)
+	for i := range recs {
+		recs[i] = varintField(i+1, uint64(i))
+	}
+	return recs
+}
+
+func TestTransposeTooManyStates(t *testing.T) {
+	recs := manyFieldRecords(maxTransitionTargets + 1)
+	if _, err := encodeTransposedChunk(nil, recs); err != errTooManyStates {
+		t.Fatalf("Expected errTooManyStates; found: %v", err)
+	}
+
+	// The writer falls back to a simple chunk.
+	w := newTransposedChunkWriter(&WriterOptions{Transpose: true})
+	for _, rec := range recs {
+		if err := w.put(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if chunkType, _, err := w.encode(); err != nil {
+		t.Fatal(err)
+	} else if chunkType != recordChunkType {
+		t.Errorf("Found chunk type %q; expected %q", chunkType, recordChunkType)
+	}
+}
 
 func TestBackwardWriter(t *testing.T) {
 	pieces := []string{"a", "b", "ccc", "dd", "eee"}
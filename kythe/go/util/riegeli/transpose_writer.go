@@ -0,0 +1,558 @@
+/*
+ * Copyright 2018 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package riegeli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Encoding format documentation:
+//   - https://github.com/google/riegeli/blob/master/doc/riegeli_records_file_format.md#transposed-chunk-with-records
+//   - https://github.com/google/riegeli/blob/master/riegeli/chunk_encoding/transpose_encoder.h
+//
+// See transpose.go for the decoding of transposed chunks.
+
+const (
+	// maxTransposeDepth is the maximum nesting of submessages that are
+	// transposed.  Any deeper submessages are encoded as strings.
+	maxTransposeDepth = 100
+
+	// statesPerPage is the number of states reachable from a single transition
+	// byte.
+	statesPerPage = 64
+
+	// maxTransitionTargets is the maximum number of states that may be targets
+	// of explicit transitions using a single page of trampoline states.
+	maxTransitionTargets = statesPerPage * statesPerPage
+)
+
+// errTooManyStates is returned by encodeTransposedChunk when the records
+// require a state machine too large to encode.
+var errTooManyStates = errors.New("too many transposed states")
+
+// A transposedChunkWriter buffers records to be encoded as a transposed chunk.
+// If the records cannot be transposed, they are encoded as a simple chunk.
+type transposedChunkWriter struct {
+	opts        *WriterOptions
+	records     [][]byte
+	decodedSize uint64
+}
+
+func newTransposedChunkWriter(opts *WriterOptions) *transposedChunkWriter {
+	return &transposedChunkWriter{opts: opts}
+}
+
+func (t *transposedChunkWriter) put(rec []byte) error {
+	t.records = append(t.records, append([]byte(nil), rec...))
+	t.decodedSize += uint64(len(rec))
+	return nil
+}
+
+func (t *transposedChunkWriter) size() (numRecords, decodedSize uint64) {
+	return uint64(len(t.records)), t.decodedSize
+}
+
+// Close implements the io.Closer interface.
+func (t *transposedChunkWriter) Close() error {
+	t.records = nil
+	return nil
+}
+
+// encode returns the binary-encoding of the Riegeli transposed chunk.
+func (t *transposedChunkWriter) encode() (chunkType, []byte, error) {
+	data, err := encodeTransposedChunk(t.opts, t.records)
+	if err == errTooManyStates {
+		// Fallback to a simple chunk of records.
+		rw := newRecordChunkWriter(t.opts)
+		for _, rec := range t.records {
+			if err := rw.put(rec); err != nil {
+				return 0, nil, err
+			}
+		}
+		return rw.encode()
+	} else if err != nil {
+		return 0, nil, err
+	}
+	return transposedChunkType, data, nil
+}
+
+// encodeTransposedChunk returns the data of a transposed chunk containing the
+// given records.
+func encodeTransposedChunk(opts *WriterOptions, records [][]byte) ([]byte, error) {
+	e := &transposeEncoder{
+		nodeIDs:    make(map[transposeNodeKey]int),
+		bufferIDs:  make(map[transposeBufferKey]int),
+		contextIDs: make(map[transposeBufferKey]int),
+	}
+	// Records are decoded in reverse order.
+	for i := len(records) - 1; i >= 0; i-- {
+		e.addRecord(records[i])
+	}
+	return e.encode(opts)
+}
+
+// A protoField is a single field of a parsed protocol buffer message.
+type protoField struct {
+	tag uint64
+
+	// value is the field's raw varint or fixed-width value or the contents of a
+	// length-delimited field.
+	value []byte
+
+	// fields are the fields of a length-delimited field that was parsed as a
+	// submessage.
+	fields     []protoField
+	submessage bool
+}
+
+// parseProto parses buf as a protocol buffer message that can be exactly
+// reproduced by a transposed chunk.  Returns false if buf is not such a
+// message.
+func parseProto(buf []byte, depth int) ([]protoField, bool) {
+	var (
+		fields []protoField
+		groups []uint64 // field numbers of open groups
+	)
+	for len(buf) > 0 {
+		tag, n := binary.Uvarint(buf)
+		if n <= 0 || n != uvarintSize(tag) || tag > math.MaxUint32 || tag < 8 {
+			return nil, false
+		}
+		buf = buf[n:]
+
+		f := protoField{tag: tag}
+		switch protoWireType(tag & 7) {
+		case protoVarintType:
+			n := varintSize(buf)
+			if n == 0 {
+				return nil, false
+			}
+			f.value, buf = buf[:n], buf[n:]
+		case protoFixed32Type, protoFixed64Type:
+			n := 4
+			if protoWireType(tag&7) == protoFixed64Type {
+				n = 8
+			}
+			if len(buf) < n {
+				return nil, false
+			}
+			f.value, buf = buf[:n], buf[n:]
+		case protoBytesType:
+			size, n := binary.Uvarint(buf)
+			if n <= 0 || n != uvarintSize(size) || size > uint64(len(buf)-n) {
+				return nil, false
+			}
+			f.value, buf = buf[n:n+int(size)], buf[n+int(size):]
+			if len(f.value) > 0 && depth < maxTransposeDepth {
+				f.fields, f.submessage = parseProto(f.value, depth+1)
+			}
+		case protoStartGroupType:
+			groups = append(groups, tag>>3)
+		case protoEndGroupType:
+			if len(groups) == 0 || groups[len(groups)-1] != tag>>3 {
+				return nil, false
+			}
+			groups = groups[:len(groups)-1]
+		default:
+			return nil, false
+		}
+		fields = append(fields, f)
+	}
+	return fields, len(groups) == 0
+}
+
+// varintSize returns the size of the varint at the start of buf or 0 if there
+// is no valid varint.
+func varintSize(buf []byte) int {
+	for i := 0; i < len(buf) && i < binary.MaxVarintLen64; i++ {
+		if buf[i] < 0x80 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// uvarintSize returns the size of the canonical varint encoding of n.
+func uvarintSize(n uint64) int {
+	size := 1
+	for ; n >= 0x80; n >>= 7 {
+		size++
+	}
+	return size
+}
+
+// A transposeNodeKey uniquely identifies a state in a transposed chunk's state
+// machine.
+type transposeNodeKey struct {
+	context int
+	tag     uint64
+	subtype tagSubtype
+}
+
+// A transposeBufferKey identifies a field within a (sub)message context.
+type transposeBufferKey struct {
+	context int
+	tag     uint64
+}
+
+// A transposeNode is a state in a transposed chunk's state machine.
+type transposeNode struct {
+	tag     uint64
+	subtype tagSubtype
+	buffer  int // index of the node's data buffer or -1 if it has none
+
+	// successor is the index of the node following each execution of this node
+	// or -1 if it is followed by different nodes (or is the last executed
+	// node).  Nodes with a single successor are encoded as implicit
+	// transitions.
+	successor    int
+	hasSuccessor bool
+
+	// targeted is the number of explicit transitions to the node.
+	targeted int
+
+	// state is the node's index in the encoded state machine.
+	state int
+
+	// next is the state index to which a trampoline node transitions.
+	next int
+}
+
+func (n *transposeNode) implicit() bool { return n.successor >= 0 }
+
+// A transposeEncoder builds the state machine for a transposed chunk.
+type transposeEncoder struct {
+	nodes   []*transposeNode
+	nodeIDs map[transposeNodeKey]int
+
+	buffers   []*bytes.Buffer
+	bufferIDs map[transposeBufferKey]int
+
+	// contextIDs are the contexts of submessages keyed by their parent's context
+	// and field tag.  The root message's context is 0.
+	contextIDs map[transposeBufferKey]int
+
+	nonProtoBuffer  int
+	nonProtoLengths bytes.Buffer
+	hasNonProto     bool
+
+	// sequence is the order in which the nodes are executed by a decoder.
+	sequence []int
+
+	// trampolines is the number of noOp states used to reach states beyond the
+	// first page of states.
+	trampolines int
+}
+
+// node returns the index of the node identified by the given key, adding a
+// node if necessary.  If hasBuffer is true, the node is associated with the
+// data buffer of the field (context, bufferTag).
+func (e *transposeEncoder) node(key transposeNodeKey, hasBuffer bool, bufferTag uint64) int {
+	if id, ok := e.nodeIDs[key]; ok {
+		return id
+	}
+	n := &transposeNode{tag: key.tag, subtype: key.subtype, buffer: -1, successor: -1}
+	if hasBuffer {
+		n.buffer = e.buffer(transposeBufferKey{key.context, bufferTag})
+	}
+	id := len(e.nodes)
+	e.nodes = append(e.nodes, n)
+	e.nodeIDs[key] = id
+	return id
+}
+
+// buffer returns the index of the data buffer with the given key, adding a
+// buffer if necessary.
+func (e *transposeEncoder) buffer(key transposeBufferKey) int {
+	if id, ok := e.bufferIDs[key]; ok {
+		return id
+	}
+	id := len(e.buffers)
+	e.buffers = append(e.buffers, new(bytes.Buffer))
+	e.bufferIDs[key] = id
+	return id
+}
+
+// visit appends the given node to the execution sequence and returns its data
+// buffer, if any.
+func (e *transposeEncoder) visit(id int) *bytes.Buffer {
+	e.sequence = append(e.sequence, id)
+	if b := e.nodes[id].buffer; b >= 0 {
+		return e.buffers[b]
+	}
+	return nil
+}
+
+func (e *transposeEncoder) addRecord(rec []byte) {
+	fields, ok := parseProto(rec, 0)
+	if !ok {
+		if !e.hasNonProto {
+			e.hasNonProto = true
+			e.nonProtoBuffer = len(e.buffers)
+			e.buffers = append(e.buffers, new(bytes.Buffer))
+		}
+		id := e.node(transposeNodeKey{tag: uint64(nonProtoTag)}, false, 0)
+		e.nodes[id].buffer = e.nonProtoBuffer
+		e.visit(id).Write(rec)
+		var buf [binary.MaxVarintLen64]byte
+		e.nonProtoLengths.Write(buf[:binary.PutUvarint(buf[:], uint64(len(rec)))])
+		return
+	}
+
+	e.addFields(0, fields)
+	e.visit(e.node(transposeNodeKey{tag: uint64(startOfMessageTag)}, false, 0))
+}
+
+// addFields adds the nodes for the given fields in the order they are executed
+// by a decoder: the decoder writes each record backwards.
+func (e *transposeEncoder) addFields(context int, fields []protoField) {
+	var buf [binary.MaxVarintLen64]byte
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		switch protoWireType(f.tag & 7) {
+		case protoVarintType:
+			if len(f.value) == 1 {
+				// Small varints are inlined within the node's subtype.
+				subtype := varintInline0Subtype + tagSubtype(f.value[0])
+				e.visit(e.node(transposeNodeKey{context, f.tag, subtype}, false, 0))
+				continue
+			}
+			subtype := varint1Subtype + tagSubtype(len(f.value)-1)
+			b := e.visit(e.node(transposeNodeKey{context, f.tag, subtype}, true, f.tag))
+			for _, v := range f.value {
+				b.WriteByte(v & 0x7f)
+			}
+		case protoFixed32Type, protoFixed64Type:
+			e.visit(e.node(transposeNodeKey{context, f.tag, trivialSubtype}, true, f.tag)).Write(f.value)
+		case protoBytesType:
+			if !f.submessage {
+				b := e.visit(e.node(transposeNodeKey{context, f.tag, delimitedStringSubtype}, true, f.tag))
+				b.Write(buf[:binary.PutUvarint(buf[:], uint64(len(f.value)))])
+				b.Write(f.value)
+				continue
+			}
+			key := transposeBufferKey{context, f.tag}
+			child, ok := e.contextIDs[key]
+			if !ok {
+				child = len(e.contextIDs) + 1
+				e.contextIDs[key] = child
+			}
+			end := f.tag - protoBytesType + protoSubmessageType
+			e.visit(e.node(transposeNodeKey{context, end, trivialSubtype}, false, 0))
+			e.addFields(child, f.fields)
+			e.visit(e.node(transposeNodeKey{child, uint64(startOfSubmessageTag), trivialSubtype}, false, 0))
+		case protoStartGroupType, protoEndGroupType:
+			e.visit(e.node(transposeNodeKey{context, f.tag, trivialSubtype}, false, 0))
+		}
+	}
+}
+
+// layout determines each node's successor and returns the nodes in the order
+// of their states, including any trampoline states.
+func (e *transposeEncoder) layout() ([]*transposeNode, error) {
+	for i, id := range e.sequence {
+		n := e.nodes[id]
+		next := -1
+		// The initial node is always explicit so that decoders need not agree on
+		// how an implicit initial state is handled.
+		if i > 0 && i+1 < len(e.sequence) {
+			next = e.sequence[i+1]
+		}
+		if !n.hasSuccessor {
+			n.hasSuccessor = true
+			n.successor = next
+		} else if n.successor != next {
+			n.successor = -1
+		}
+	}
+	for i, id := range e.sequence[:len(e.sequence)-1] {
+		if !e.nodes[id].implicit() {
+			e.nodes[e.sequence[i+1]].targeted++
+		}
+	}
+
+	// Order the targets of explicit transitions first, most frequent first, so
+	// that the most common transitions require a single byte.
+	var targets, others []*transposeNode
+	for _, n := range e.nodes {
+		if n.targeted > 0 {
+			targets = append(targets, n)
+		} else {
+			others = append(others, n)
+		}
+	}
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].targeted > targets[j].targeted })
+
+	var states []*transposeNode
+	if len(targets) <= statesPerPage {
+		states = append(targets, others...)
+	} else if len(targets) > maxTransitionTargets {
+		return nil, errTooManyStates
+	} else {
+		// Targets that don't fit within the first page of states are reached
+		// through noOp trampoline states at the end of the first page, each
+		// transitioning to a separate page of targets.
+		e.trampolines = (len(targets) - 2) / (statesPerPage - 1)
+		direct := statesPerPage - e.trampolines
+		states = append(states, targets[:direct]...)
+		for i := 1; i <= e.trampolines; i++ {
+			states = append(states, &transposeNode{
+				tag:       uint64(noOpTag),
+				buffer:    -1,
+				successor: -1,
+				next:      i * statesPerPage,
+			})
+		}
+		states = append(states, targets[direct:]...)
+		states = append(states, others...)
+	}
+	for i, n := range states {
+		n.state = i
+	}
+	return states, nil
+}
+
+// transitions returns the transition bytes for the execution sequence.
+// Explicit transitions are all relative to the first state.
+func (e *transposeEncoder) transitions() []byte {
+	var ts []byte
+	for i, id := range e.sequence[:len(e.sequence)-1] {
+		if e.nodes[id].implicit() {
+			continue
+		}
+		target := e.nodes[e.sequence[i+1]].state
+		if page := target / statesPerPage; page > 0 {
+			// Transition through the page's trampoline state.
+			trampoline := statesPerPage - e.trampolines + page - 1
+			ts = append(ts, byte(trampoline<<2))
+			target -= page * statesPerPage
+		}
+		ts = append(ts, byte(target<<2))
+	}
+	return ts
+}
+
+// encode returns the data of the transposed chunk.
+func (e *transposeEncoder) encode(opts *WriterOptions) ([]byte, error) {
+	if len(e.sequence) == 0 {
+		return nil, errors.New("empty transposed chunk")
+	}
+	states, err := e.layout()
+	if err != nil {
+		return nil, err
+	}
+
+	buffers := e.buffers
+	if e.hasNonProto {
+		// The non-proto record lengths are always the final buffer.
+		buffers = append(buffers, &e.nonProtoLengths)
+	} else if len(buffers) == 0 {
+		// At least one buffer is required.
+		buffers = append(buffers, new(bytes.Buffer))
+	}
+
+	// All buffers are compressed together in a single bucket.
+	bucket, err := newCompressor(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range buffers {
+		if _, err := bucket.Write(b.Bytes()); err != nil {
+			return nil, fmt.Errorf("compressing buffer: %v", err)
+		}
+	}
+	if err := bucket.Close(); err != nil {
+		return nil, fmt.Errorf("closing bucket compressor: %v", err)
+	}
+
+	// See parseTransposeStateMachine for the header format.
+	var hdr bytes.Buffer
+	putUvarint := func(n uint64) {
+		var buf [binary.MaxVarintLen64]byte
+		hdr.Write(buf[:binary.PutUvarint(buf[:], n)])
+	}
+	putUvarint(1) // num_buckets
+	putUvarint(uint64(len(buffers)))
+	putUvarint(uint64(bucket.Len()))
+	for _, b := range buffers {
+		putUvarint(uint64(b.Len()))
+	}
+	putUvarint(uint64(len(states)))
+	for _, n := range states {
+		putUvarint(n.tag)
+	}
+	for _, n := range states {
+		switch {
+		case n.implicit():
+			putUvarint(uint64(e.nodes[n.successor].state + len(states)))
+		default:
+			putUvarint(uint64(n.next))
+		}
+	}
+	for _, n := range states {
+		if validProtoTag(n.tag) && hasSubtype(n.tag) {
+			hdr.WriteByte(byte(n.subtype))
+		}
+	}
+	for _, n := range states {
+		if n.buffer >= 0 {
+			putUvarint(uint64(n.buffer))
+		}
+	}
+	putUvarint(uint64(e.nodes[e.sequence[0]].state))
+
+	header, err := compress(opts, hdr.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("compressing header: %v", err)
+	}
+	transitions, err := compress(opts, e.transitions())
+	if err != nil {
+		return nil, fmt.Errorf("compressing transitions: %v", err)
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	data := bytes.NewBuffer(make([]byte, 0, 1+len(buf)+len(header)+bucket.Len()+len(transitions)))
+	data.WriteByte(byte(opts.compressionType()))
+	data.Write(buf[:binary.PutUvarint(buf[:], uint64(len(header)))])
+	data.Write(header)
+	bucket.WriteTo(data)
+	data.Write(transitions)
+	return data.Bytes(), nil
+}
+
+// compress returns the compressed block of the given data.
+func compress(opts *WriterOptions, data []byte) ([]byte, error) {
+	c, err := newCompressor(opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Write(data); err != nil {
+		return nil, err
+	} else if err := c.Close(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -22,13 +22,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+
+	"github.com/golang/protobuf/proto"
+
+	rmpb "kythe.io/third_party/riegeli/records_metadata_go_proto"
 )
 
 // https://github.com/google/riegeli/blob/master/doc/riegeli_records_file_format.md#file-signature
 var fileSignatureChunk = &chunk{Header: chunkHeader{ChunkType: fileSignatureChunkType}}
 
+// fileSignature is the encoding of the fileSignatureChunk at the beginning of
+// every Riegeli file.
+var fileSignature []byte
+
 func init() {
 	binary.LittleEndian.PutUint64(fileSignatureChunk.Header.DataHash[:], hashBytes(fileSignatureChunk.Data))
+
+	var buf bytes.Buffer
+	if _, err := fileSignatureChunk.WriteTo(&blockWriter{w: &buf}, 0); err != nil {
+		panic(err)
+	}
+	fileSignature = buf.Bytes()
 }
 
 func (w *Writer) ensureFileHeader() error {
@@ -36,36 +50,128 @@ func (w *Writer) ensureFileHeader() error {
 		return nil
 	}
 
-	_, err := fileSignatureChunk.WriteTo(w.w, w.w.pos)
-	// TODO(schroederc): encode RecordsMetadata chunk
+	if _, err := fileSignatureChunk.WriteTo(w.w, w.w.pos); err != nil {
+		return err
+	}
 	w.fileHeaderWritten = true
+	if w.opts == nil || w.opts.Metadata == nil {
+		return nil
+	}
+	return w.writeMetadata()
+}
+
+// writeMetadata writes the RecordsMetadata chunk from the Writer's options.
+func (w *Writer) writeMetadata() error {
+	md := proto.Clone(w.opts.Metadata).(*rmpb.RecordsMetadata)
+	if md.RecordWriterOptions == nil {
+		md.RecordWriterOptions = proto.String(w.opts.String())
+	}
+	rec, err := proto.Marshal(md)
+	if err != nil {
+		return fmt.Errorf("marshaling RecordsMetadata: %v", err)
+	}
+	data, err := encodeTransposedChunk(w.opts, [][]byte{rec})
+	if err != nil {
+		return fmt.Errorf("encoding RecordsMetadata chunk: %v", err)
+	}
+	// The metadata chunk contains no records in the file; its decoded size is
+	// the size of the serialized RecordsMetadata.
+	chunk := &chunk{
+		Header: chunkHeader{
+			ChunkType:       fileMetadataChunkType,
+			DataSize:        uint64(len(data)),
+			DecodedDataSize: uint64(len(rec)),
+		},
+		Data: data,
+	}
+	_, err = chunk.WriteTo(w.w, w.w.pos)
 	return err
 }
 
 func (w *Writer) flushRecord() error {
-	if w.recordWriter == nil || w.recordWriter.numRecords == 0 {
+	if w.recordWriter == nil {
 		// Skip writing empty record chunk.
 		return nil
 	}
+	if numRecords, _ := w.recordWriter.size(); numRecords == 0 {
+		// Skip writing empty record chunk.
+		return nil
+	}
+
+	cw := w.recordWriter
+	if n := w.opts.parallelism(); n > 1 {
+		// Encode the chunk concurrently, waiting for earlier chunks to be written
+		// if too many are already pending.
+		for len(w.pending) >= n {
+			if err := w.writePending(); err != nil {
+				return err
+			}
+		}
+		p := &pendingChunk{done: make(chan struct{})}
+		go func() {
+			defer close(p.done)
+			p.chunk, p.err = encodeChunk(cw)
+		}()
+		w.pending = append(w.pending, p)
+	} else {
+		chunk, err := encodeChunk(cw)
+		if err != nil {
+			return err
+		} else if _, err := chunk.WriteTo(w.w, w.w.pos); err != nil {
+			return err
+		}
+	}
 
-	data, err := w.recordWriter.encode()
+	var err error
+	w.recordWriter, err = newChunkWriter(w.opts)
+	return err
+}
+
+// A pendingChunk is a chunk being encoded concurrently with the Writer.
+type pendingChunk struct {
+	done  chan struct{}
+	chunk *chunk
+	err   error
+}
+
+// writePending waits for the oldest pending chunk to be encoded and writes it.
+func (w *Writer) writePending() error {
+	p := w.pending[0]
+	w.pending = w.pending[1:]
+	<-p.done
+	if p.err != nil {
+		return p.err
+	}
+	_, err := p.chunk.WriteTo(w.w, w.w.pos)
+	return err
+}
+
+// writeAllPending writes all pending chunks, in order.
+func (w *Writer) writeAllPending() error {
+	for len(w.pending) > 0 {
+		if err := w.writePending(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeChunk encodes the records buffered by cw as a chunk.
+func encodeChunk(cw chunkWriter) (*chunk, error) {
+	numRecords, decodedSize := cw.size()
+	chunkType, data, err := cw.encode()
 	if err != nil {
-		return fmt.Errorf("encoding record chunk: %v", err)
+		return nil, fmt.Errorf("encoding record chunk: %v", err)
 	}
-	chunk := &chunk{
+	return &chunk{
 		Header: chunkHeader{
-			ChunkType:       recordChunkType,
+			ChunkType:       chunkType,
 			DataSize:        uint64(len(data)),
-			DecodedDataSize: w.recordWriter.decodedSize,
-			NumRecords:      w.recordWriter.numRecords,
+			DecodedDataSize: decodedSize,
+			NumRecords:      numRecords,
 		},
 		Data: data,
-	}
-	if _, err := chunk.WriteTo(w.w, w.w.pos); err != nil {
-		return err
-	}
-	w.recordWriter, err = newRecordChunkWriter(w.opts)
-	return err
+	}, nil
 }
 
 // A blockWriter interleaves blockHeaders inside chunks of data.  Each
@@ -144,11 +250,37 @@ func (b *blockHeader) WriteTo(w io.Writer) (int, error) {
 	return w.Write(buf[:])
 }
 
+// A chunkWriter buffers records to be encoded as a single chunk.
+type chunkWriter interface {
+	io.Closer
+
+	// put adds the given record to the chunk.
+	put(rec []byte) error
+
+	// size returns the number of records in the chunk and their total size.
+	size() (numRecords, decodedSize uint64)
+
+	// encode returns the chunk's type and the binary-encoding of its data.
+	encode() (chunkType, []byte, error)
+}
+
+// newChunkWriter returns a chunkWriter for the chunk encoding determined by the
+// given options.
+func newChunkWriter(opts *WriterOptions) (chunkWriter, error) {
+	if opts.transpose() {
+		return newTransposedChunkWriter(opts), nil
+	}
+	return newRecordChunkWriter(opts), nil
+}
+
+// A recordChunkWriter buffers records to be encoded as a simple chunk.  Record
+// sizes and values are compressed when the chunk is encoded so that chunks may
+// be compressed concurrently.
 type recordChunkWriter struct {
-	compressionType         compressionType
+	opts                    *WriterOptions
 	numRecords, decodedSize uint64
 
-	sizesCompressor, valsCompressor compressor
+	sizes, vals bytes.Buffer
 }
 
 func (r *recordChunkWriter) put(rec []byte) error {
@@ -156,63 +288,54 @@ func (r *recordChunkWriter) put(rec []byte) error {
 
 	var buf [binary.MaxVarintLen64]byte
 	n := binary.PutUvarint(buf[:], size)
-
-	if _, err := r.sizesCompressor.Write(buf[:n]); err != nil {
-		return fmt.Errorf("compressing record size: %v", err)
-	} else if _, err := r.valsCompressor.Write(rec); err != nil {
-		return fmt.Errorf("compressing record: %v", err)
-	}
+	r.sizes.Write(buf[:n])
+	r.vals.Write(rec)
 
 	r.decodedSize += size
 	r.numRecords++
 	return nil
 }
 
+func (r *recordChunkWriter) size() (numRecords, decodedSize uint64) {
+	return r.numRecords, r.decodedSize
+}
+
 // Close implements the io.Closer interface.
 func (r *recordChunkWriter) Close() error {
-	if err := r.sizesCompressor.Close(); err != nil {
-		return fmt.Errorf("closing record size compressor: %v", err)
-	} else if err := r.valsCompressor.Close(); err != nil {
-		return fmt.Errorf("closing record value compressor: %v", err)
-	}
+	r.sizes.Reset()
+	r.vals.Reset()
 	return nil
 }
 
 // encode returns the binary-encoding of the Riegeli record chunk.
-func (r *recordChunkWriter) encode() ([]byte, error) {
-	if err := r.Close(); err != nil {
-		return nil, err
+func (r *recordChunkWriter) encode() (chunkType, []byte, error) {
+	defer r.Close()
+
+	sizes, err := compress(r.opts, r.sizes.Bytes())
+	if err != nil {
+		return 0, nil, fmt.Errorf("compressing record sizes: %v", err)
+	}
+	vals, err := compress(r.opts, r.vals.Bytes())
+	if err != nil {
+		return 0, nil, fmt.Errorf("compressing record values: %v", err)
 	}
 
-	sizesSizePrefix := make([]byte, binary.MaxVarintLen64)
-	n := binary.PutUvarint(sizesSizePrefix[:], uint64(r.sizesCompressor.Len()))
-	sizesSizePrefix = sizesSizePrefix[:n]
+	var sizesSizePrefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(sizesSizePrefix[:], uint64(len(sizes)))
 
 	// TODO(schroederc): reuse buffers
-	buf := bytes.NewBuffer(make([]byte, 0, 1+len(sizesSizePrefix)+r.sizesCompressor.Len()+r.valsCompressor.Len()))
+	buf := bytes.NewBuffer(make([]byte, 0, 1+n+len(sizes)+len(vals)))
 
-	buf.WriteByte(byte(r.compressionType))
-	buf.Write(sizesSizePrefix)
-	r.sizesCompressor.WriteTo(buf)
-	r.valsCompressor.WriteTo(buf)
+	buf.WriteByte(byte(r.opts.compressionType()))
+	buf.Write(sizesSizePrefix[:n])
+	buf.Write(sizes)
+	buf.Write(vals)
 
-	return buf.Bytes(), nil
+	return recordChunkType, buf.Bytes(), nil
 }
 
-func newRecordChunkWriter(opts *WriterOptions) (*recordChunkWriter, error) {
-	vals, err := newCompressor(opts)
-	if err != nil {
-		return nil, err
-	}
-	sizes, err := newCompressor(opts)
-	if err != nil {
-		return nil, err
-	}
-	return &recordChunkWriter{
-		compressionType: opts.compressionType(),
-		valsCompressor:  vals,
-		sizesCompressor: sizes,
-	}, nil
+func newRecordChunkWriter(opts *WriterOptions) *recordChunkWriter {
+	return &recordChunkWriter{opts: opts}
 }
 
 // WriteTo implements the io.WriterTo interface for chunkHeaders.
@@ -22,6 +22,9 @@ type FindRequest struct {
 	Identifier           string   `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
 	Corpus               []string `protobuf:"bytes,2,rep,name=corpus" json:"corpus,omitempty"`
 	Languages            []string `protobuf:"bytes,3,rep,name=languages" json:"languages,omitempty"`
+	Fuzzy                bool     `protobuf:"varint,4,opt,name=fuzzy" json:"fuzzy,omitempty"`
+	PageSize             int32    `protobuf:"varint,5,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,6,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -31,7 +34,7 @@ func (m *FindRequest) Reset()         { *m = FindRequest{} }
 func (m *FindRequest) String() string { return proto.CompactTextString(m) }
 func (*FindRequest) ProtoMessage()    {}
 func (*FindRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_identifier_00b2b7435e9cc3ea, []int{0}
+	return fileDescriptor_identifier_465fa12eeef26de5, []int{0}
 }
 func (m *FindRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindRequest.Unmarshal(m, b)
@@ -72,8 +75,30 @@ func (m *FindRequest) GetLanguages() []string {
 	return nil
 }
 
+func (m *FindRequest) GetFuzzy() bool {
+	if m != nil {
+		return m.Fuzzy
+	}
+	return false
+}
+
+func (m *FindRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *FindRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type FindReply struct {
 	Matches              []*FindReply_Match `protobuf:"bytes,1,rep,name=matches" json:"matches,omitempty"`
+	NextPageToken        string             `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -83,7 +108,7 @@ func (m *FindReply) Reset()         { *m = FindReply{} }
 func (m *FindReply) String() string { return proto.CompactTextString(m) }
 func (*FindReply) ProtoMessage()    {}
 func (*FindReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_identifier_00b2b7435e9cc3ea, []int{1}
+	return fileDescriptor_identifier_465fa12eeef26de5, []int{1}
 }
 func (m *FindReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindReply.Unmarshal(m, b)
@@ -110,6 +135,13 @@ func (m *FindReply) GetMatches() []*FindReply_Match {
 	return nil
 }
 
+func (m *FindReply) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type FindReply_Match struct {
 	Ticket               string   `protobuf:"bytes,1,opt,name=ticket" json:"ticket,omitempty"`
 	NodeKind             string   `protobuf:"bytes,2,opt,name=node_kind,json=nodeKind" json:"node_kind,omitempty"`
@@ -125,7 +157,7 @@ func (m *FindReply_Match) Reset()         { *m = FindReply_Match{} }
 func (m *FindReply_Match) String() string { return proto.CompactTextString(m) }
 func (*FindReply_Match) ProtoMessage()    {}
 func (*FindReply_Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_identifier_00b2b7435e9cc3ea, []int{1, 0}
+	return fileDescriptor_identifier_465fa12eeef26de5, []int{1, 0}
 }
 func (m *FindReply_Match) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindReply_Match.Unmarshal(m, b)
@@ -187,29 +219,34 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("kythe/proto/identifier.proto", fileDescriptor_identifier_00b2b7435e9cc3ea)
-}
-
-var fileDescriptor_identifier_00b2b7435e9cc3ea = []byte{
-	// 315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xd1, 0x6a, 0xf2, 0x30,
-	0x14, 0xc7, 0xa9, 0x55, 0xbf, 0xaf, 0xa7, 0xdb, 0x60, 0xb9, 0x90, 0xe0, 0x64, 0x53, 0x61, 0xe0,
-	0x55, 0x05, 0x07, 0x63, 0xd7, 0xbb, 0x18, 0x8c, 0xe1, 0x2e, 0xea, 0x03, 0x48, 0x4c, 0xce, 0x6a,
-	0xb0, 0x4d, 0xb4, 0x49, 0x05, 0x9f, 0x67, 0x4f, 0xb7, 0xb7, 0x18, 0x49, 0x9d, 0xf6, 0xc2, 0xab,
-	0x72, 0x7e, 0xff, 0x5f, 0xe8, 0x39, 0x7f, 0x18, 0x6c, 0x0e, 0x76, 0x8d, 0xd3, 0x6d, 0xa9, 0xad,
-	0x9e, 0x4a, 0x81, 0xca, 0xca, 0x2f, 0x89, 0x65, 0xe2, 0x01, 0x89, 0x7d, 0x5a, 0x0f, 0x63, 0x0e,
-	0xf1, 0x9b, 0x54, 0x22, 0xc5, 0x5d, 0x85, 0xc6, 0x92, 0x7b, 0x80, 0xb3, 0x4f, 0x83, 0x61, 0x30,
-	0x89, 0xd2, 0x06, 0x21, 0x3d, 0xe8, 0x72, 0x5d, 0x6e, 0x2b, 0x43, 0x5b, 0xc3, 0x70, 0x12, 0xa5,
-	0xc7, 0x89, 0x0c, 0x20, 0xca, 0x99, 0xca, 0x2a, 0x96, 0xa1, 0xa1, 0xa1, 0x8f, 0xce, 0x60, 0xfc,
-	0x13, 0x40, 0x54, 0xff, 0x65, 0x9b, 0x1f, 0xc8, 0x33, 0xfc, 0x2b, 0x98, 0xe5, 0x6b, 0x34, 0x34,
-	0x18, 0x86, 0x93, 0x78, 0x36, 0x48, 0x1a, 0x1b, 0x25, 0x27, 0x31, 0x99, 0x3b, 0x2b, 0xfd, 0x93,
-	0xfb, 0xdf, 0x01, 0x74, 0x3c, 0x72, 0x5b, 0x58, 0xc9, 0x37, 0x68, 0x8f, 0x1b, 0x1e, 0x27, 0x72,
-	0x07, 0x91, 0xd2, 0x02, 0x97, 0x1b, 0xa9, 0x04, 0x6d, 0xf9, 0xe8, 0xbf, 0x03, 0x1f, 0x52, 0x09,
-	0x32, 0x82, 0x2b, 0x1f, 0x9a, 0x6a, 0xe5, 0xf3, 0xd0, 0xe7, 0xb1, 0x63, 0x8b, 0x1a, 0xb9, 0xf7,
-	0x2b, 0x66, 0x70, 0xa9, 0x58, 0x81, 0xb4, 0x5d, 0xbf, 0x77, 0xe0, 0x93, 0x15, 0x48, 0x1e, 0xe1,
-	0x66, 0x57, 0xb1, 0xdc, 0xf5, 0x20, 0x6a, 0xa3, 0xe3, 0x8d, 0xeb, 0x13, 0x75, 0xda, 0x6c, 0x0e,
-	0xb7, 0xef, 0xa7, 0xbe, 0x16, 0x58, 0xee, 0x25, 0x47, 0xf2, 0x02, 0x6d, 0x77, 0x16, 0xa1, 0x17,
-	0x2e, 0xf5, 0xc5, 0xf7, 0x7b, 0x97, 0x3b, 0x78, 0x1d, 0xc1, 0x03, 0xd7, 0x45, 0x92, 0x69, 0x9d,
-	0xe5, 0x98, 0x08, 0xdc, 0x5b, 0xad, 0x73, 0xd3, 0x94, 0x57, 0x5d, 0xff, 0x79, 0xfa, 0x0d, 0x00,
-	0x00, 0xff, 0xff, 0x5b, 0x5e, 0x58, 0x2b, 0xf6, 0x01, 0x00, 0x00,
+	proto.RegisterFile("kythe/proto/identifier.proto", fileDescriptor_identifier_465fa12eeef26de5)
+}
+
+var fileDescriptor_identifier_465fa12eeef26de5 = []byte{
+	// 385 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xdd, 0x8a, 0xd3, 0x40,
+	0x14, 0xc7, 0x49, 0xb3, 0xa9, 0xc9, 0x89, 0x1f, 0x38, 0xc8, 0x32, 0xac, 0x55, 0xb3, 0x0b, 0x4a,
+	0xae, 0xb2, 0xb0, 0x82, 0x78, 0xed, 0x85, 0x20, 0xb2, 0x22, 0xa9, 0xf7, 0x61, 0x9a, 0x9c, 0xcd,
+	0x0e, 0x49, 0x66, 0xd2, 0xcc, 0xa4, 0xd8, 0xbc, 0x85, 0xcf, 0xe0, 0x33, 0xf8, 0x7e, 0x32, 0x93,
+	0xb4, 0xcd, 0x45, 0xaf, 0x86, 0xf3, 0xfb, 0xff, 0xcf, 0x9c, 0x2f, 0x58, 0x55, 0x7b, 0xfd, 0x88,
+	0xb7, 0x6d, 0x27, 0xb5, 0xbc, 0xe5, 0x05, 0x0a, 0xcd, 0x1f, 0x38, 0x76, 0x89, 0x05, 0x24, 0xb4,
+	0xea, 0x18, 0xdc, 0xfc, 0x73, 0x20, 0xfc, 0xca, 0x45, 0x91, 0xe2, 0xb6, 0x47, 0xa5, 0xc9, 0x5b,
+	0x80, 0x53, 0x02, 0x75, 0x22, 0x27, 0x0e, 0xd2, 0x19, 0x21, 0x97, 0xb0, 0xcc, 0x65, 0xd7, 0xf6,
+	0x8a, 0x2e, 0x22, 0x37, 0x0e, 0xd2, 0x29, 0x22, 0x2b, 0x08, 0x6a, 0x26, 0xca, 0x9e, 0x95, 0xa8,
+	0xa8, 0x6b, 0xa5, 0x13, 0x20, 0xaf, 0xc0, 0x7b, 0xe8, 0x87, 0x61, 0x4f, 0x2f, 0x22, 0x27, 0xf6,
+	0xd3, 0x31, 0x20, 0xaf, 0x21, 0x68, 0x59, 0x89, 0x99, 0xe2, 0x03, 0x52, 0x2f, 0x72, 0x62, 0x2f,
+	0xf5, 0x0d, 0x58, 0xf3, 0x01, 0xc9, 0x1b, 0x00, 0x2b, 0x6a, 0x59, 0xa1, 0xa0, 0x4b, 0xdb, 0x88,
+	0xb5, 0xff, 0x32, 0xe0, 0xe6, 0xcf, 0x02, 0x82, 0xb1, 0xef, 0xb6, 0xde, 0x93, 0x4f, 0xf0, 0xa4,
+	0x61, 0x3a, 0x7f, 0x44, 0x45, 0x9d, 0xc8, 0x8d, 0xc3, 0xbb, 0x55, 0x32, 0x1b, 0x32, 0x39, 0x1a,
+	0x93, 0x7b, 0xe3, 0x4a, 0x0f, 0x66, 0xf2, 0x01, 0x5e, 0x08, 0xfc, 0xad, 0xb3, 0x59, 0xa5, 0x85,
+	0xad, 0xf4, 0xcc, 0xe0, 0x9f, 0x87, 0x6a, 0x57, 0x7f, 0x1d, 0xf0, 0x6c, 0xaa, 0x99, 0x5f, 0xf3,
+	0xbc, 0x42, 0x3d, 0xed, 0x66, 0x8a, 0xcc, 0x2c, 0x42, 0x16, 0x98, 0x55, 0x5c, 0x14, 0xd3, 0x1f,
+	0xbe, 0x01, 0xdf, 0xb9, 0x28, 0xc8, 0x35, 0x3c, 0xb5, 0xa2, 0xea, 0x37, 0x56, 0x77, 0xad, 0x1e,
+	0x1a, 0xb6, 0x1e, 0x91, 0xc9, 0xdf, 0x30, 0x85, 0x99, 0x60, 0x0d, 0xda, 0x2d, 0x05, 0xa9, 0x6f,
+	0xc0, 0x0f, 0xd6, 0x20, 0x79, 0x0f, 0xcf, 0xb7, 0x3d, 0xab, 0xcd, 0x05, 0x8a, 0xd1, 0xe1, 0x8d,
+	0x5d, 0x1e, 0xa9, 0xb1, 0xdd, 0xdd, 0xc3, 0xcb, 0x6f, 0xc7, 0x4b, 0xad, 0xb1, 0xdb, 0xf1, 0x1c,
+	0xc9, 0x67, 0xb8, 0x30, 0xe3, 0x13, 0x7a, 0x66, 0x23, 0xf6, 0xe4, 0x57, 0x97, 0xe7, 0x77, 0xf5,
+	0xe5, 0x1a, 0xde, 0xe5, 0xb2, 0x49, 0x4a, 0x29, 0xcb, 0x1a, 0x93, 0x02, 0x77, 0x5a, 0xca, 0x5a,
+	0xcd, 0xcd, 0x9b, 0xa5, 0x7d, 0x3e, 0xfe, 0x1f, 0x00, 0xbc, 0x88, 0x85, 0xb7, 0x71, 0x02, 0x00,
+	0x00,
 }
@@ -6,6 +6,7 @@ package pipeline_go_proto
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common_go_proto "kythe.io/kythe/proto/common_go_proto"
 import schema_go_proto "kythe.io/kythe/proto/schema_go_proto"
 import serving_go_proto "kythe.io/kythe/proto/serving_go_proto"
 import storage_go_proto "kythe.io/kythe/proto/storage_go_proto"
@@ -42,7 +43,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{0}
+	return fileDescriptor_pipeline_101860d29c344659, []int{0}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -271,7 +272,7 @@ func (m *Fact) Reset()         { *m = Fact{} }
 func (m *Fact) String() string { return proto.CompactTextString(m) }
 func (*Fact) ProtoMessage()    {}
 func (*Fact) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{1}
+	return fileDescriptor_pipeline_101860d29c344659, []int{1}
 }
 func (m *Fact) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Fact.Unmarshal(m, b)
@@ -424,7 +425,7 @@ func (m *Edge) Reset()         { *m = Edge{} }
 func (m *Edge) String() string { return proto.CompactTextString(m) }
 func (*Edge) ProtoMessage()    {}
 func (*Edge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{2}
+	return fileDescriptor_pipeline_101860d29c344659, []int{2}
 }
 func (m *Edge) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Edge.Unmarshal(m, b)
@@ -596,7 +597,7 @@ func (m *Reference) Reset()         { *m = Reference{} }
 func (m *Reference) String() string { return proto.CompactTextString(m) }
 func (*Reference) ProtoMessage()    {}
 func (*Reference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{3}
+	return fileDescriptor_pipeline_101860d29c344659, []int{3}
 }
 func (m *Reference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Reference.Unmarshal(m, b)
@@ -744,6 +745,8 @@ type DecorationPiece struct {
 	//	*DecorationPiece_Reference
 	//	*DecorationPiece_Node
 	//	*DecorationPiece_Definition_
+	//	*DecorationPiece_Override
+	//	*DecorationPiece_Diagnostic
 	Piece                isDecorationPiece_Piece `protobuf_oneof:"piece"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -754,7 +757,7 @@ func (m *DecorationPiece) Reset()         { *m = DecorationPiece{} }
 func (m *DecorationPiece) String() string { return proto.CompactTextString(m) }
 func (*DecorationPiece) ProtoMessage()    {}
 func (*DecorationPiece) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{4}
+	return fileDescriptor_pipeline_101860d29c344659, []int{4}
 }
 func (m *DecorationPiece) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationPiece.Unmarshal(m, b)
@@ -790,11 +793,19 @@ type DecorationPiece_Node struct {
 type DecorationPiece_Definition_ struct {
 	Definition *DecorationPiece_Definition `protobuf:"bytes,5,opt,name=definition,oneof"`
 }
+type DecorationPiece_Override struct {
+	Override *serving_go_proto.FileDecorations_Override `protobuf:"bytes,6,opt,name=override,oneof"`
+}
+type DecorationPiece_Diagnostic struct {
+	Diagnostic *common_go_proto.Diagnostic `protobuf:"bytes,7,opt,name=diagnostic,oneof"`
+}
 
 func (*DecorationPiece_File) isDecorationPiece_Piece()        {}
 func (*DecorationPiece_Reference) isDecorationPiece_Piece()   {}
 func (*DecorationPiece_Node) isDecorationPiece_Piece()        {}
 func (*DecorationPiece_Definition_) isDecorationPiece_Piece() {}
+func (*DecorationPiece_Override) isDecorationPiece_Piece()    {}
+func (*DecorationPiece_Diagnostic) isDecorationPiece_Piece()  {}
 
 func (m *DecorationPiece) GetPiece() isDecorationPiece_Piece {
 	if m != nil {
@@ -838,6 +849,20 @@ func (m *DecorationPiece) GetDefinition() *DecorationPiece_Definition {
 	return nil
 }
 
+func (m *DecorationPiece) GetOverride() *serving_go_proto.FileDecorations_Override {
+	if x, ok := m.GetPiece().(*DecorationPiece_Override); ok {
+		return x.Override
+	}
+	return nil
+}
+
+func (m *DecorationPiece) GetDiagnostic() *common_go_proto.Diagnostic {
+	if x, ok := m.GetPiece().(*DecorationPiece_Diagnostic); ok {
+		return x.Diagnostic
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DecorationPiece) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DecorationPiece_OneofMarshaler, _DecorationPiece_OneofUnmarshaler, _DecorationPiece_OneofSizer, []interface{}{
@@ -845,6 +870,8 @@ func (*DecorationPiece) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffe
 		(*DecorationPiece_Reference)(nil),
 		(*DecorationPiece_Node)(nil),
 		(*DecorationPiece_Definition_)(nil),
+		(*DecorationPiece_Override)(nil),
+		(*DecorationPiece_Diagnostic)(nil),
 	}
 }
 
@@ -872,6 +899,16 @@ func _DecorationPiece_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Definition); err != nil {
 			return err
 		}
+	case *DecorationPiece_Override:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Override); err != nil {
+			return err
+		}
+	case *DecorationPiece_Diagnostic:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Diagnostic); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DecorationPiece.Piece has unexpected type %T", x)
@@ -914,6 +951,22 @@ func _DecorationPiece_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Piece = &DecorationPiece_Definition_{msg}
 		return true, err
+	case 6: // piece.override
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(serving_go_proto.FileDecorations_Override)
+		err := b.DecodeMessage(msg)
+		m.Piece = &DecorationPiece_Override{msg}
+		return true, err
+	case 7: // piece.diagnostic
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common_go_proto.Diagnostic)
+		err := b.DecodeMessage(msg)
+		m.Piece = &DecorationPiece_Diagnostic{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -943,6 +996,16 @@ func _DecorationPiece_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DecorationPiece_Override:
+		s := proto.Size(x.Override)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *DecorationPiece_Diagnostic:
+		s := proto.Size(x.Diagnostic)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -962,7 +1025,7 @@ func (m *DecorationPiece_Definition) Reset()         { *m = DecorationPiece_Defi
 func (m *DecorationPiece_Definition) String() string { return proto.CompactTextString(m) }
 func (*DecorationPiece_Definition) ProtoMessage()    {}
 func (*DecorationPiece_Definition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pipeline_31b70f6420ca9d7a, []int{4, 0}
+	return fileDescriptor_pipeline_101860d29c344659, []int{4, 0}
 }
 func (m *DecorationPiece_Definition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationPiece_Definition.Unmarshal(m, b)
@@ -1006,50 +1069,53 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("kythe/proto/pipeline.proto", fileDescriptor_pipeline_31b70f6420ca9d7a)
-}
-
-var fileDescriptor_pipeline_31b70f6420ca9d7a = []byte{
-	// 645 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xcf, 0x6a, 0xdb, 0x4e,
-	0x10, 0xc7, 0x23, 0x59, 0xb6, 0xf1, 0x28, 0xbf, 0x04, 0x96, 0x1c, 0x14, 0xff, 0x0a, 0x71, 0x1d,
-	0x28, 0x6e, 0x0e, 0x4a, 0x70, 0x8f, 0xa1, 0x94, 0xba, 0x49, 0x30, 0x14, 0x42, 0xd9, 0x42, 0xaf,
-	0x61, 0x23, 0x8d, 0x95, 0x25, 0xf2, 0xae, 0x91, 0x15, 0xd3, 0xbc, 0x42, 0x9f, 0xa7, 0x2f, 0xd0,
-	0x17, 0xe9, 0x73, 0xf4, 0x58, 0x76, 0x76, 0xed, 0xda, 0x41, 0x6a, 0x9a, 0xf6, 0x24, 0x8d, 0xe7,
-	0x33, 0xff, 0xbe, 0x33, 0x32, 0x74, 0x6f, 0xef, 0xcb, 0x1b, 0x3c, 0x9e, 0x15, 0xba, 0xd4, 0xc7,
-	0x33, 0x39, 0xc3, 0x5c, 0x2a, 0x8c, 0xc9, 0x64, 0x7b, 0xe4, 0xb3, 0x46, 0xbc, 0xf4, 0x75, 0xa3,
-	0xf5, 0x88, 0x79, 0x72, 0x83, 0x53, 0x61, 0x91, 0xee, 0xfe, 0x86, 0x07, 0x8b, 0x85, 0x54, 0x59,
-	0xa5, 0xab, 0xd4, 0x85, 0xc8, 0x5c, 0xe2, 0xfe, 0x0f, 0x1f, 0x82, 0x4b, 0x9d, 0x22, 0x3b, 0x82,
-	0xd6, 0x5c, 0xdf, 0x15, 0x09, 0x46, 0x5e, 0xcf, 0x1b, 0x84, 0x43, 0x16, 0xaf, 0xd7, 0xff, 0x74,
-	0x29, 0xa6, 0xc8, 0x1d, 0xc1, 0x62, 0x08, 0x26, 0x22, 0x29, 0x23, 0xbf, 0xd7, 0x18, 0x84, 0xc3,
-	0x6e, 0x5c, 0xd5, 0x69, 0x7c, 0x21, 0x92, 0x92, 0x13, 0x67, 0x78, 0x4c, 0x33, 0x8c, 0x1a, 0xbf,
-	0xe3, 0xcf, 0xd3, 0x0c, 0x39, 0x71, 0xec, 0x35, 0x00, 0x21, 0x57, 0xb7, 0x52, 0xa5, 0x51, 0xd0,
-	0xf3, 0x06, 0x3b, 0xc3, 0x67, 0x1b, 0x51, 0x6e, 0x72, 0xd3, 0xf9, 0x7b, 0xa9, 0xd2, 0xf1, 0x16,
-	0xef, 0x90, 0xdb, 0x18, 0xec, 0x10, 0xb6, 0x33, 0x54, 0x58, 0xc8, 0xc4, 0x26, 0x68, 0xf6, 0xbc,
-	0x41, 0x67, 0xbc, 0xc5, 0x43, 0xf7, 0x2b, 0x41, 0x23, 0xf8, 0xcf, 0xd6, 0x98, 0xdf, 0x5d, 0x13,
-	0xd5, 0xa2, 0x32, 0xff, 0x57, 0x95, 0xf9, 0x68, 0x91, 0xb1, 0xc7, 0xb7, 0xc9, 0xeb, 0x6c, 0xf6,
-	0x12, 0x76, 0x97, 0x85, 0x96, 0x59, 0xda, 0x54, 0xcb, 0xe3, 0x3b, 0xce, 0xe1, 0xd0, 0x51, 0x0b,
-	0x02, 0x7a, 0x76, 0xa0, 0xed, 0xd0, 0xfe, 0x57, 0x0f, 0x02, 0x23, 0xd2, 0x93, 0xa4, 0x5f, 0x49,
-	0xa3, 0xc4, 0x14, 0x23, 0xbf, 0x5e, 0x1a, 0x93, 0xd9, 0x44, 0xae, 0xa4, 0x31, 0xc6, 0xba, 0x34,
-	0x94, 0xa0, 0xf1, 0x40, 0x1a, 0x82, 0xf6, 0xa0, 0xb9, 0x10, 0xf9, 0x1d, 0x92, 0xf2, 0xdb, 0xdc,
-	0x1a, 0x66, 0x02, 0x13, 0xd2, 0xff, 0xee, 0x43, 0x60, 0x76, 0xf5, 0xa4, 0xb6, 0x8f, 0xa0, 0x55,
-	0x8a, 0x22, 0xc3, 0x92, 0x5a, 0xae, 0x61, 0x2d, 0xf1, 0x60, 0xfb, 0x8d, 0xfa, 0x11, 0x4d, 0x17,
-	0x8f, 0x6f, 0x3f, 0xa8, 0xda, 0x7e, 0x04, 0x6d, 0x5d, 0xa4, 0x52, 0x89, 0x9c, 0xae, 0xa3, 0xc9,
-	0x97, 0x26, 0x3b, 0x85, 0xd0, 0xf6, 0x7c, 0xa5, 0x74, 0x8a, 0x74, 0x15, 0xb5, 0x27, 0x6b, 0xce,
-	0x8f, 0x83, 0xc5, 0xe9, 0x23, 0x3a, 0x85, 0xd0, 0x0e, 0x61, 0x83, 0xdb, 0x8f, 0x07, 0x5b, 0xdc,
-	0xbc, 0x2f, 0x4f, 0xa4, 0xff, 0xc5, 0x87, 0x0e, 0xc7, 0x09, 0x16, 0xa8, 0x12, 0xfc, 0xbb, 0xe3,
-	0xa0, 0xc1, 0xfd, 0x7f, 0x55, 0xae, 0x51, 0xa5, 0xdc, 0x29, 0xb4, 0x84, 0x4a, 0x6e, 0x74, 0x41,
-	0xc2, 0x86, 0xc3, 0xc3, 0xcd, 0xfc, 0xee, 0x7f, 0xe7, 0xfc, 0xf3, 0x4c, 0xa8, 0x14, 0xd3, 0xb7,
-	0x84, 0x72, 0x17, 0xc2, 0x06, 0xd0, 0x9c, 0x27, 0x7a, 0x86, 0x24, 0x7a, 0xf5, 0x2c, 0x16, 0x58,
-	0x89, 0xf1, 0xad, 0x01, 0xbb, 0x67, 0x98, 0xe8, 0x42, 0x94, 0x52, 0xab, 0x0f, 0x12, 0x13, 0x64,
-	0x43, 0x08, 0x27, 0x32, 0xc7, 0xab, 0x85, 0xbd, 0xe1, 0x7a, 0x5d, 0x3a, 0x06, 0xa3, 0x57, 0x76,
-	0x0c, 0x81, 0x31, 0xdc, 0xf9, 0xed, 0x57, 0x36, 0x7d, 0x21, 0x73, 0xf3, 0xb9, 0x10, 0xc8, 0xde,
-	0x40, 0xa7, 0x58, 0x2e, 0x81, 0x94, 0x08, 0x87, 0x07, 0xd5, 0x8b, 0x5c, 0xed, 0xca, 0xa8, 0xb9,
-	0x8a, 0x61, 0x27, 0x10, 0xd0, 0x11, 0x04, 0x8f, 0x1d, 0x81, 0x29, 0x69, 0x48, 0xc6, 0x01, 0x52,
-	0x9c, 0x48, 0x25, 0xcd, 0xa8, 0x4e, 0xa2, 0x93, 0xea, 0xb8, 0x07, 0x92, 0xc4, 0x67, 0xab, 0xb8,
-	0xf1, 0x16, 0x5f, 0xcb, 0xd2, 0xbd, 0x07, 0xf8, 0xe5, 0x63, 0x2f, 0x5c, 0x4f, 0xf5, 0x92, 0xd9,
-	0x4e, 0xde, 0x6d, 0x74, 0xe2, 0xff, 0xf9, 0xa2, 0xd7, 0xc2, 0x46, 0x6d, 0x68, 0xce, 0x4c, 0x73,
-	0xa3, 0xe7, 0x70, 0x90, 0xe8, 0x69, 0x9c, 0x69, 0x9d, 0xe5, 0x18, 0xa7, 0xb8, 0x28, 0xb5, 0xce,
-	0xe7, 0xeb, 0xe9, 0xae, 0x5b, 0xf4, 0x78, 0xf5, 0x33, 0x00, 0x00, 0xff, 0xff, 0x4e, 0xf7, 0xc4,
-	0x76, 0x11, 0x07, 0x00, 0x00,
+	proto.RegisterFile("kythe/proto/pipeline.proto", fileDescriptor_pipeline_101860d29c344659)
+}
+
+var fileDescriptor_pipeline_101860d29c344659 = []byte{
+	// 697 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
+	0x10, 0x8e, 0x1d, 0x27, 0x39, 0x19, 0xf7, 0xb4, 0xd2, 0xaa, 0x17, 0x6e, 0xce, 0x11, 0x0d, 0xa9,
+	0x84, 0x42, 0x25, 0xdc, 0x2a, 0x5c, 0x56, 0x08, 0x08, 0x6d, 0x15, 0xa9, 0x52, 0x41, 0x8b, 0xc4,
+	0x6d, 0xe5, 0xda, 0x13, 0x77, 0x55, 0x67, 0x37, 0x5a, 0xbb, 0x11, 0x7d, 0x05, 0x9e, 0x87, 0x07,
+	0xe1, 0x09, 0x78, 0x0e, 0x2e, 0xd1, 0xfe, 0xc4, 0x4d, 0x2a, 0x9b, 0x52, 0xb8, 0xb2, 0x27, 0xf3,
+	0x7d, 0xf3, 0xf3, 0xcd, 0x97, 0x04, 0x7a, 0xd7, 0xb7, 0xc5, 0x15, 0x1e, 0xcc, 0xa5, 0x28, 0xc4,
+	0xc1, 0x9c, 0xcd, 0x31, 0x63, 0x1c, 0x43, 0x1d, 0x92, 0x6d, 0x9d, 0x33, 0x41, 0xb8, 0xcc, 0xf5,
+	0x82, 0x55, 0x46, 0x2c, 0x66, 0x33, 0xc1, 0x0d, 0x64, 0x3d, 0x93, 0xc7, 0x57, 0x38, 0x8b, 0x6c,
+	0x66, 0x67, 0x2d, 0x83, 0x72, 0xc1, 0x78, 0x5a, 0x99, 0x2a, 0x84, 0x8c, 0x52, 0xdb, 0x72, 0xf0,
+	0xc3, 0x05, 0xef, 0x5c, 0x24, 0x48, 0xf6, 0xa1, 0x9d, 0x8b, 0x1b, 0x19, 0x63, 0xe0, 0xf4, 0x9d,
+	0xa1, 0x3f, 0x22, 0xe1, 0xea, 0x64, 0x9f, 0xce, 0xa3, 0x19, 0x52, 0x8b, 0x20, 0x21, 0x78, 0xd3,
+	0x28, 0x2e, 0x02, 0xb7, 0xdf, 0x1c, 0xfa, 0xa3, 0x5e, 0x58, 0xb5, 0x43, 0x78, 0x1a, 0xc5, 0x05,
+	0xd5, 0x38, 0x85, 0xc7, 0x24, 0xc5, 0xa0, 0xf9, 0x2b, 0xfc, 0x49, 0x92, 0x22, 0xd5, 0x38, 0xf2,
+	0x0a, 0x40, 0x43, 0x2e, 0xae, 0x19, 0x4f, 0x02, 0xaf, 0xef, 0x0c, 0x37, 0x47, 0xff, 0xaf, 0xb1,
+	0xec, 0xe6, 0x6a, 0xf2, 0x33, 0xc6, 0x93, 0x49, 0x83, 0x76, 0x75, 0x5a, 0x05, 0x64, 0x0f, 0x36,
+	0x52, 0xe4, 0x28, 0x59, 0x6c, 0x0a, 0xb4, 0xfa, 0xce, 0xb0, 0x3b, 0x69, 0x50, 0xdf, 0x7e, 0xaa,
+	0x41, 0x63, 0xf8, 0xd7, 0xf4, 0xc8, 0x6f, 0x2e, 0x35, 0xaa, 0xad, 0xdb, 0xfc, 0x57, 0xd5, 0xe6,
+	0xa3, 0x81, 0x4c, 0x1c, 0xba, 0xa1, 0xb3, 0x36, 0x26, 0xcf, 0x61, 0x6b, 0xd9, 0x68, 0x59, 0xa5,
+	0xa3, 0x7b, 0x39, 0x74, 0xd3, 0x26, 0x2c, 0x74, 0xdc, 0x06, 0x4f, 0x3f, 0xbb, 0xd0, 0xb1, 0xd0,
+	0xc1, 0x57, 0x07, 0x3c, 0x25, 0xd2, 0xa3, 0xa4, 0x2f, 0xa5, 0xe1, 0xd1, 0x0c, 0x03, 0xb7, 0x5e,
+	0x1a, 0x55, 0x59, 0x31, 0x4b, 0x69, 0x54, 0xb0, 0x2a, 0x8d, 0x2e, 0xd0, 0xbc, 0x27, 0x8d, 0x06,
+	0x6d, 0x43, 0x6b, 0x11, 0x65, 0x37, 0xa8, 0x95, 0xdf, 0xa0, 0x26, 0x50, 0x1b, 0x28, 0xca, 0xe0,
+	0xbb, 0x0b, 0x9e, 0xba, 0xd5, 0xa3, 0xc6, 0xde, 0x87, 0x76, 0x11, 0xc9, 0x14, 0x8b, 0xc0, 0xad,
+	0xc7, 0x1a, 0xc4, 0xbd, 0xeb, 0x37, 0xeb, 0x57, 0x54, 0x53, 0x3c, 0x7c, 0x7d, 0xaf, 0xea, 0xfa,
+	0x01, 0x74, 0x84, 0x4c, 0x18, 0x8f, 0x32, 0xed, 0x8e, 0x16, 0x5d, 0x86, 0xe4, 0x08, 0x7c, 0x33,
+	0xf3, 0x05, 0x17, 0x09, 0x6a, 0x57, 0xd4, 0x5a, 0x56, 0xd9, 0x8f, 0x82, 0x81, 0xab, 0x77, 0x45,
+	0x36, 0x4b, 0x18, 0x72, 0xe7, 0x61, 0xb2, 0x81, 0xab, 0xf7, 0xa5, 0x45, 0x06, 0x5f, 0x5c, 0xe8,
+	0x52, 0x9c, 0xa2, 0x44, 0x1e, 0xe3, 0x9f, 0x99, 0x43, 0x2f, 0xee, 0xfe, 0xad, 0x72, 0xcd, 0x2a,
+	0xe5, 0x8e, 0xa0, 0x1d, 0xf1, 0xf8, 0x4a, 0x48, 0x2d, 0xac, 0x3f, 0xda, 0x5b, 0xaf, 0x6f, 0x7f,
+	0x77, 0x4e, 0x3e, 0xcf, 0x23, 0x9e, 0x60, 0xf2, 0x56, 0x43, 0xa9, 0xa5, 0x90, 0x21, 0xb4, 0xf2,
+	0x58, 0xcc, 0x31, 0x68, 0xd5, 0xee, 0x62, 0x00, 0xa5, 0x18, 0xdf, 0x3c, 0xd8, 0x3a, 0xc6, 0x58,
+	0xc8, 0xa8, 0x60, 0x82, 0x7f, 0x60, 0x18, 0x23, 0x19, 0x81, 0x3f, 0x65, 0x19, 0x5e, 0x2c, 0x8c,
+	0x87, 0xeb, 0x75, 0xe9, 0x2a, 0x98, 0x7e, 0x25, 0x07, 0xe0, 0xa9, 0xc0, 0xda, 0x6f, 0xa7, 0x72,
+	0xe8, 0x53, 0x96, 0xa9, 0xaf, 0x8b, 0x06, 0x92, 0xd7, 0xd0, 0x95, 0xcb, 0x23, 0x68, 0x25, 0xfc,
+	0xd1, 0x6e, 0xf5, 0x21, 0xcb, 0x5b, 0x29, 0x35, 0x4b, 0x0e, 0x39, 0x04, 0x4f, 0x9b, 0xc0, 0x7b,
+	0xc8, 0x04, 0xaa, 0xa5, 0x42, 0x12, 0x0a, 0x90, 0xe0, 0x94, 0x71, 0xa6, 0x56, 0xb5, 0x12, 0x1d,
+	0x56, 0xf3, 0xee, 0x49, 0x12, 0x1e, 0x97, 0xbc, 0x49, 0x83, 0xae, 0x54, 0x21, 0x67, 0xf0, 0x8f,
+	0x58, 0xa0, 0x94, 0xac, 0xf4, 0xf2, 0x8b, 0xda, 0xdd, 0xef, 0x8a, 0xe6, 0xe1, 0x7b, 0x4b, 0x9a,
+	0x34, 0x68, 0x59, 0x80, 0xbc, 0x01, 0x48, 0x58, 0x94, 0x72, 0x91, 0x17, 0x2c, 0xb6, 0xee, 0x7e,
+	0xb2, 0x56, 0xce, 0xfe, 0x57, 0x1d, 0x97, 0x28, 0x3d, 0x4e, 0x19, 0xf5, 0x6e, 0x01, 0xee, 0x46,
+	0x25, 0xcf, 0xac, 0x44, 0xf5, 0x17, 0x34, 0xc2, 0xbc, 0x5b, 0x13, 0xc6, 0xfd, 0x7d, 0xdf, 0xad,
+	0xd0, 0xc6, 0x1d, 0x68, 0xcd, 0x95, 0x56, 0xe3, 0xa7, 0xb0, 0x1b, 0x8b, 0x59, 0x98, 0x0a, 0x91,
+	0x66, 0x18, 0x26, 0xb8, 0x28, 0x84, 0xc8, 0xf2, 0xd5, 0x72, 0x97, 0x6d, 0xfd, 0x78, 0xf9, 0x73,
+	0x00, 0x50, 0xe5, 0xb1, 0xb2, 0xba, 0x07, 0x00, 0x00,
 }
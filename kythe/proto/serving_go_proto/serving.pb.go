@@ -39,7 +39,7 @@ func (x FileDecorations_Override_Kind) String() string {
 	return proto.EnumName(FileDecorations_Override_Kind_name, int32(x))
 }
 func (FileDecorations_Override_Kind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{11, 1, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{11, 1, 0}
 }
 
 type Relatives_Type int32
@@ -65,7 +65,7 @@ func (x Relatives_Type) String() string {
 	return proto.EnumName(Relatives_Type_name, int32(x))
 }
 func (Relatives_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{15, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{15, 0}
 }
 
 type Callgraph_Type int32
@@ -91,7 +91,7 @@ func (x Callgraph_Type) String() string {
 	return proto.EnumName(Callgraph_Type_name, int32(x))
 }
 func (Callgraph_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{16, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{16, 0}
 }
 
 type Node struct {
@@ -107,7 +107,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{0}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -163,7 +163,7 @@ func (m *Edge) Reset()         { *m = Edge{} }
 func (m *Edge) String() string { return proto.CompactTextString(m) }
 func (*Edge) ProtoMessage()    {}
 func (*Edge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{1}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{1}
 }
 func (m *Edge) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Edge.Unmarshal(m, b)
@@ -230,7 +230,7 @@ func (m *EdgeGroup) Reset()         { *m = EdgeGroup{} }
 func (m *EdgeGroup) String() string { return proto.CompactTextString(m) }
 func (*EdgeGroup) ProtoMessage()    {}
 func (*EdgeGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{2}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{2}
 }
 func (m *EdgeGroup) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EdgeGroup.Unmarshal(m, b)
@@ -276,7 +276,7 @@ func (m *EdgeGroup_Edge) Reset()         { *m = EdgeGroup_Edge{} }
 func (m *EdgeGroup_Edge) String() string { return proto.CompactTextString(m) }
 func (*EdgeGroup_Edge) ProtoMessage()    {}
 func (*EdgeGroup_Edge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{2, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{2, 0}
 }
 func (m *EdgeGroup_Edge) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EdgeGroup_Edge.Unmarshal(m, b)
@@ -324,7 +324,7 @@ func (m *PagedEdgeSet) Reset()         { *m = PagedEdgeSet{} }
 func (m *PagedEdgeSet) String() string { return proto.CompactTextString(m) }
 func (*PagedEdgeSet) ProtoMessage()    {}
 func (*PagedEdgeSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{3}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{3}
 }
 func (m *PagedEdgeSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedEdgeSet.Unmarshal(m, b)
@@ -386,7 +386,7 @@ func (m *PageIndex) Reset()         { *m = PageIndex{} }
 func (m *PageIndex) String() string { return proto.CompactTextString(m) }
 func (*PageIndex) ProtoMessage()    {}
 func (*PageIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{4}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{4}
 }
 func (m *PageIndex) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PageIndex.Unmarshal(m, b)
@@ -440,7 +440,7 @@ func (m *EdgePage) Reset()         { *m = EdgePage{} }
 func (m *EdgePage) String() string { return proto.CompactTextString(m) }
 func (*EdgePage) ProtoMessage()    {}
 func (*EdgePage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{5}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{5}
 }
 func (m *EdgePage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EdgePage.Unmarshal(m, b)
@@ -493,7 +493,7 @@ func (m *FileDirectory) Reset()         { *m = FileDirectory{} }
 func (m *FileDirectory) String() string { return proto.CompactTextString(m) }
 func (*FileDirectory) ProtoMessage()    {}
 func (*FileDirectory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{6}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{6}
 }
 func (m *FileDirectory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileDirectory.Unmarshal(m, b)
@@ -538,7 +538,7 @@ func (m *CorpusRoots) Reset()         { *m = CorpusRoots{} }
 func (m *CorpusRoots) String() string { return proto.CompactTextString(m) }
 func (*CorpusRoots) ProtoMessage()    {}
 func (*CorpusRoots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{7}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{7}
 }
 func (m *CorpusRoots) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CorpusRoots.Unmarshal(m, b)
@@ -577,7 +577,7 @@ func (m *CorpusRoots_Corpus) Reset()         { *m = CorpusRoots_Corpus{} }
 func (m *CorpusRoots_Corpus) String() string { return proto.CompactTextString(m) }
 func (*CorpusRoots_Corpus) ProtoMessage()    {}
 func (*CorpusRoots_Corpus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{7, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{7, 0}
 }
 func (m *CorpusRoots_Corpus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CorpusRoots_Corpus.Unmarshal(m, b)
@@ -624,7 +624,7 @@ func (m *File) Reset()         { *m = File{} }
 func (m *File) String() string { return proto.CompactTextString(m) }
 func (*File) ProtoMessage()    {}
 func (*File) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{8}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{8}
 }
 func (m *File) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_File.Unmarshal(m, b)
@@ -680,7 +680,7 @@ func (m *RawAnchor) Reset()         { *m = RawAnchor{} }
 func (m *RawAnchor) String() string { return proto.CompactTextString(m) }
 func (*RawAnchor) ProtoMessage()    {}
 func (*RawAnchor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{9}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{9}
 }
 func (m *RawAnchor) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RawAnchor.Unmarshal(m, b)
@@ -751,7 +751,7 @@ func (m *ExpandedAnchor) Reset()         { *m = ExpandedAnchor{} }
 func (m *ExpandedAnchor) String() string { return proto.CompactTextString(m) }
 func (*ExpandedAnchor) ProtoMessage()    {}
 func (*ExpandedAnchor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{10}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{10}
 }
 func (m *ExpandedAnchor) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpandedAnchor.Unmarshal(m, b)
@@ -829,7 +829,7 @@ func (m *FileDecorations) Reset()         { *m = FileDecorations{} }
 func (m *FileDecorations) String() string { return proto.CompactTextString(m) }
 func (*FileDecorations) ProtoMessage()    {}
 func (*FileDecorations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{11}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{11}
 }
 func (m *FileDecorations) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileDecorations.Unmarshal(m, b)
@@ -905,7 +905,7 @@ func (m *FileDecorations_Decoration) Reset()         { *m = FileDecorations_Deco
 func (m *FileDecorations_Decoration) String() string { return proto.CompactTextString(m) }
 func (*FileDecorations_Decoration) ProtoMessage()    {}
 func (*FileDecorations_Decoration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{11, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{11, 0}
 }
 func (m *FileDecorations_Decoration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileDecorations_Decoration.Unmarshal(m, b)
@@ -968,7 +968,7 @@ func (m *FileDecorations_Override) Reset()         { *m = FileDecorations_Overri
 func (m *FileDecorations_Override) String() string { return proto.CompactTextString(m) }
 func (*FileDecorations_Override) ProtoMessage()    {}
 func (*FileDecorations_Override) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{11, 1}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{11, 1}
 }
 func (m *FileDecorations_Override) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FileDecorations_Override.Unmarshal(m, b)
@@ -1041,7 +1041,7 @@ func (m *PagedCrossReferences) Reset()         { *m = PagedCrossReferences{} }
 func (m *PagedCrossReferences) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences) ProtoMessage()    {}
 func (*PagedCrossReferences) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12}
 }
 func (m *PagedCrossReferences) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences.Unmarshal(m, b)
@@ -1130,7 +1130,7 @@ func (m *PagedCrossReferences_RelatedNode) Reset()         { *m = PagedCrossRefe
 func (m *PagedCrossReferences_RelatedNode) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences_RelatedNode) ProtoMessage()    {}
 func (*PagedCrossReferences_RelatedNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12, 0}
 }
 func (m *PagedCrossReferences_RelatedNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences_RelatedNode.Unmarshal(m, b)
@@ -1178,7 +1178,7 @@ func (m *PagedCrossReferences_Caller) Reset()         { *m = PagedCrossReference
 func (m *PagedCrossReferences_Caller) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences_Caller) ProtoMessage()    {}
 func (*PagedCrossReferences_Caller) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12, 1}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12, 1}
 }
 func (m *PagedCrossReferences_Caller) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences_Caller.Unmarshal(m, b)
@@ -1240,7 +1240,7 @@ func (m *PagedCrossReferences_Group) Reset()         { *m = PagedCrossReferences
 func (m *PagedCrossReferences_Group) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences_Group) ProtoMessage()    {}
 func (*PagedCrossReferences_Group) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12, 2}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12, 2}
 }
 func (m *PagedCrossReferences_Group) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences_Group.Unmarshal(m, b)
@@ -1301,7 +1301,7 @@ func (m *PagedCrossReferences_Page) Reset()         { *m = PagedCrossReferences_
 func (m *PagedCrossReferences_Page) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences_Page) ProtoMessage()    {}
 func (*PagedCrossReferences_Page) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12, 3}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12, 3}
 }
 func (m *PagedCrossReferences_Page) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences_Page.Unmarshal(m, b)
@@ -1346,6 +1346,10 @@ type PagedCrossReferences_PageIndex struct {
 	Kind                 string   `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
 	Count                int32    `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
 	PageKey              string   `protobuf:"bytes,3,opt,name=page_key,json=pageKey" json:"page_key,omitempty"`
+	Corpus               []string `protobuf:"bytes,4,rep,name=corpus" json:"corpus,omitempty"`
+	Root                 []string `protobuf:"bytes,5,rep,name=root" json:"root,omitempty"`
+	MinPath              string   `protobuf:"bytes,6,opt,name=min_path,json=minPath" json:"min_path,omitempty"`
+	MaxPath              string   `protobuf:"bytes,7,opt,name=max_path,json=maxPath" json:"max_path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1355,7 +1359,7 @@ func (m *PagedCrossReferences_PageIndex) Reset()         { *m = PagedCrossRefere
 func (m *PagedCrossReferences_PageIndex) String() string { return proto.CompactTextString(m) }
 func (*PagedCrossReferences_PageIndex) ProtoMessage()    {}
 func (*PagedCrossReferences_PageIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{12, 4}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{12, 4}
 }
 func (m *PagedCrossReferences_PageIndex) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PagedCrossReferences_PageIndex.Unmarshal(m, b)
@@ -1396,6 +1400,34 @@ func (m *PagedCrossReferences_PageIndex) GetPageKey() string {
 	return ""
 }
 
+func (m *PagedCrossReferences_PageIndex) GetCorpus() []string {
+	if m != nil {
+		return m.Corpus
+	}
+	return nil
+}
+
+func (m *PagedCrossReferences_PageIndex) GetRoot() []string {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *PagedCrossReferences_PageIndex) GetMinPath() string {
+	if m != nil {
+		return m.MinPath
+	}
+	return ""
+}
+
+func (m *PagedCrossReferences_PageIndex) GetMaxPath() string {
+	if m != nil {
+		return m.MaxPath
+	}
+	return ""
+}
+
 type Document struct {
 	Ticket               string                        `protobuf:"bytes,1,opt,name=ticket" json:"ticket,omitempty"`
 	MarkedSource         *common_go_proto.MarkedSource `protobuf:"bytes,2,opt,name=marked_source,json=markedSource" json:"marked_source,omitempty"`
@@ -1413,7 +1445,7 @@ func (m *Document) Reset()         { *m = Document{} }
 func (m *Document) String() string { return proto.CompactTextString(m) }
 func (*Document) ProtoMessage()    {}
 func (*Document) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{13}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{13}
 }
 func (m *Document) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Document.Unmarshal(m, b)
@@ -1495,7 +1527,7 @@ func (m *IdentifierMatch) Reset()         { *m = IdentifierMatch{} }
 func (m *IdentifierMatch) String() string { return proto.CompactTextString(m) }
 func (*IdentifierMatch) ProtoMessage()    {}
 func (*IdentifierMatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{14}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{14}
 }
 func (m *IdentifierMatch) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdentifierMatch.Unmarshal(m, b)
@@ -1549,7 +1581,7 @@ func (m *IdentifierMatch_Node) Reset()         { *m = IdentifierMatch_Node{} }
 func (m *IdentifierMatch_Node) String() string { return proto.CompactTextString(m) }
 func (*IdentifierMatch_Node) ProtoMessage()    {}
 func (*IdentifierMatch_Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{14, 0}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{14, 0}
 }
 func (m *IdentifierMatch_Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdentifierMatch_Node.Unmarshal(m, b)
@@ -1602,7 +1634,7 @@ func (m *Relatives) Reset()         { *m = Relatives{} }
 func (m *Relatives) String() string { return proto.CompactTextString(m) }
 func (*Relatives) ProtoMessage()    {}
 func (*Relatives) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{15}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{15}
 }
 func (m *Relatives) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Relatives.Unmarshal(m, b)
@@ -1648,7 +1680,7 @@ func (m *Callgraph) Reset()         { *m = Callgraph{} }
 func (m *Callgraph) String() string { return proto.CompactTextString(m) }
 func (*Callgraph) ProtoMessage()    {}
 func (*Callgraph) Descriptor() ([]byte, []int) {
-	return fileDescriptor_serving_798e764c5281a514, []int{16}
+	return fileDescriptor_serving_bc17327f7871ed69, []int{16}
 }
 func (m *Callgraph) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Callgraph.Unmarshal(m, b)
@@ -1682,6 +1714,60 @@ func (m *Callgraph) GetType() Callgraph_Type {
 	return Callgraph_UNKNOWN
 }
 
+type IdentifierPostings struct {
+	QualifiedName        []string `protobuf:"bytes,1,rep,name=qualified_name,json=qualifiedName" json:"qualified_name,omitempty"`
+	BaseName             []string `protobuf:"bytes,2,rep,name=base_name,json=baseName" json:"base_name,omitempty"`
+	ShardCount           int32    `protobuf:"varint,3,opt,name=shard_count,json=shardCount" json:"shard_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IdentifierPostings) Reset()         { *m = IdentifierPostings{} }
+func (m *IdentifierPostings) String() string { return proto.CompactTextString(m) }
+func (*IdentifierPostings) ProtoMessage()    {}
+func (*IdentifierPostings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_serving_bc17327f7871ed69, []int{17}
+}
+func (m *IdentifierPostings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentifierPostings.Unmarshal(m, b)
+}
+func (m *IdentifierPostings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentifierPostings.Marshal(b, m, deterministic)
+}
+func (dst *IdentifierPostings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentifierPostings.Merge(dst, src)
+}
+func (m *IdentifierPostings) XXX_Size() int {
+	return xxx_messageInfo_IdentifierPostings.Size(m)
+}
+func (m *IdentifierPostings) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentifierPostings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentifierPostings proto.InternalMessageInfo
+
+func (m *IdentifierPostings) GetQualifiedName() []string {
+	if m != nil {
+		return m.QualifiedName
+	}
+	return nil
+}
+
+func (m *IdentifierPostings) GetBaseName() []string {
+	if m != nil {
+		return m.BaseName
+	}
+	return nil
+}
+
+func (m *IdentifierPostings) GetShardCount() int32 {
+	if m != nil {
+		return m.ShardCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Node)(nil), "kythe.proto.serving.Node")
 	proto.RegisterType((*Edge)(nil), "kythe.proto.serving.Edge")
@@ -1710,116 +1796,122 @@ func init() {
 	proto.RegisterType((*IdentifierMatch_Node)(nil), "kythe.proto.serving.IdentifierMatch.Node")
 	proto.RegisterType((*Relatives)(nil), "kythe.proto.serving.Relatives")
 	proto.RegisterType((*Callgraph)(nil), "kythe.proto.serving.Callgraph")
+	proto.RegisterType((*IdentifierPostings)(nil), "kythe.proto.serving.IdentifierPostings")
 	proto.RegisterEnum("kythe.proto.serving.FileDecorations_Override_Kind", FileDecorations_Override_Kind_name, FileDecorations_Override_Kind_value)
 	proto.RegisterEnum("kythe.proto.serving.Relatives_Type", Relatives_Type_name, Relatives_Type_value)
 	proto.RegisterEnum("kythe.proto.serving.Callgraph_Type", Callgraph_Type_name, Callgraph_Type_value)
 }
 
-func init() { proto.RegisterFile("kythe/proto/serving.proto", fileDescriptor_serving_798e764c5281a514) }
-
-var fileDescriptor_serving_798e764c5281a514 = []byte{
-	// 1645 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x41, 0x6f, 0xdb, 0xc6,
-	0x12, 0x7e, 0x94, 0x29, 0x59, 0x1a, 0xca, 0xb6, 0xb2, 0xc9, 0x0b, 0x68, 0x05, 0x2f, 0x71, 0x68,
-	0x3c, 0xc4, 0x0f, 0x89, 0xe5, 0x57, 0x27, 0x6d, 0x81, 0x06, 0x69, 0x90, 0xd8, 0x4a, 0xe3, 0xc4,
-	0x91, 0x83, 0x95, 0x9b, 0xe4, 0x54, 0x82, 0x26, 0xd7, 0x32, 0x61, 0x89, 0xab, 0x92, 0x2b, 0x3b,
-	0xba, 0xb7, 0xa7, 0xa2, 0xfd, 0x07, 0xbd, 0xf4, 0x56, 0x14, 0xbd, 0xf4, 0x57, 0xf4, 0x3f, 0xf4,
-	0x6f, 0x14, 0x3d, 0x15, 0x68, 0xb1, 0xb3, 0x4b, 0x8a, 0x8e, 0x25, 0x5b, 0x75, 0x7b, 0xd2, 0xce,
-	0xec, 0xcc, 0xec, 0xcc, 0x37, 0xb3, 0xb3, 0x43, 0xc1, 0xe2, 0xe1, 0x50, 0x1c, 0xb0, 0xb5, 0x7e,
-	0xcc, 0x05, 0x5f, 0x4b, 0x58, 0x7c, 0x14, 0x46, 0x9d, 0x06, 0x52, 0xe4, 0x32, 0x6e, 0x29, 0xa2,
-	0xa1, 0xb7, 0xea, 0x76, 0x5e, 0xde, 0xe7, 0xbd, 0x1e, 0x8f, 0x94, 0x84, 0xf3, 0x9d, 0x01, 0x66,
-	0x8b, 0x07, 0x8c, 0x5c, 0x85, 0x92, 0x08, 0xfd, 0x43, 0x26, 0x6c, 0x63, 0xc9, 0x58, 0xa9, 0x50,
-	0x4d, 0x91, 0x3b, 0x60, 0xee, 0x7b, 0xbe, 0xb0, 0x0b, 0x4b, 0x33, 0x2b, 0xd6, 0xba, 0xdd, 0xc8,
-	0x9b, 0xd7, 0x96, 0x9e, 0x78, 0xbe, 0xa0, 0x28, 0x45, 0x76, 0xe1, 0x72, 0xc0, 0xf6, 0xc3, 0x28,
-	0x14, 0x21, 0x8f, 0xdc, 0x2e, 0xf7, 0x3d, 0xb9, 0xb0, 0x67, 0x96, 0x8c, 0x15, 0x6b, 0x7d, 0xb9,
-	0x31, 0xc6, 0xb7, 0x46, 0xf3, 0x6d, 0xdf, 0x8b, 0x02, 0x16, 0x3c, 0x8a, 0xfc, 0x03, 0x1e, 0x53,
-	0x32, 0xd2, 0xdf, 0xd6, 0xea, 0xce, 0xcf, 0x06, 0x98, 0xcd, 0xa0, 0xc3, 0xc8, 0x7b, 0x50, 0x4a,
-	0xf8, 0x20, 0xf6, 0x19, 0x3a, 0x69, 0xad, 0x2f, 0x8e, 0xb5, 0x28, 0xe3, 0xa1, 0x5a, 0x90, 0x10,
-	0x30, 0x0f, 0xc3, 0x28, 0xb0, 0x0b, 0x18, 0x15, 0xae, 0x89, 0x0d, 0xb3, 0x3c, 0x0e, 0xc2, 0xc8,
-	0xeb, 0xda, 0xc5, 0x25, 0x63, 0xa5, 0x48, 0x53, 0x52, 0x1e, 0x20, 0xbc, 0xb8, 0xc3, 0x84, 0x76,
-	0xf9, 0xac, 0x03, 0x94, 0x60, 0x06, 0x90, 0x39, 0x0d, 0x40, 0xce, 0x8f, 0x06, 0x54, 0x64, 0x28,
-	0x9f, 0xc4, 0x7c, 0xd0, 0xcf, 0x9c, 0x33, 0x72, 0xce, 0x7d, 0x08, 0x26, 0x0b, 0x3a, 0x4c, 0x03,
-	0x3e, 0x01, 0xb3, 0xd4, 0x02, 0xae, 0x28, 0x2a, 0xd4, 0xdb, 0x23, 0x90, 0x74, 0x0c, 0xc6, 0xb4,
-	0x31, 0xe4, 0x00, 0x29, 0x9c, 0x00, 0xc4, 0xf9, 0xc5, 0x80, 0xea, 0x4b, 0xaf, 0xc3, 0x02, 0x69,
-	0xba, 0xcd, 0xc4, 0x45, 0x52, 0x70, 0x0f, 0x8a, 0x1d, 0xe9, 0xac, 0x0e, 0xe9, 0xfa, 0xd9, 0x21,
-	0x51, 0x25, 0x4c, 0x96, 0xc1, 0x12, 0x5c, 0x78, 0x5d, 0x57, 0x06, 0x97, 0x60, 0x3e, 0x8a, 0x8f,
-	0x0b, 0xb6, 0x41, 0x01, 0xd9, 0x52, 0x3e, 0x21, 0x0f, 0x00, 0xfa, 0x5e, 0x87, 0xb9, 0x61, 0x14,
-	0xb0, 0xb7, 0x3a, 0x05, 0xe3, 0xed, 0xcb, 0x20, 0xb6, 0xa4, 0x14, 0xad, 0xf4, 0xd3, 0xa5, 0xb3,
-	0x07, 0x95, 0x8c, 0x4f, 0xae, 0x41, 0x45, 0x1e, 0xe5, 0xe6, 0x32, 0x52, 0x96, 0x8c, 0xe7, 0x32,
-	0x2b, 0xff, 0x01, 0xc0, 0x4d, 0x9f, 0x0f, 0x22, 0xa1, 0x41, 0x42, 0xf1, 0x0d, 0xc9, 0x20, 0x8b,
-	0x50, 0x46, 0x3f, 0x0e, 0xd9, 0x10, 0x3d, 0xad, 0xd0, 0x59, 0x49, 0x3f, 0x67, 0x43, 0xe7, 0x2b,
-	0x03, 0xca, 0xd2, 0x59, 0x79, 0xd0, 0x09, 0x39, 0xe3, 0x84, 0x1c, 0x59, 0x86, 0x39, 0x85, 0x97,
-	0xab, 0xef, 0xa1, 0xaa, 0xd8, 0xaa, 0x62, 0xee, 0xaa, 0xdb, 0xf8, 0x10, 0x2c, 0x84, 0xc3, 0x55,
-	0x80, 0xaa, 0x22, 0x3d, 0x0f, 0x50, 0xf4, 0x3c, 0xc1, 0xb5, 0xb3, 0x0b, 0x73, 0x4f, 0xc2, 0x2e,
-	0xdb, 0x0c, 0x63, 0xe6, 0x0b, 0x1e, 0x0f, 0x89, 0x03, 0xd5, 0x64, 0xb0, 0x17, 0xa4, 0xb4, 0x6d,
-	0x2c, 0xcd, 0xe0, 0xa9, 0x39, 0x1e, 0xb9, 0x01, 0xd6, 0x7e, 0xd8, 0xcd, 0x39, 0x26, 0x45, 0x40,
-	0xb2, 0x94, 0x5b, 0xce, 0x17, 0x06, 0x58, 0x1b, 0x3c, 0xee, 0x0f, 0x12, 0xca, 0xb9, 0x48, 0xc8,
-	0x43, 0x28, 0xf9, 0x48, 0xa2, 0x39, 0x6b, 0xfd, 0xd6, 0x58, 0x0f, 0x73, 0x1a, 0xe9, 0x5a, 0xab,
-	0xd5, 0xef, 0x41, 0x49, 0x71, 0x64, 0x5f, 0xca, 0x4c, 0x61, 0x5f, 0x52, 0x94, 0xbc, 0x3a, 0x31,
-	0xe7, 0xa9, 0x33, 0xb8, 0x76, 0x5a, 0x60, 0xca, 0xe0, 0x26, 0xf6, 0x32, 0x02, 0xa6, 0x60, 0x6f,
-	0x15, 0xb2, 0x55, 0x8a, 0x6b, 0x52, 0x87, 0x32, 0x8b, 0x7c, 0x1e, 0x84, 0x51, 0x47, 0x67, 0x2e,
-	0xa3, 0x9d, 0x1f, 0x0c, 0xa8, 0x50, 0xef, 0x58, 0x75, 0xa6, 0x89, 0x56, 0x6f, 0x42, 0x35, 0x11,
-	0x5e, 0x2c, 0x5c, 0xbe, 0xbf, 0x9f, 0xb0, 0xb4, 0x38, 0x2c, 0xe4, 0xed, 0x20, 0x0b, 0xab, 0x27,
-	0x0a, 0x52, 0x81, 0x19, 0x5d, 0x3d, 0x51, 0xa0, 0xb7, 0x65, 0xea, 0xa3, 0xb0, 0xdf, 0x67, 0xc2,
-	0x45, 0x2d, 0xdb, 0x44, 0x89, 0xaa, 0x66, 0xb6, 0x25, 0x4f, 0x26, 0x21, 0x15, 0x62, 0x51, 0xa0,
-	0x1b, 0x17, 0x68, 0x56, 0x33, 0x0a, 0xe4, 0x55, 0x9d, 0x3f, 0xd9, 0x4c, 0xcf, 0x02, 0xe2, 0x54,
-	0x53, 0x4c, 0xc1, 0x31, 0x15, 0x0f, 0xc1, 0xb9, 0x03, 0x66, 0xd2, 0xf7, 0x22, 0x3c, 0x6c, 0x42,
-	0x6f, 0x6b, 0xf7, 0xbd, 0x88, 0xa2, 0x94, 0xec, 0x22, 0xda, 0x1d, 0xbb, 0xa4, 0x6a, 0x5b, 0x93,
-	0xe4, 0x3e, 0x54, 0xb3, 0x00, 0xa5, 0xbd, 0xd9, 0x73, 0xec, 0xa5, 0x91, 0x4a, 0xe2, 0x99, 0x59,
-	0x9e, 0xa9, 0x99, 0xce, 0x97, 0xb3, 0xb0, 0x80, 0x95, 0xcb, 0x7c, 0x1e, 0xe3, 0xb3, 0x90, 0x90,
-	0x55, 0x30, 0x65, 0x11, 0x9e, 0xd9, 0x89, 0xa4, 0x0e, 0x45, 0x31, 0xb2, 0x03, 0x10, 0x64, 0xda,
-	0xba, 0x19, 0xad, 0x4d, 0x54, 0xca, 0x1d, 0xd4, 0x18, 0xad, 0x69, 0xce, 0x44, 0xae, 0xd3, 0xaa,
-	0xce, 0x33, 0x45, 0xa7, 0xa5, 0x40, 0xd4, 0xca, 0x1d, 0xbd, 0x73, 0xb2, 0xb9, 0xcd, 0x4c, 0xfb,
-	0x3e, 0x5e, 0x52, 0xea, 0x9b, 0x23, 0x6d, 0xf2, 0x0a, 0x16, 0xb4, 0x4d, 0x7e, 0xc4, 0xe2, 0x38,
-	0x0c, 0x98, 0x5d, 0x44, 0x83, 0xab, 0x53, 0x05, 0xb7, 0xa3, 0x95, 0xe8, 0xbc, 0xb2, 0x92, 0xd2,
-	0xe4, 0x63, 0x80, 0x20, 0xf4, 0x3a, 0x11, 0x4f, 0x44, 0xe8, 0xdb, 0xa5, 0x31, 0xcd, 0x55, 0xe7,
-	0x6c, 0x33, 0x93, 0xa2, 0x39, 0x8d, 0xfa, 0xb7, 0x06, 0xc0, 0xe8, 0x20, 0xf2, 0x01, 0x94, 0x3c,
-	0x8c, 0x41, 0xe7, 0x6b, 0x7c, 0xdb, 0xca, 0xee, 0x1b, 0xd5, 0xd2, 0x63, 0x8b, 0xf5, 0x6a, 0x86,
-	0x7c, 0x51, 0x17, 0xb6, 0x82, 0xf7, 0x36, 0x5c, 0x3a, 0x05, 0xaf, 0xae, 0xe8, 0xda, 0xbb, 0xc0,
-	0xd5, 0x7f, 0x2a, 0x40, 0x39, 0x0b, 0xf6, 0x3a, 0x80, 0x46, 0x4f, 0x76, 0x02, 0x75, 0x5d, 0x72,
-	0x9c, 0xdc, 0x7e, 0xc0, 0x22, 0xed, 0x4b, 0x8e, 0x43, 0xee, 0xc2, 0xbf, 0x47, 0x54, 0xfe, 0x74,
-	0xe5, 0xe0, 0x95, 0xd1, 0xe6, 0xc8, 0x03, 0xf2, 0x44, 0x87, 0x26, 0x3b, 0xc2, 0xfc, 0xfa, 0xfa,
-	0x5f, 0x4a, 0x57, 0x43, 0xbe, 0x4b, 0x1a, 0x8e, 0x26, 0xcc, 0xf5, 0xbc, 0xf8, 0x90, 0x05, 0xae,
-	0x7e, 0x9b, 0x4d, 0x44, 0x78, 0x69, 0x5c, 0xb2, 0x5e, 0xa0, 0x60, 0x1b, 0xe5, 0x68, 0xb5, 0x97,
-	0xa3, 0x1c, 0x07, 0x4c, 0x7c, 0xec, 0xe6, 0xa0, 0xb2, 0xf3, 0xaa, 0x49, 0xe9, 0xd6, 0x66, 0xb3,
-	0x5d, 0xfb, 0x17, 0xb1, 0x60, 0xb6, 0xf9, 0x66, 0xb7, 0xd9, 0xda, 0x6c, 0xd7, 0x0c, 0xe7, 0x8f,
-	0x0a, 0x5c, 0xc1, 0x81, 0x60, 0x23, 0xe6, 0x49, 0x42, 0xd9, 0x3e, 0x8b, 0x59, 0xe4, 0xb3, 0x44,
-	0xf6, 0xb8, 0x1e, 0x8b, 0x3b, 0xcc, 0x3d, 0x0e, 0xc5, 0x81, 0x3d, 0x8b, 0x6d, 0xb9, 0x82, 0x9c,
-	0xd7, 0xa1, 0x38, 0x38, 0xfd, 0xbc, 0x19, 0x63, 0x9e, 0xb7, 0x8f, 0xc0, 0xd2, 0x42, 0x11, 0x0f,
-	0x98, 0x5d, 0x3e, 0x6f, 0xc2, 0x00, 0x25, 0x8d, 0x03, 0x6c, 0xf3, 0xe4, 0x94, 0xb1, 0x36, 0x71,
-	0x0a, 0x78, 0xd7, 0xf3, 0xc6, 0x89, 0xb1, 0x83, 0x9e, 0x98, 0x28, 0xd4, 0xc5, 0xbc, 0x3b, 0xbd,
-	0xad, 0x71, 0x63, 0x06, 0x59, 0x85, 0x9a, 0x1a, 0x65, 0xe2, 0x4c, 0x50, 0xb5, 0x78, 0x9c, 0x67,
-	0x16, 0x70, 0x2f, 0x87, 0xe4, 0x75, 0x80, 0x30, 0xf2, 0x79, 0xaf, 0xdf, 0x65, 0x82, 0x61, 0xfd,
-	0x94, 0x69, 0x8e, 0x73, 0x3a, 0xdb, 0xa5, 0x8b, 0x64, 0xbb, 0xfe, 0x0a, 0x2c, 0xca, 0xba, 0x9e,
-	0x60, 0x01, 0xe2, 0xb7, 0x0a, 0x26, 0x82, 0x7e, 0xee, 0x58, 0x87, 0x62, 0x93, 0x47, 0xc6, 0xfa,
-	0x6f, 0x06, 0x94, 0x36, 0xbc, 0x6e, 0x97, 0xc5, 0xe4, 0x3e, 0x94, 0x7c, 0x5c, 0x69, 0xab, 0x53,
-	0x75, 0x38, 0xad, 0x42, 0x6e, 0xc1, 0x42, 0xc2, 0x7a, 0x5e, 0x24, 0x42, 0xdf, 0xd5, 0x56, 0xd4,
-	0xb5, 0x9b, 0x4f, 0xd9, 0xfa, 0x94, 0x53, 0x78, 0xcc, 0x5c, 0x04, 0x0f, 0xf2, 0x10, 0xca, 0xf2,
-	0x98, 0x24, 0x14, 0x4c, 0xf7, 0xf3, 0xa9, 0xdc, 0xcd, 0x94, 0xea, 0xbf, 0x1b, 0x50, 0x9c, 0x3c,
-	0xd7, 0xdf, 0xcf, 0xda, 0x5f, 0x61, 0x7a, 0xe3, 0x69, 0x0f, 0x7c, 0x03, 0xd5, 0x58, 0xe5, 0x4a,
-	0xdd, 0x0c, 0x55, 0x97, 0xef, 0x4f, 0x5f, 0x97, 0xb9, 0x4c, 0x53, 0x2b, 0xce, 0xa5, 0xfd, 0x69,
-	0x96, 0x22, 0x15, 0xf3, 0xff, 0xa7, 0xb7, 0xa9, 0xe0, 0x4f, 0xf3, 0x55, 0xff, 0xda, 0x00, 0xf3,
-	0x1f, 0x19, 0x72, 0xb3, 0x9b, 0xac, 0xf2, 0x78, 0xc1, 0x9b, 0x5c, 0x7f, 0x99, 0x1f, 0xee, 0xc7,
-	0x65, 0xe4, 0x0a, 0x14, 0xf3, 0xe3, 0xbc, 0x22, 0xce, 0x18, 0xe5, 0x9f, 0x99, 0x65, 0xa8, 0x59,
-	0xce, 0xf7, 0x05, 0x28, 0x6f, 0x72, 0x7f, 0xd0, 0x63, 0x91, 0x98, 0x38, 0x61, 0x9d, 0xaa, 0xc9,
-	0xc2, 0x85, 0x6a, 0x72, 0x11, 0xca, 0xb1, 0x77, 0xec, 0xe2, 0x60, 0xa6, 0x9d, 0x89, 0xbd, 0xe3,
-	0x5d, 0x3d, 0x9b, 0x75, 0xc3, 0xe8, 0xf0, 0xac, 0xef, 0xce, 0xed, 0x30, 0x3a, 0xa4, 0x28, 0x25,
-	0x87, 0x54, 0xff, 0x20, 0xec, 0x06, 0x29, 0xee, 0x45, 0xec, 0xcf, 0x16, 0xf2, 0x34, 0xec, 0x69,
-	0x03, 0x28, 0x9d, 0x37, 0xcb, 0xa8, 0x06, 0xb0, 0x0c, 0x73, 0x81, 0x46, 0x81, 0x05, 0xee, 0xde,
-	0x10, 0x87, 0xba, 0x0a, 0xad, 0x8e, 0x98, 0x8f, 0x87, 0xce, 0xaf, 0x06, 0x2c, 0x6c, 0x05, 0x2c,
-	0x12, 0xe1, 0x7e, 0xc8, 0xe2, 0x17, 0x9e, 0xf0, 0x0f, 0xc8, 0x7f, 0x61, 0xfe, 0xf3, 0x81, 0xd7,
-	0x95, 0x9c, 0xc0, 0x8d, 0xbc, 0x1e, 0xd3, 0xd0, 0xcd, 0x65, 0xdc, 0x96, 0xd7, 0x63, 0xf2, 0x73,
-	0x6c, 0xcf, 0x4b, 0x98, 0x92, 0x50, 0x65, 0x52, 0x96, 0x0c, 0xdc, 0x7c, 0xa0, 0x7d, 0x55, 0xf7,
-	0xe0, 0x7f, 0x63, 0x7d, 0x7d, 0xe7, 0xdc, 0x9c, 0xef, 0xf5, 0xcf, 0xce, 0xf9, 0xd3, 0xe3, 0x1a,
-	0x54, 0xa4, 0x9c, 0x9b, 0x9b, 0x3b, 0xca, 0x92, 0x81, 0xaf, 0xe3, 0x4d, 0xa8, 0xe2, 0x66, 0x32,
-	0xd8, 0xcb, 0x1e, 0xef, 0x0a, 0xb5, 0x24, 0xaf, 0xad, 0x58, 0xce, 0x37, 0xf2, 0xc3, 0x41, 0x5e,
-	0xb2, 0xf0, 0x88, 0x25, 0xb2, 0x55, 0x2a, 0xbb, 0x89, 0xfe, 0xba, 0x4a, 0x49, 0xf9, 0xad, 0x2f,
-	0x86, 0x7d, 0x15, 0xde, 0xfc, 0x84, 0x8e, 0x90, 0xd9, 0x69, 0xec, 0x0e, 0xfb, 0x8c, 0xa2, 0x82,
-	0xd3, 0x00, 0x53, 0x52, 0xf2, 0x69, 0xfe, 0xb4, 0xf5, 0xbc, 0xb5, 0xf3, 0xba, 0xa5, 0xde, 0xe9,
-	0x97, 0x8f, 0x68, 0xb3, 0xb5, 0xdb, 0xae, 0x19, 0xa4, 0x0a, 0xe5, 0x8d, 0xa7, 0x5b, 0xdb, 0x9b,
-	0xb4, 0xd9, 0xaa, 0x15, 0xe4, 0x47, 0x68, 0x45, 0x5e, 0xd7, 0x4e, 0xec, 0xf5, 0x0f, 0xfe, 0xa6,
-	0x43, 0x99, 0x9d, 0xbc, 0x43, 0xb7, 0xc7, 0x39, 0x04, 0x50, 0xda, 0x78, 0xb4, 0xbd, 0xdd, 0xa4,
-	0x35, 0x23, 0x5b, 0x37, 0x6b, 0x85, 0xc7, 0x37, 0xe1, 0x86, 0xcf, 0x7b, 0x8d, 0x0e, 0xe7, 0x9d,
-	0x2e, 0x6b, 0x04, 0xec, 0x48, 0x70, 0xde, 0x4d, 0xf2, 0x87, 0xed, 0x95, 0xf0, 0xe7, 0xee, 0x9f,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x25, 0x4c, 0xf4, 0xea, 0x12, 0x00, 0x00,
+func init() { proto.RegisterFile("kythe/proto/serving.proto", fileDescriptor_serving_bc17327f7871ed69) }
+
+var fileDescriptor_serving_bc17327f7871ed69 = []byte{
+	// 1713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x73, 0x1b, 0x4b,
+	0x11, 0x67, 0xa5, 0x95, 0x2c, 0xf5, 0xca, 0xb6, 0xde, 0xbc, 0xf0, 0x6a, 0xad, 0x57, 0xe4, 0x39,
+	0x9b, 0xa2, 0x62, 0x2a, 0x58, 0x06, 0x27, 0x40, 0x15, 0xa9, 0x90, 0x4a, 0x6c, 0x85, 0x38, 0x71,
+	0x64, 0xd7, 0xc8, 0x24, 0x39, 0xb1, 0x35, 0xde, 0x1d, 0x4b, 0x5b, 0x96, 0x66, 0xc4, 0xee, 0xc8,
+	0xb6, 0xee, 0x70, 0xa2, 0xe0, 0x1b, 0x70, 0xe1, 0x46, 0x51, 0x5c, 0xf2, 0x29, 0xf8, 0x0e, 0xdc,
+	0xf8, 0x0c, 0x14, 0x27, 0x0e, 0xd4, 0xfc, 0xd9, 0xd5, 0xca, 0x96, 0x2c, 0x61, 0xde, 0x49, 0xd3,
+	0x3d, 0xdd, 0x3d, 0xdd, 0xbf, 0xee, 0xe9, 0xe9, 0x15, 0x6c, 0x9c, 0x8f, 0x45, 0x8f, 0xee, 0x0c,
+	0x63, 0x2e, 0xf8, 0x4e, 0x42, 0xe3, 0x8b, 0x88, 0x75, 0x9b, 0x8a, 0x42, 0x5f, 0xaa, 0x2d, 0x4d,
+	0x34, 0xcd, 0x56, 0xc3, 0xcd, 0xcb, 0x07, 0x7c, 0x30, 0xe0, 0x4c, 0x4b, 0x78, 0x7f, 0xb6, 0xc0,
+	0x6e, 0xf3, 0x90, 0xa2, 0xaf, 0xa0, 0x2c, 0xa2, 0xe0, 0x9c, 0x0a, 0xd7, 0xda, 0xb4, 0xb6, 0xaa,
+	0xd8, 0x50, 0xe8, 0x87, 0x60, 0x9f, 0x91, 0x40, 0xb8, 0x85, 0xcd, 0xe2, 0x96, 0xb3, 0xeb, 0x36,
+	0xf3, 0xe6, 0x8d, 0xa5, 0xd7, 0x24, 0x10, 0x58, 0x49, 0xa1, 0x13, 0xf8, 0x32, 0xa4, 0x67, 0x11,
+	0x8b, 0x44, 0xc4, 0x99, 0xdf, 0xe7, 0x01, 0x91, 0x0b, 0xb7, 0xb8, 0x69, 0x6d, 0x39, 0xbb, 0x0f,
+	0x9b, 0x33, 0x7c, 0x6b, 0xb6, 0xae, 0x86, 0x84, 0x85, 0x34, 0x7c, 0xc9, 0x82, 0x1e, 0x8f, 0x31,
+	0x9a, 0xe8, 0x1f, 0x1a, 0x75, 0xef, 0xef, 0x16, 0xd8, 0xad, 0xb0, 0x4b, 0xd1, 0x8f, 0xa1, 0x9c,
+	0xf0, 0x51, 0x1c, 0x50, 0xe5, 0xa4, 0xb3, 0xbb, 0x31, 0xd3, 0xa2, 0x8c, 0x07, 0x1b, 0x41, 0x84,
+	0xc0, 0x3e, 0x8f, 0x58, 0xe8, 0x16, 0x54, 0x54, 0x6a, 0x8d, 0x5c, 0x58, 0xe1, 0x71, 0x18, 0x31,
+	0xd2, 0x77, 0x4b, 0x9b, 0xd6, 0x56, 0x09, 0xa7, 0xa4, 0x3c, 0x40, 0x90, 0xb8, 0x4b, 0x85, 0x5b,
+	0x5c, 0x78, 0x80, 0x16, 0xcc, 0x00, 0xb2, 0x97, 0x01, 0xc8, 0xfb, 0x9b, 0x05, 0x55, 0x19, 0xca,
+	0x2f, 0x63, 0x3e, 0x1a, 0x66, 0xce, 0x59, 0x39, 0xe7, 0x7e, 0x06, 0x36, 0x0d, 0xbb, 0xd4, 0x00,
+	0x3e, 0x07, 0xb3, 0xd4, 0x82, 0x5a, 0x61, 0xa5, 0xd0, 0xe8, 0x4c, 0x40, 0x32, 0x31, 0x58, 0xcb,
+	0xc6, 0x90, 0x03, 0xa4, 0x30, 0x05, 0x88, 0xf7, 0x0f, 0x0b, 0x6a, 0xc7, 0xa4, 0x4b, 0x43, 0x69,
+	0xba, 0x43, 0xc5, 0x5d, 0x52, 0xf0, 0x14, 0x4a, 0x5d, 0xe9, 0xac, 0x09, 0xe9, 0xfe, 0xed, 0x21,
+	0x61, 0x2d, 0x8c, 0x1e, 0x82, 0x23, 0xb8, 0x20, 0x7d, 0x5f, 0x06, 0x97, 0xa8, 0x7c, 0x94, 0x5e,
+	0x15, 0x5c, 0x0b, 0x83, 0x62, 0x4b, 0xf9, 0x04, 0x3d, 0x07, 0x18, 0x92, 0x2e, 0xf5, 0x23, 0x16,
+	0xd2, 0x2b, 0xd7, 0xbe, 0xc5, 0xbe, 0x0c, 0xe2, 0x40, 0x4a, 0xe1, 0xea, 0x30, 0x5d, 0x7a, 0xa7,
+	0x50, 0xcd, 0xf8, 0xe8, 0x6b, 0xa8, 0xca, 0xa3, 0xfc, 0x5c, 0x46, 0x2a, 0x92, 0xf1, 0x4e, 0x66,
+	0xe5, 0x7b, 0x00, 0x6a, 0x33, 0xe0, 0x23, 0x26, 0x0c, 0x48, 0x4a, 0x7c, 0x4f, 0x32, 0xd0, 0x06,
+	0x54, 0x94, 0x1f, 0xe7, 0x74, 0xac, 0x3c, 0xad, 0xe2, 0x15, 0x49, 0xbf, 0xa3, 0x63, 0xef, 0xf7,
+	0x16, 0x54, 0xa4, 0xb3, 0xf2, 0xa0, 0x29, 0x39, 0x6b, 0x4a, 0x0e, 0x3d, 0x84, 0x55, 0x8d, 0x97,
+	0x6f, 0xee, 0xa1, 0xae, 0xd8, 0x9a, 0x66, 0x9e, 0x28, 0x1e, 0x7a, 0x01, 0x8e, 0x82, 0xc3, 0xd7,
+	0x80, 0xea, 0x22, 0x5d, 0x04, 0xa8, 0xf2, 0x3c, 0x51, 0x6b, 0xef, 0x04, 0x56, 0x5f, 0x47, 0x7d,
+	0xba, 0x1f, 0xc5, 0x34, 0x10, 0x3c, 0x1e, 0x23, 0x0f, 0x6a, 0xc9, 0xe8, 0x34, 0x4c, 0x69, 0xd7,
+	0xda, 0x2c, 0xaa, 0x53, 0x73, 0x3c, 0xf4, 0x0d, 0x38, 0x67, 0x51, 0x3f, 0xe7, 0x98, 0x14, 0x01,
+	0xc9, 0xd2, 0x6e, 0x79, 0xbf, 0xb5, 0xc0, 0xd9, 0xe3, 0xf1, 0x70, 0x94, 0x60, 0xce, 0x45, 0x82,
+	0x5e, 0x40, 0x39, 0x50, 0xa4, 0x32, 0xe7, 0xec, 0x3e, 0x9a, 0xe9, 0x61, 0x4e, 0x23, 0x5d, 0x1b,
+	0xb5, 0xc6, 0x53, 0x28, 0x6b, 0x8e, 0xec, 0x4b, 0x99, 0x29, 0xd5, 0x97, 0x34, 0x25, 0xaf, 0x4e,
+	0xcc, 0x79, 0xea, 0x8c, 0x5a, 0x7b, 0x6d, 0xb0, 0x65, 0x70, 0x73, 0x7b, 0x19, 0x02, 0x5b, 0xd0,
+	0x2b, 0x8d, 0x6c, 0x0d, 0xab, 0x35, 0x6a, 0x40, 0x85, 0xb2, 0x80, 0x87, 0x11, 0xeb, 0x9a, 0xcc,
+	0x65, 0xb4, 0xf7, 0x57, 0x0b, 0xaa, 0x98, 0x5c, 0xea, 0xce, 0x34, 0xd7, 0xea, 0x03, 0xa8, 0x25,
+	0x82, 0xc4, 0xc2, 0xe7, 0x67, 0x67, 0x09, 0x4d, 0x8b, 0xc3, 0x51, 0xbc, 0x23, 0xc5, 0x52, 0xd5,
+	0xc3, 0xc2, 0x54, 0xa0, 0x68, 0xaa, 0x87, 0x85, 0x66, 0x5b, 0xa6, 0x9e, 0x45, 0xc3, 0x21, 0x15,
+	0xbe, 0xd2, 0x72, 0x6d, 0x25, 0x51, 0x33, 0xcc, 0x8e, 0xe4, 0xc9, 0x24, 0xa4, 0x42, 0x94, 0x85,
+	0xa6, 0x71, 0x81, 0x61, 0xb5, 0x58, 0x28, 0xaf, 0xea, 0xda, 0x74, 0x33, 0xbd, 0x0d, 0x88, 0x1b,
+	0x4d, 0x31, 0x05, 0xc7, 0xd6, 0x3c, 0xb9, 0x96, 0xbd, 0x2d, 0x19, 0x12, 0xa6, 0x0e, 0x9b, 0xd3,
+	0xdb, 0x3a, 0x43, 0xc2, 0xb0, 0x92, 0x92, 0x5d, 0xc4, 0xb8, 0xe3, 0x96, 0x75, 0x6d, 0x1b, 0x12,
+	0x3d, 0x83, 0x5a, 0x16, 0xa0, 0xb4, 0xb7, 0xb2, 0xc0, 0x5e, 0x1a, 0xa9, 0x24, 0xde, 0xda, 0x95,
+	0x62, 0xdd, 0xf6, 0x7e, 0xb7, 0x02, 0xeb, 0xaa, 0x72, 0x69, 0xc0, 0x63, 0xf5, 0x2c, 0x24, 0x68,
+	0x1b, 0x6c, 0x59, 0x84, 0xb7, 0x76, 0x22, 0xa9, 0x83, 0x95, 0x18, 0x3a, 0x02, 0x08, 0x33, 0x6d,
+	0xd3, 0x8c, 0x76, 0xe6, 0x2a, 0xe5, 0x0e, 0x6a, 0x4e, 0xd6, 0x38, 0x67, 0x22, 0xd7, 0x69, 0x75,
+	0xe7, 0x59, 0xa2, 0xd3, 0x62, 0x40, 0x7a, 0xe5, 0x4f, 0xde, 0x39, 0xd9, 0xdc, 0x8a, 0xcb, 0xbe,
+	0x8f, 0x5f, 0x68, 0xf5, 0xfd, 0x89, 0x36, 0xfa, 0x00, 0xeb, 0xc6, 0x26, 0xbf, 0xa0, 0x71, 0x1c,
+	0x85, 0xd4, 0x2d, 0x29, 0x83, 0xdb, 0x4b, 0x05, 0x77, 0x64, 0x94, 0xf0, 0x9a, 0xb6, 0x92, 0xd2,
+	0xe8, 0x17, 0x00, 0x61, 0x44, 0xba, 0x8c, 0x27, 0x22, 0x0a, 0xdc, 0xf2, 0x8c, 0xe6, 0x6a, 0x72,
+	0xb6, 0x9f, 0x49, 0xe1, 0x9c, 0x46, 0xe3, 0x4f, 0x16, 0xc0, 0xe4, 0x20, 0xf4, 0x53, 0x28, 0x13,
+	0x15, 0x83, 0xc9, 0xd7, 0xec, 0xb6, 0x95, 0xdd, 0x37, 0x6c, 0xa4, 0x67, 0x16, 0xeb, 0x57, 0x19,
+	0xf2, 0x25, 0x53, 0xd8, 0x8a, 0x42, 0x8f, 0xe1, 0x8b, 0x1b, 0xf0, 0x9a, 0x8a, 0xae, 0x5f, 0x07,
+	0xae, 0xf1, 0xb9, 0x00, 0x95, 0x2c, 0xd8, 0xfb, 0x00, 0x06, 0x3d, 0xd9, 0x09, 0xf4, 0x75, 0xc9,
+	0x71, 0x72, 0xfb, 0x21, 0x65, 0xc6, 0x97, 0x1c, 0x07, 0x3d, 0x81, 0xef, 0x4e, 0xa8, 0xfc, 0xe9,
+	0xda, 0xc1, 0x7b, 0x93, 0xcd, 0x89, 0x07, 0xe8, 0xb5, 0x09, 0x4d, 0x76, 0x84, 0xb5, 0xdd, 0xdd,
+	0xff, 0x29, 0x5d, 0x4d, 0xf9, 0x2e, 0x19, 0x38, 0x5a, 0xb0, 0x3a, 0x20, 0xf1, 0x39, 0x0d, 0x7d,
+	0xf3, 0x36, 0xdb, 0x0a, 0xe1, 0xcd, 0x59, 0xc9, 0x7a, 0xaf, 0x04, 0x3b, 0x4a, 0x0e, 0xd7, 0x06,
+	0x39, 0xca, 0xf3, 0xc0, 0x56, 0x8f, 0xdd, 0x2a, 0x54, 0x8f, 0x3e, 0xb4, 0x30, 0x3e, 0xd8, 0x6f,
+	0x75, 0xea, 0xdf, 0x41, 0x0e, 0xac, 0xb4, 0x3e, 0x9d, 0xb4, 0xda, 0xfb, 0x9d, 0xba, 0xe5, 0xfd,
+	0x13, 0xe0, 0x9e, 0x1a, 0x08, 0xf6, 0x62, 0x9e, 0x24, 0x98, 0x9e, 0xd1, 0x98, 0xb2, 0x80, 0x26,
+	0xb2, 0xc7, 0x0d, 0x68, 0xdc, 0xa5, 0xfe, 0x65, 0x24, 0x7a, 0xee, 0x8a, 0x6a, 0xcb, 0x55, 0xc5,
+	0xf9, 0x18, 0x89, 0xde, 0xcd, 0xe7, 0xcd, 0x9a, 0xf1, 0xbc, 0xfd, 0x1c, 0x1c, 0x23, 0xc4, 0x78,
+	0x48, 0xdd, 0xca, 0xa2, 0x09, 0x03, 0xb4, 0xb4, 0x5c, 0xa3, 0xd6, 0xf4, 0x94, 0xb1, 0x33, 0x77,
+	0x0a, 0xb8, 0xee, 0x79, 0x73, 0x6a, 0xec, 0xc0, 0x53, 0x13, 0x85, 0xbe, 0x98, 0x4f, 0x96, 0xb7,
+	0x35, 0x6b, 0xcc, 0x40, 0xdb, 0x50, 0xd7, 0xa3, 0x4c, 0x9c, 0x09, 0xba, 0x76, 0x36, 0xcf, 0xac,
+	0xab, 0xbd, 0x1c, 0x92, 0xf7, 0x01, 0x22, 0x16, 0xf0, 0xc1, 0xb0, 0x4f, 0x05, 0x55, 0xf5, 0x53,
+	0xc1, 0x39, 0xce, 0xcd, 0x6c, 0x97, 0xef, 0x92, 0xed, 0xc6, 0x07, 0x70, 0x30, 0xed, 0x13, 0x41,
+	0x43, 0x85, 0xdf, 0x36, 0xd8, 0x0a, 0xf4, 0x85, 0x63, 0x9d, 0x12, 0x9b, 0x3f, 0x32, 0x36, 0xfe,
+	0x6d, 0x41, 0x79, 0x8f, 0xf4, 0xfb, 0x34, 0x46, 0xcf, 0xa0, 0x1c, 0xa8, 0x95, 0xb1, 0xba, 0x54,
+	0x87, 0x33, 0x2a, 0xe8, 0x11, 0xac, 0x27, 0x74, 0x40, 0x98, 0x88, 0x02, 0xdf, 0x58, 0xd1, 0xd7,
+	0x6e, 0x2d, 0x65, 0x9b, 0x53, 0x6e, 0xe0, 0x51, 0xbc, 0x0b, 0x1e, 0xe8, 0x05, 0x54, 0xe4, 0x31,
+	0x49, 0x24, 0xa8, 0xe9, 0xe7, 0x4b, 0xb9, 0x9b, 0x29, 0x35, 0xfe, 0x63, 0x41, 0x69, 0xfe, 0x5c,
+	0xff, 0x2c, 0x6b, 0x7f, 0x85, 0xe5, 0x8d, 0x1b, 0x15, 0xf4, 0x09, 0x6a, 0xb1, 0xce, 0x95, 0xbe,
+	0x19, 0xba, 0x2e, 0x7f, 0xb2, 0x7c, 0x5d, 0xe6, 0x32, 0x8d, 0x9d, 0x78, 0x42, 0xa0, 0x37, 0x59,
+	0x8a, 0x74, 0xcc, 0x3f, 0x5a, 0xde, 0xa6, 0x86, 0x3f, 0xcd, 0x57, 0xe3, 0x0f, 0x16, 0xd8, 0xdf,
+	0xca, 0x90, 0x9b, 0xdd, 0x64, 0x9d, 0xc7, 0x3b, 0xde, 0xe4, 0xc6, 0x67, 0x2b, 0x3f, 0xdd, 0xcf,
+	0x4a, 0xc9, 0x3d, 0x28, 0xe5, 0xe7, 0x79, 0x4d, 0xdc, 0x32, 0xcb, 0xe7, 0x86, 0x51, 0x5b, 0xf5,
+	0xb7, 0xeb, 0xc3, 0x68, 0x69, 0x32, 0x8c, 0x4a, 0x33, 0x83, 0x88, 0xf9, 0x43, 0x22, 0x7a, 0xe9,
+	0x38, 0x34, 0x88, 0xd8, 0x31, 0x11, 0x3d, 0xb5, 0x45, 0xae, 0xf4, 0xd6, 0x8a, 0xd9, 0x22, 0x57,
+	0x72, 0xeb, 0xad, 0x5d, 0x81, 0xba, 0xe3, 0xfd, 0xa5, 0x00, 0x95, 0x7d, 0x1e, 0x8c, 0x06, 0x94,
+	0x89, 0xb9, 0x43, 0xdc, 0x8d, 0xb2, 0x2f, 0xdc, 0xa9, 0xec, 0x37, 0xa0, 0x12, 0x93, 0x4b, 0x5f,
+	0xcd, 0x7e, 0x26, 0xdc, 0x98, 0x5c, 0x9e, 0x98, 0xf1, 0xaf, 0x1f, 0xb1, 0xf3, 0xdb, 0x3e, 0x6d,
+	0x0f, 0x23, 0x76, 0x8e, 0x95, 0x94, 0x9c, 0x83, 0x83, 0x5e, 0xd4, 0x0f, 0xd3, 0xd4, 0x6a, 0x30,
+	0x1c, 0xc5, 0x33, 0x99, 0x4d, 0x7b, 0x4c, 0x79, 0xd1, 0xb8, 0xa4, 0xc4, 0x64, 0xb5, 0x84, 0x06,
+	0x05, 0x1a, 0xfa, 0xa7, 0x63, 0x03, 0x56, 0x6d, 0xc2, 0x7c, 0x35, 0xf6, 0xfe, 0x65, 0xc1, 0xfa,
+	0x41, 0x48, 0x99, 0x88, 0xce, 0x22, 0x1a, 0xbf, 0x27, 0x22, 0xe8, 0xa1, 0xef, 0xc3, 0xda, 0x6f,
+	0x46, 0xa4, 0x2f, 0x39, 0xa1, 0xcf, 0xc8, 0x80, 0x1a, 0xe8, 0x56, 0x33, 0x6e, 0x9b, 0x0c, 0xa8,
+	0xfc, 0xe2, 0x3b, 0x25, 0x09, 0xd5, 0x12, 0xba, 0x12, 0x2b, 0x92, 0xa1, 0x36, 0x9f, 0x83, 0x9d,
+	0xbb, 0x6a, 0x3f, 0x98, 0xe9, 0xeb, 0xb5, 0x73, 0x73, 0xbe, 0x37, 0x7e, 0xbd, 0xe0, 0x7f, 0x95,
+	0xaf, 0xa1, 0x2a, 0xe5, 0xfc, 0xdc, 0x68, 0x53, 0x91, 0x0c, 0xf5, 0x00, 0x3f, 0x80, 0x9a, 0xda,
+	0x4c, 0x46, 0xa7, 0xd9, 0x7c, 0x50, 0xc5, 0x8e, 0xe4, 0x75, 0x34, 0xcb, 0xfb, 0xa3, 0xfc, 0x36,
+	0x91, 0xf7, 0x38, 0xba, 0xa0, 0x89, 0xec, 0xc6, 0xda, 0x6e, 0x62, 0x3e, 0xe0, 0x52, 0x52, 0xfe,
+	0x9d, 0x20, 0xc6, 0x43, 0x1d, 0xde, 0xda, 0x9c, 0xa6, 0x93, 0xd9, 0x69, 0x9e, 0x8c, 0x87, 0x14,
+	0x2b, 0x05, 0xaf, 0x09, 0xb6, 0xa4, 0xe4, 0xeb, 0xff, 0xab, 0xf6, 0xbb, 0xf6, 0xd1, 0xc7, 0xb6,
+	0x1e, 0x05, 0x8e, 0x5f, 0xe2, 0x56, 0xfb, 0xa4, 0x53, 0xb7, 0x50, 0x0d, 0x2a, 0x7b, 0x6f, 0x0e,
+	0x0e, 0xf7, 0x71, 0xab, 0x5d, 0x2f, 0xc8, 0xef, 0xdc, 0xaa, 0xec, 0x08, 0xdd, 0x98, 0x0c, 0x7b,
+	0xff, 0xa7, 0x43, 0x99, 0x9d, 0xbc, 0x43, 0x8f, 0x67, 0x39, 0x04, 0x50, 0xde, 0x7b, 0x79, 0x78,
+	0xd8, 0xc2, 0x75, 0x2b, 0x5b, 0xb7, 0xea, 0x05, 0x6f, 0x0c, 0x68, 0x92, 0x9c, 0x63, 0x39, 0x8f,
+	0xb2, 0x6e, 0x32, 0xb3, 0x2e, 0x8a, 0x0b, 0xeb, 0xa2, 0x38, 0x55, 0x17, 0xf2, 0x3b, 0xac, 0x47,
+	0xe2, 0xd0, 0xfc, 0x15, 0x50, 0x34, 0xdf, 0x61, 0x92, 0xa5, 0xfe, 0x0b, 0x78, 0xf5, 0x00, 0xbe,
+	0x09, 0xf8, 0xa0, 0xd9, 0xe5, 0xbc, 0xdb, 0xa7, 0xcd, 0x90, 0x5e, 0x08, 0xce, 0xfb, 0x49, 0x3e,
+	0xce, 0xd3, 0xb2, 0xfa, 0x79, 0xf2, 0xdf, 0x01, 0x00, 0x8b, 0x31, 0x9f, 0x6f, 0xc8, 0x13, 0x00,
+	0x00,
 }
@@ -33,7 +33,7 @@ func (m *VName) Reset()         { *m = VName{} }
 func (m *VName) String() string { return proto.CompactTextString(m) }
 func (*VName) ProtoMessage()    {}
 func (*VName) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{0}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{0}
 }
 func (m *VName) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VName.Unmarshal(m, b)
@@ -103,7 +103,7 @@ func (m *VNameMask) Reset()         { *m = VNameMask{} }
 func (m *VNameMask) String() string { return proto.CompactTextString(m) }
 func (*VNameMask) ProtoMessage()    {}
 func (*VNameMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{1}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{1}
 }
 func (m *VNameMask) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VNameMask.Unmarshal(m, b)
@@ -173,7 +173,7 @@ func (m *Entry) Reset()         { *m = Entry{} }
 func (m *Entry) String() string { return proto.CompactTextString(m) }
 func (*Entry) ProtoMessage()    {}
 func (*Entry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{2}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{2}
 }
 func (m *Entry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Entry.Unmarshal(m, b)
@@ -239,7 +239,7 @@ func (m *Entries) Reset()         { *m = Entries{} }
 func (m *Entries) String() string { return proto.CompactTextString(m) }
 func (*Entries) ProtoMessage()    {}
 func (*Entries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{3}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{3}
 }
 func (m *Entries) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Entries.Unmarshal(m, b)
@@ -278,7 +278,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{4}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{4}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -324,7 +324,7 @@ func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
 func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteRequest) ProtoMessage()    {}
 func (*WriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{5}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{5}
 }
 func (m *WriteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WriteRequest.Unmarshal(m, b)
@@ -372,7 +372,7 @@ func (m *WriteRequest_Update) Reset()         { *m = WriteRequest_Update{} }
 func (m *WriteRequest_Update) String() string { return proto.CompactTextString(m) }
 func (*WriteRequest_Update) ProtoMessage()    {}
 func (*WriteRequest_Update) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{5, 0}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{5, 0}
 }
 func (m *WriteRequest_Update) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WriteRequest_Update.Unmarshal(m, b)
@@ -430,7 +430,7 @@ func (m *WriteReply) Reset()         { *m = WriteReply{} }
 func (m *WriteReply) String() string { return proto.CompactTextString(m) }
 func (*WriteReply) ProtoMessage()    {}
 func (*WriteReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{6}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{6}
 }
 func (m *WriteReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WriteReply.Unmarshal(m, b)
@@ -463,7 +463,7 @@ func (m *ScanRequest) Reset()         { *m = ScanRequest{} }
 func (m *ScanRequest) String() string { return proto.CompactTextString(m) }
 func (*ScanRequest) ProtoMessage()    {}
 func (*ScanRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{7}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{7}
 }
 func (m *ScanRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ScanRequest.Unmarshal(m, b)
@@ -516,7 +516,7 @@ func (m *CountRequest) Reset()         { *m = CountRequest{} }
 func (m *CountRequest) String() string { return proto.CompactTextString(m) }
 func (*CountRequest) ProtoMessage()    {}
 func (*CountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{8}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{8}
 }
 func (m *CountRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountRequest.Unmarshal(m, b)
@@ -561,7 +561,7 @@ func (m *CountReply) Reset()         { *m = CountReply{} }
 func (m *CountReply) String() string { return proto.CompactTextString(m) }
 func (*CountReply) ProtoMessage()    {}
 func (*CountReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{9}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{9}
 }
 func (m *CountReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountReply.Unmarshal(m, b)
@@ -591,6 +591,8 @@ func (m *CountReply) GetEntries() int64 {
 type ShardRequest struct {
 	Index                int64    `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
 	Shards               int64    `protobuf:"varint,2,opt,name=shards" json:"shards,omitempty"`
+	Start                []byte   `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -600,7 +602,7 @@ func (m *ShardRequest) Reset()         { *m = ShardRequest{} }
 func (m *ShardRequest) String() string { return proto.CompactTextString(m) }
 func (*ShardRequest) ProtoMessage()    {}
 func (*ShardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_cf94fe682d1cf1e9, []int{10}
+	return fileDescriptor_storage_49d485e18ab8f119, []int{10}
 }
 func (m *ShardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShardRequest.Unmarshal(m, b)
@@ -634,6 +636,20 @@ func (m *ShardRequest) GetShards() int64 {
 	return 0
 }
 
+func (m *ShardRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *ShardRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VName)(nil), "kythe.proto.VName")
 	proto.RegisterType((*VNameMask)(nil), "kythe.proto.VNameMask")
@@ -649,39 +665,40 @@ func init() {
 	proto.RegisterType((*ShardRequest)(nil), "kythe.proto.ShardRequest")
 }
 
-func init() { proto.RegisterFile("kythe/proto/storage.proto", fileDescriptor_storage_cf94fe682d1cf1e9) }
-
-var fileDescriptor_storage_cf94fe682d1cf1e9 = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xc1, 0x6e, 0xd4, 0x30,
-	0x10, 0x86, 0x95, 0xcd, 0x6e, 0x9a, 0x9d, 0xe4, 0x64, 0x21, 0x14, 0x0a, 0xa8, 0x4b, 0x0e, 0x08,
-	0x21, 0x94, 0x4a, 0x70, 0x80, 0x43, 0x4f, 0x20, 0x4e, 0x08, 0x84, 0x52, 0xb1, 0x1c, 0x2b, 0x93,
-	0x4c, 0xb3, 0xd1, 0x66, 0xe3, 0x60, 0x3b, 0xa5, 0x7b, 0x44, 0x82, 0x23, 0xef, 0xc3, 0xe3, 0x21,
-	0x8f, 0xdd, 0x92, 0xd2, 0x06, 0x41, 0xd5, 0x53, 0x3c, 0xbf, 0xff, 0xcc, 0x7c, 0x9e, 0xb1, 0xe1,
-	0xce, 0x7a, 0xab, 0x57, 0xb8, 0xdf, 0x49, 0xa1, 0xc5, 0xbe, 0xd2, 0x42, 0xf2, 0x0a, 0x33, 0x8a,
-	0x58, 0x44, 0x5b, 0x36, 0x48, 0xbf, 0x7a, 0x30, 0x5b, 0xbe, 0xe3, 0x1b, 0x64, 0xf7, 0x60, 0xae,
-	0xea, 0xaa, 0xe5, 0xba, 0x97, 0x98, 0x78, 0x0b, 0xef, 0xd1, 0x3c, 0xff, 0x2d, 0xb0, 0xdb, 0x10,
-	0x14, 0x42, 0x76, 0xbd, 0x4a, 0x26, 0xb4, 0xe5, 0x22, 0xc6, 0x60, 0x2a, 0x85, 0xd0, 0x89, 0x4f,
-	0x2a, 0xad, 0x8d, 0xd6, 0x71, 0xbd, 0x4a, 0xa6, 0x56, 0x33, 0x6b, 0xb6, 0x0b, 0x61, 0xc3, 0xdb,
-	0xaa, 0xe7, 0x15, 0x26, 0x33, 0xd2, 0xcf, 0xe3, 0xf4, 0xbb, 0x07, 0x73, 0x62, 0x78, 0xcb, 0xd5,
-	0xfa, 0x32, 0x47, 0x38, 0xce, 0x11, 0x5e, 0xc9, 0x11, 0x5e, 0xc1, 0x11, 0x8e, 0x70, 0x84, 0x03,
-	0x8e, 0x9f, 0x1e, 0xcc, 0x5e, 0xb7, 0x5a, 0x6e, 0xd9, 0x63, 0x08, 0x94, 0xe8, 0x65, 0x61, 0x01,
-	0xa2, 0xa7, 0x2c, 0x1b, 0xf4, 0x2c, 0x23, 0xd6, 0xdc, 0x39, 0xd8, 0x5d, 0x98, 0x63, 0x59, 0xe1,
-	0xd1, 0xba, 0x6e, 0x4b, 0xd7, 0x9c, 0xd0, 0x08, 0x6f, 0xea, 0xb6, 0x34, 0x89, 0x34, 0x97, 0x15,
-	0x5a, 0xb0, 0x91, 0x44, 0xd6, 0x61, 0x12, 0x1d, 0xf3, 0x42, 0x1f, 0xb5, 0x7c, 0x83, 0xae, 0x77,
-	0xa1, 0x11, 0x68, 0x3a, 0xf7, 0x01, 0x68, 0xf3, 0x84, 0x37, 0xbd, 0x25, 0x8f, 0x73, 0xb2, 0x2f,
-	0x8d, 0x90, 0x3e, 0x87, 0x1d, 0x43, 0x5e, 0xa3, 0x62, 0x4f, 0x60, 0x07, 0xed, 0x32, 0xf1, 0x16,
-	0xfe, 0xa5, 0x9a, 0x74, 0xc0, 0xfc, 0xcc, 0x92, 0x2e, 0x21, 0xca, 0x91, 0x97, 0x39, 0x7e, 0xee,
-	0x51, 0xe9, 0x1b, 0x3b, 0x78, 0xfa, 0x6d, 0x02, 0xf1, 0x47, 0x59, 0x6b, 0xbc, 0x4e, 0xe6, 0x17,
-	0x10, 0xf4, 0x5d, 0xc9, 0x35, 0x26, 0x13, 0x3a, 0xc1, 0xe2, 0x82, 0x77, 0x98, 0x36, 0xfb, 0x40,
-	0xbe, 0xdc, 0xf9, 0x77, 0x7f, 0x78, 0x10, 0x58, 0xe9, 0x22, 0x9e, 0x37, 0x3a, 0x97, 0xc9, 0xff,
-	0xcd, 0xc5, 0xff, 0xeb, 0x5c, 0xa6, 0x7f, 0xce, 0x25, 0x06, 0x70, 0xb8, 0x5d, 0xb3, 0x4d, 0xbf,
-	0x40, 0x74, 0x58, 0xf0, 0x76, 0xd0, 0x12, 0x07, 0xe1, 0xfd, 0x0b, 0xc4, 0xf8, 0x2d, 0xdb, 0x83,
-	0x88, 0x20, 0x3a, 0x89, 0xc7, 0xf5, 0xa9, 0x63, 0x24, 0xae, 0xf7, 0xa4, 0xa4, 0x07, 0x10, 0xbf,
-	0x12, 0x7d, 0xab, 0xcf, 0x2a, 0xdf, 0x82, 0x59, 0xdd, 0x96, 0x78, 0x4a, 0x85, 0xfd, 0xdc, 0x06,
-	0xe6, 0x6d, 0xa9, 0x15, 0x97, 0xa5, 0x7d, 0x5b, 0x7e, 0xee, 0xa2, 0xf4, 0x21, 0x80, 0xfb, 0xbb,
-	0x6b, 0xb6, 0x2c, 0x19, 0xde, 0x2f, 0x63, 0x3b, 0xbf, 0x4b, 0x07, 0x10, 0x1f, 0x9a, 0x3f, 0xae,
-	0x55, 0xe5, 0xe5, 0x03, 0xd8, 0x2b, 0xc4, 0x26, 0xab, 0x84, 0xa8, 0x1a, 0xcc, 0x4a, 0x3c, 0xd1,
-	0x42, 0x34, 0x6a, 0xd8, 0x92, 0x4f, 0x01, 0x7d, 0x9e, 0xfd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x1d,
-	0xe7, 0x96, 0xd1, 0xdd, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("kythe/proto/storage.proto", fileDescriptor_storage_49d485e18ab8f119) }
+
+var fileDescriptor_storage_49d485e18ab8f119 = []byte{
+	// 502 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcf, 0x6e, 0xd4, 0x30,
+	0x10, 0xc6, 0x95, 0xcd, 0xee, 0x36, 0x3b, 0x9b, 0x03, 0xb2, 0x10, 0x0a, 0x05, 0xd4, 0x25, 0x07,
+	0x84, 0x10, 0x4a, 0x25, 0x38, 0xc0, 0x81, 0x13, 0x88, 0x13, 0x02, 0x21, 0x57, 0x2c, 0xc7, 0xca,
+	0xc4, 0xd3, 0x6c, 0xb4, 0x59, 0x3b, 0xd8, 0x4e, 0xe9, 0x1e, 0x91, 0xe0, 0xc8, 0xfb, 0xf0, 0x78,
+	0xc8, 0x7f, 0x5a, 0x52, 0xda, 0x20, 0xa8, 0x7a, 0x8a, 0xe7, 0xf3, 0x97, 0x99, 0x9f, 0x67, 0x6c,
+	0xb8, 0xbd, 0xde, 0x9a, 0x15, 0xee, 0xb7, 0x4a, 0x1a, 0xb9, 0xaf, 0x8d, 0x54, 0xac, 0xc2, 0xc2,
+	0x45, 0x64, 0xee, 0xb6, 0x7c, 0x90, 0x7f, 0x8d, 0x60, 0xb2, 0x7c, 0xc7, 0x36, 0x48, 0xee, 0xc2,
+	0x4c, 0xd7, 0x95, 0x60, 0xa6, 0x53, 0x98, 0x45, 0x8b, 0xe8, 0xe1, 0x8c, 0xfe, 0x16, 0xc8, 0x2d,
+	0x98, 0x96, 0x52, 0xb5, 0x9d, 0xce, 0x46, 0x6e, 0x2b, 0x44, 0x84, 0xc0, 0x58, 0x49, 0x69, 0xb2,
+	0xd8, 0xa9, 0x6e, 0x6d, 0xb5, 0x96, 0x99, 0x55, 0x36, 0xf6, 0x9a, 0x5d, 0x93, 0x5d, 0x48, 0x1a,
+	0x26, 0xaa, 0x8e, 0x55, 0x98, 0x4d, 0x9c, 0x7e, 0x16, 0xe7, 0xdf, 0x23, 0x98, 0x39, 0x86, 0xb7,
+	0x4c, 0xaf, 0x2f, 0x72, 0x24, 0xc3, 0x1c, 0xc9, 0xa5, 0x1c, 0xc9, 0x25, 0x1c, 0xc9, 0x00, 0x47,
+	0xd2, 0xe3, 0xf8, 0x19, 0xc1, 0xe4, 0xb5, 0x30, 0x6a, 0x4b, 0x1e, 0xc1, 0x54, 0xcb, 0x4e, 0x95,
+	0x1e, 0x60, 0xfe, 0x84, 0x14, 0xbd, 0x9e, 0x15, 0x8e, 0x95, 0x06, 0x07, 0xb9, 0x03, 0x33, 0xe4,
+	0x15, 0x1e, 0xae, 0x6b, 0xc1, 0x43, 0x73, 0x12, 0x2b, 0xbc, 0xa9, 0x05, 0xb7, 0x89, 0x0c, 0x53,
+	0x15, 0x7a, 0xb0, 0x81, 0x44, 0xde, 0x61, 0x13, 0x1d, 0xb1, 0xd2, 0x1c, 0x0a, 0xb6, 0xc1, 0xd0,
+	0xbb, 0xc4, 0x0a, 0x6e, 0x3a, 0xf7, 0x00, 0xdc, 0xe6, 0x31, 0x6b, 0x3a, 0x4f, 0x9e, 0x52, 0x67,
+	0x5f, 0x5a, 0x21, 0x7f, 0x06, 0x3b, 0x96, 0xbc, 0x46, 0x4d, 0x1e, 0xc3, 0x0e, 0xfa, 0x65, 0x16,
+	0x2d, 0xe2, 0x0b, 0x35, 0xdd, 0x01, 0xe9, 0xa9, 0x25, 0x5f, 0xc2, 0x9c, 0x22, 0xe3, 0x14, 0x3f,
+	0x77, 0xa8, 0xcd, 0xb5, 0x1d, 0x3c, 0xff, 0x36, 0x82, 0xf4, 0xa3, 0xaa, 0x0d, 0x5e, 0x25, 0xf3,
+	0x73, 0x98, 0x76, 0x2d, 0x67, 0x06, 0xb3, 0x91, 0x3b, 0xc1, 0xe2, 0x9c, 0xb7, 0x9f, 0xb6, 0xf8,
+	0xe0, 0x7c, 0x34, 0xf8, 0x77, 0x7f, 0x44, 0x30, 0xf5, 0xd2, 0x79, 0xbc, 0x68, 0x70, 0x2e, 0xa3,
+	0xff, 0x9b, 0x4b, 0xfc, 0xd7, 0xb9, 0x8c, 0xff, 0x9c, 0x4b, 0x0a, 0x10, 0x70, 0xdb, 0x66, 0x9b,
+	0x7f, 0x81, 0xf9, 0x41, 0xc9, 0x44, 0xaf, 0x25, 0x01, 0x22, 0xfa, 0x17, 0x88, 0xe1, 0x5b, 0xb6,
+	0x07, 0x73, 0x07, 0xd1, 0x2a, 0x3c, 0xaa, 0x4f, 0x02, 0xa3, 0xe3, 0x7a, 0xef, 0x94, 0xfc, 0x05,
+	0xa4, 0xaf, 0x64, 0x27, 0xcc, 0x69, 0xe5, 0x9b, 0x30, 0xa9, 0x05, 0xc7, 0x13, 0x57, 0x38, 0xa6,
+	0x3e, 0xb0, 0x6f, 0x4b, 0xaf, 0x98, 0xe2, 0xfe, 0x6d, 0xc5, 0x34, 0x44, 0xf9, 0x03, 0x80, 0xf0,
+	0x77, 0xdb, 0x6c, 0x49, 0xd6, 0xbf, 0x5f, 0xd6, 0x76, 0x76, 0x97, 0x38, 0xa4, 0x07, 0xf6, 0x8f,
+	0x2b, 0x55, 0xb1, 0x6e, 0x6d, 0x98, 0xf2, 0x2f, 0x25, 0xa5, 0x3e, 0x20, 0x37, 0x20, 0x46, 0xc1,
+	0x43, 0x63, 0xed, 0xf2, 0xe5, 0x7d, 0xd8, 0x2b, 0xe5, 0xa6, 0xa8, 0xa4, 0xac, 0x1a, 0x2c, 0x38,
+	0x1e, 0x1b, 0x29, 0x1b, 0xdd, 0x6f, 0xdd, 0xa7, 0xa9, 0xfb, 0x3c, 0xfd, 0x35, 0x00, 0x7f, 0xe6,
+	0x4e, 0xda, 0x05, 0x05, 0x00, 0x00,
 }
@@ -39,7 +39,7 @@ func (x SnippetsKind) String() string {
 	return proto.EnumName(SnippetsKind_name, int32(x))
 }
 func (SnippetsKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{0}
 }
 
 type Location_Kind int32
@@ -62,7 +62,7 @@ func (x Location_Kind) String() string {
 	return proto.EnumName(Location_Kind_name, int32(x))
 }
 func (Location_Kind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{0, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{0, 0}
 }
 
 type DecorationsRequest_SpanKind int32
@@ -85,7 +85,7 @@ func (x DecorationsRequest_SpanKind) String() string {
 	return proto.EnumName(DecorationsRequest_SpanKind_name, int32(x))
 }
 func (DecorationsRequest_SpanKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{1, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{1, 0}
 }
 
 type DecorationsReply_Override_Kind int32
@@ -108,7 +108,7 @@ func (x DecorationsReply_Override_Kind) String() string {
 	return proto.EnumName(DecorationsReply_Override_Kind_name, int32(x))
 }
 func (DecorationsReply_Override_Kind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{2, 1, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{2, 1, 0}
 }
 
 type CrossReferencesRequest_DefinitionKind int32
@@ -137,7 +137,7 @@ func (x CrossReferencesRequest_DefinitionKind) String() string {
 	return proto.EnumName(CrossReferencesRequest_DefinitionKind_name, int32(x))
 }
 func (CrossReferencesRequest_DefinitionKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{3, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3, 0}
 }
 
 type CrossReferencesRequest_DeclarationKind int32
@@ -160,7 +160,7 @@ func (x CrossReferencesRequest_DeclarationKind) String() string {
 	return proto.EnumName(CrossReferencesRequest_DeclarationKind_name, int32(x))
 }
 func (CrossReferencesRequest_DeclarationKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{3, 1}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3, 1}
 }
 
 type CrossReferencesRequest_ReferenceKind int32
@@ -189,7 +189,7 @@ func (x CrossReferencesRequest_ReferenceKind) String() string {
 	return proto.EnumName(CrossReferencesRequest_ReferenceKind_name, int32(x))
 }
 func (CrossReferencesRequest_ReferenceKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{3, 2}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3, 2}
 }
 
 type CrossReferencesRequest_CallerKind int32
@@ -215,7 +215,7 @@ func (x CrossReferencesRequest_CallerKind) String() string {
 	return proto.EnumName(CrossReferencesRequest_CallerKind_name, int32(x))
 }
 func (CrossReferencesRequest_CallerKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{3, 3}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3, 3}
 }
 
 type Location struct {
@@ -231,7 +231,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{0}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Location.Unmarshal(m, b)
@@ -292,7 +292,7 @@ func (m *DecorationsRequest) Reset()         { *m = DecorationsRequest{} }
 func (m *DecorationsRequest) String() string { return proto.CompactTextString(m) }
 func (*DecorationsRequest) ProtoMessage()    {}
 func (*DecorationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{1}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{1}
 }
 func (m *DecorationsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationsRequest.Unmarshal(m, b)
@@ -400,7 +400,7 @@ func (m *DecorationsReply) Reset()         { *m = DecorationsReply{} }
 func (m *DecorationsReply) String() string { return proto.CompactTextString(m) }
 func (*DecorationsReply) ProtoMessage()    {}
 func (*DecorationsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{2}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{2}
 }
 func (m *DecorationsReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationsReply.Unmarshal(m, b)
@@ -490,7 +490,7 @@ func (m *DecorationsReply_Reference) Reset()         { *m = DecorationsReply_Ref
 func (m *DecorationsReply_Reference) String() string { return proto.CompactTextString(m) }
 func (*DecorationsReply_Reference) ProtoMessage()    {}
 func (*DecorationsReply_Reference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{2, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{2, 0}
 }
 func (m *DecorationsReply_Reference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationsReply_Reference.Unmarshal(m, b)
@@ -552,7 +552,7 @@ func (m *DecorationsReply_Override) Reset()         { *m = DecorationsReply_Over
 func (m *DecorationsReply_Override) String() string { return proto.CompactTextString(m) }
 func (*DecorationsReply_Override) ProtoMessage()    {}
 func (*DecorationsReply_Override) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{2, 1}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{2, 1}
 }
 func (m *DecorationsReply_Override) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationsReply_Override.Unmarshal(m, b)
@@ -611,7 +611,7 @@ func (m *DecorationsReply_Overrides) Reset()         { *m = DecorationsReply_Ove
 func (m *DecorationsReply_Overrides) String() string { return proto.CompactTextString(m) }
 func (*DecorationsReply_Overrides) ProtoMessage()    {}
 func (*DecorationsReply_Overrides) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{2, 2}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{2, 2}
 }
 func (m *DecorationsReply_Overrides) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecorationsReply_Overrides.Unmarshal(m, b)
@@ -639,28 +639,29 @@ func (m *DecorationsReply_Overrides) GetOverride() []*DecorationsReply_Override
 }
 
 type CrossReferencesRequest struct {
-	Ticket               []string                               `protobuf:"bytes,1,rep,name=ticket" json:"ticket,omitempty"`
-	DefinitionKind       CrossReferencesRequest_DefinitionKind  `protobuf:"varint,2,opt,name=definition_kind,json=definitionKind,enum=kythe.proto.CrossReferencesRequest_DefinitionKind" json:"definition_kind,omitempty"`
-	DeclarationKind      CrossReferencesRequest_DeclarationKind `protobuf:"varint,7,opt,name=declaration_kind,json=declarationKind,enum=kythe.proto.CrossReferencesRequest_DeclarationKind" json:"declaration_kind,omitempty"`
-	ReferenceKind        CrossReferencesRequest_ReferenceKind   `protobuf:"varint,3,opt,name=reference_kind,json=referenceKind,enum=kythe.proto.CrossReferencesRequest_ReferenceKind" json:"reference_kind,omitempty"`
-	CallerKind           CrossReferencesRequest_CallerKind      `protobuf:"varint,12,opt,name=caller_kind,json=callerKind,enum=kythe.proto.CrossReferencesRequest_CallerKind" json:"caller_kind,omitempty"`
-	Filter               []string                               `protobuf:"bytes,5,rep,name=filter" json:"filter,omitempty"`
-	RelatedNodeKind      []string                               `protobuf:"bytes,14,rep,name=related_node_kind,json=relatedNodeKind" json:"related_node_kind,omitempty"`
-	AnchorText           bool                                   `protobuf:"varint,6,opt,name=anchor_text,json=anchorText" json:"anchor_text,omitempty"`
-	NodeDefinitions      bool                                   `protobuf:"varint,8,opt,name=node_definitions,json=nodeDefinitions" json:"node_definitions,omitempty"`
-	PageSize             int32                                  `protobuf:"varint,10,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
-	PageToken            string                                 `protobuf:"bytes,11,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
-	Snippets             SnippetsKind                           `protobuf:"varint,13,opt,name=snippets,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
-	XXX_unrecognized     []byte                                 `json:"-"`
-	XXX_sizecache        int32                                  `json:"-"`
+	Ticket               []string                                 `protobuf:"bytes,1,rep,name=ticket" json:"ticket,omitempty"`
+	DefinitionKind       CrossReferencesRequest_DefinitionKind    `protobuf:"varint,2,opt,name=definition_kind,json=definitionKind,enum=kythe.proto.CrossReferencesRequest_DefinitionKind" json:"definition_kind,omitempty"`
+	DeclarationKind      CrossReferencesRequest_DeclarationKind   `protobuf:"varint,7,opt,name=declaration_kind,json=declarationKind,enum=kythe.proto.CrossReferencesRequest_DeclarationKind" json:"declaration_kind,omitempty"`
+	ReferenceKind        CrossReferencesRequest_ReferenceKind     `protobuf:"varint,3,opt,name=reference_kind,json=referenceKind,enum=kythe.proto.CrossReferencesRequest_ReferenceKind" json:"reference_kind,omitempty"`
+	CallerKind           CrossReferencesRequest_CallerKind        `protobuf:"varint,12,opt,name=caller_kind,json=callerKind,enum=kythe.proto.CrossReferencesRequest_CallerKind" json:"caller_kind,omitempty"`
+	Filter               []string                                 `protobuf:"bytes,5,rep,name=filter" json:"filter,omitempty"`
+	RelatedNodeKind      []string                                 `protobuf:"bytes,14,rep,name=related_node_kind,json=relatedNodeKind" json:"related_node_kind,omitempty"`
+	AnchorText           bool                                     `protobuf:"varint,6,opt,name=anchor_text,json=anchorText" json:"anchor_text,omitempty"`
+	NodeDefinitions      bool                                     `protobuf:"varint,8,opt,name=node_definitions,json=nodeDefinitions" json:"node_definitions,omitempty"`
+	PageSize             int32                                    `protobuf:"varint,10,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	PageToken            string                                   `protobuf:"bytes,11,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+	Snippets             SnippetsKind                             `protobuf:"varint,13,opt,name=snippets,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
+	CorpusPathFilter     *CrossReferencesRequest_CorpusPathFilter `protobuf:"bytes,15,opt,name=corpus_path_filter,json=corpusPathFilter" json:"corpus_path_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
 }
 
 func (m *CrossReferencesRequest) Reset()         { *m = CrossReferencesRequest{} }
 func (m *CrossReferencesRequest) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesRequest) ProtoMessage()    {}
 func (*CrossReferencesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{3}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3}
 }
 func (m *CrossReferencesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesRequest.Unmarshal(m, b)
@@ -764,6 +765,69 @@ func (m *CrossReferencesRequest) GetSnippets() SnippetsKind {
 	return SnippetsKind_NONE
 }
 
+func (m *CrossReferencesRequest) GetCorpusPathFilter() *CrossReferencesRequest_CorpusPathFilter {
+	if m != nil {
+		return m.CorpusPathFilter
+	}
+	return nil
+}
+
+type CrossReferencesRequest_CorpusPathFilter struct {
+	Corpus               string   `protobuf:"bytes,1,opt,name=corpus" json:"corpus,omitempty"`
+	Root                 string   `protobuf:"bytes,2,opt,name=root" json:"root,omitempty"`
+	Path                 string   `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossReferencesRequest_CorpusPathFilter) Reset() {
+	*m = CrossReferencesRequest_CorpusPathFilter{}
+}
+func (m *CrossReferencesRequest_CorpusPathFilter) String() string { return proto.CompactTextString(m) }
+func (*CrossReferencesRequest_CorpusPathFilter) ProtoMessage()    {}
+func (*CrossReferencesRequest_CorpusPathFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{3, 0}
+}
+func (m *CrossReferencesRequest_CorpusPathFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter.Unmarshal(m, b)
+}
+func (m *CrossReferencesRequest_CorpusPathFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter.Marshal(b, m, deterministic)
+}
+func (dst *CrossReferencesRequest_CorpusPathFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter.Merge(dst, src)
+}
+func (m *CrossReferencesRequest_CorpusPathFilter) XXX_Size() int {
+	return xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter.Size(m)
+}
+func (m *CrossReferencesRequest_CorpusPathFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossReferencesRequest_CorpusPathFilter proto.InternalMessageInfo
+
+func (m *CrossReferencesRequest_CorpusPathFilter) GetCorpus() string {
+	if m != nil {
+		return m.Corpus
+	}
+	return ""
+}
+
+func (m *CrossReferencesRequest_CorpusPathFilter) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+func (m *CrossReferencesRequest_CorpusPathFilter) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
 type Anchor struct {
 	Ticket               string                `protobuf:"bytes,1,opt,name=ticket" json:"ticket,omitempty"`
 	Kind                 string                `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
@@ -781,7 +845,7 @@ func (m *Anchor) Reset()         { *m = Anchor{} }
 func (m *Anchor) String() string { return proto.CompactTextString(m) }
 func (*Anchor) ProtoMessage()    {}
 func (*Anchor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{4}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{4}
 }
 func (m *Anchor) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Anchor.Unmarshal(m, b)
@@ -862,7 +926,7 @@ func (m *Printable) Reset()         { *m = Printable{} }
 func (m *Printable) String() string { return proto.CompactTextString(m) }
 func (*Printable) ProtoMessage()    {}
 func (*Printable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{5}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{5}
 }
 func (m *Printable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Printable.Unmarshal(m, b)
@@ -911,7 +975,7 @@ func (m *CrossReferencesReply) Reset()         { *m = CrossReferencesReply{} }
 func (m *CrossReferencesReply) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesReply) ProtoMessage()    {}
 func (*CrossReferencesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{6}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{6}
 }
 func (m *CrossReferencesReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesReply.Unmarshal(m, b)
@@ -979,7 +1043,7 @@ func (m *CrossReferencesReply_RelatedNode) Reset()         { *m = CrossReference
 func (m *CrossReferencesReply_RelatedNode) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesReply_RelatedNode) ProtoMessage()    {}
 func (*CrossReferencesReply_RelatedNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{6, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{6, 0}
 }
 func (m *CrossReferencesReply_RelatedNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesReply_RelatedNode.Unmarshal(m, b)
@@ -1034,7 +1098,7 @@ func (m *CrossReferencesReply_RelatedAnchor) Reset()         { *m = CrossReferen
 func (m *CrossReferencesReply_RelatedAnchor) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesReply_RelatedAnchor) ProtoMessage()    {}
 func (*CrossReferencesReply_RelatedAnchor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{6, 1}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{6, 1}
 }
 func (m *CrossReferencesReply_RelatedAnchor) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesReply_RelatedAnchor.Unmarshal(m, b)
@@ -1101,7 +1165,7 @@ func (m *CrossReferencesReply_CrossReferenceSet) Reset() {
 func (m *CrossReferencesReply_CrossReferenceSet) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesReply_CrossReferenceSet) ProtoMessage()    {}
 func (*CrossReferencesReply_CrossReferenceSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{6, 2}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{6, 2}
 }
 func (m *CrossReferencesReply_CrossReferenceSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesReply_CrossReferenceSet.Unmarshal(m, b)
@@ -1186,7 +1250,7 @@ func (m *CrossReferencesReply_Total) Reset()         { *m = CrossReferencesReply
 func (m *CrossReferencesReply_Total) String() string { return proto.CompactTextString(m) }
 func (*CrossReferencesReply_Total) ProtoMessage()    {}
 func (*CrossReferencesReply_Total) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{6, 3}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{6, 3}
 }
 func (m *CrossReferencesReply_Total) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CrossReferencesReply_Total.Unmarshal(m, b)
@@ -1261,7 +1325,7 @@ func (m *DocumentationRequest) Reset()         { *m = DocumentationRequest{} }
 func (m *DocumentationRequest) String() string { return proto.CompactTextString(m) }
 func (*DocumentationRequest) ProtoMessage()    {}
 func (*DocumentationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{7}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{7}
 }
 func (m *DocumentationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DocumentationRequest.Unmarshal(m, b)
@@ -1315,7 +1379,7 @@ func (m *DocumentationReply) Reset()         { *m = DocumentationReply{} }
 func (m *DocumentationReply) String() string { return proto.CompactTextString(m) }
 func (*DocumentationReply) ProtoMessage()    {}
 func (*DocumentationReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{8}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{8}
 }
 func (m *DocumentationReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DocumentationReply.Unmarshal(m, b)
@@ -1370,7 +1434,7 @@ func (m *DocumentationReply_Document) Reset()         { *m = DocumentationReply_
 func (m *DocumentationReply_Document) String() string { return proto.CompactTextString(m) }
 func (*DocumentationReply_Document) ProtoMessage()    {}
 func (*DocumentationReply_Document) Descriptor() ([]byte, []int) {
-	return fileDescriptor_xref_22631e27e6c95add, []int{8, 0}
+	return fileDescriptor_xref_1767d5d2fe8e7743, []int{8, 0}
 }
 func (m *DocumentationReply_Document) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DocumentationReply_Document.Unmarshal(m, b)
@@ -1429,6 +1493,7 @@ func init() {
 	proto.RegisterType((*DecorationsReply_Override)(nil), "kythe.proto.DecorationsReply.Override")
 	proto.RegisterType((*DecorationsReply_Overrides)(nil), "kythe.proto.DecorationsReply.Overrides")
 	proto.RegisterType((*CrossReferencesRequest)(nil), "kythe.proto.CrossReferencesRequest")
+	proto.RegisterType((*CrossReferencesRequest_CorpusPathFilter)(nil), "kythe.proto.CrossReferencesRequest.CorpusPathFilter")
 	proto.RegisterType((*Anchor)(nil), "kythe.proto.Anchor")
 	proto.RegisterType((*Printable)(nil), "kythe.proto.Printable")
 	proto.RegisterType((*CrossReferencesReply)(nil), "kythe.proto.CrossReferencesReply")
@@ -1455,133 +1520,136 @@ func init() {
 	proto.RegisterEnum("kythe.proto.CrossReferencesRequest_CallerKind", CrossReferencesRequest_CallerKind_name, CrossReferencesRequest_CallerKind_value)
 }
 
-func init() { proto.RegisterFile("kythe/proto/xref.proto", fileDescriptor_xref_22631e27e6c95add) }
-
-var fileDescriptor_xref_22631e27e6c95add = []byte{
-	// 1985 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x73, 0xdb, 0xc6,
-	0x15, 0x17, 0x48, 0x90, 0x02, 0x1f, 0xf8, 0x01, 0xad, 0x15, 0x15, 0x66, 0x9a, 0x58, 0x86, 0xdb,
-	0x58, 0xfe, 0x88, 0x3c, 0xa1, 0xa7, 0x9d, 0x4c, 0xda, 0xa4, 0x95, 0x44, 0x2a, 0xa5, 0xaa, 0x90,
-	0xea, 0x92, 0x4e, 0x3d, 0xe3, 0x99, 0xa2, 0x30, 0xb1, 0x92, 0x31, 0x82, 0x00, 0x05, 0x80, 0x6c,
-	0x29, 0x87, 0xce, 0xf4, 0xde, 0x53, 0xff, 0x8a, 0x5e, 0x7b, 0xed, 0xb1, 0xc7, 0xfe, 0x2f, 0xbd,
-	0xb7, 0x87, 0x74, 0x3a, 0x78, 0x0b, 0x80, 0x0b, 0x7e, 0x88, 0x94, 0x26, 0x97, 0xdc, 0xb0, 0x6f,
-	0xdf, 0xfb, 0xbd, 0xdd, 0xf7, 0xbd, 0x80, 0x8d, 0xd3, 0xab, 0xe8, 0x0d, 0x7b, 0x76, 0x1e, 0xf8,
-	0x91, 0xff, 0xec, 0x32, 0x60, 0xc7, 0xdb, 0xf8, 0x49, 0x54, 0xa4, 0xf3, 0x45, 0x53, 0x17, 0x99,
-	0x46, 0xfe, 0xd9, 0x99, 0xef, 0xf1, 0x1d, 0xe3, 0x6f, 0x12, 0x28, 0x87, 0xfe, 0xc8, 0x8a, 0x1c,
-	0xdf, 0x23, 0x1b, 0x50, 0x8e, 0x9c, 0xd1, 0x29, 0x8b, 0x74, 0x69, 0x53, 0xda, 0xaa, 0xd0, 0x64,
-	0x45, 0xb6, 0x41, 0x3e, 0x75, 0x3c, 0x5b, 0x2f, 0x6c, 0x4a, 0x5b, 0xf5, 0x56, 0x73, 0x5b, 0x80,
-	0xde, 0x4e, 0x85, 0xb7, 0x7f, 0xeb, 0x78, 0x36, 0x45, 0x3e, 0xf2, 0x14, 0xe4, 0xf0, 0xdc, 0xf2,
-	0xf4, 0xd2, 0xa6, 0xb4, 0xa5, 0xb6, 0xf4, 0x1c, 0x7f, 0xa2, 0x7d, 0x70, 0x6e, 0x79, 0x14, 0xb9,
-	0x8c, 0x26, 0xc8, 0xb1, 0x2c, 0x51, 0x40, 0xde, 0xef, 0x1e, 0x76, 0xb4, 0x95, 0xf8, 0x6b, 0x70,
-	0xb4, 0xd3, 0xd3, 0xa4, 0x03, 0x59, 0x29, 0x6a, 0xf2, 0x81, 0xac, 0xc8, 0x5a, 0xc9, 0xf8, 0x77,
-	0x11, 0x48, 0x9b, 0x8d, 0xfc, 0x00, 0xf5, 0x85, 0x94, 0x7d, 0x73, 0xc1, 0xc2, 0x88, 0x7c, 0x02,
-	0x8a, 0x9b, 0x9c, 0x01, 0x8f, 0xad, 0xb6, 0xde, 0x9b, 0x79, 0x40, 0x9a, 0xb1, 0x91, 0x0e, 0x54,
-	0x62, 0xcd, 0x26, 0x5e, 0x0a, 0xf0, 0x52, 0x5b, 0x39, 0x99, 0x69, 0x35, 0x78, 0x60, 0xbc, 0xa2,
-	0x12, 0x26, 0x5f, 0xe4, 0x3e, 0x54, 0x6d, 0x27, 0x88, 0xae, 0xcc, 0xd7, 0x17, 0xc7, 0xc7, 0x2c,
-	0x40, 0xf3, 0x54, 0xa9, 0x8a, 0xb4, 0x5d, 0x24, 0x91, 0x7b, 0xa0, 0x86, 0xfe, 0x45, 0x30, 0x62,
-	0x66, 0xc4, 0x2e, 0x23, 0xbd, 0xb8, 0x29, 0x6d, 0x29, 0x14, 0x38, 0x69, 0xc8, 0x2e, 0x23, 0xf2,
-	0x21, 0x40, 0xc0, 0x8e, 0x59, 0xc0, 0xbc, 0x11, 0x0b, 0x75, 0x99, 0xef, 0x8f, 0x29, 0xe4, 0x63,
-	0x20, 0x91, 0x15, 0x9c, 0xb0, 0xc8, 0xb4, 0xd9, 0xb1, 0xe3, 0x39, 0x78, 0x26, 0xbd, 0x8c, 0x7c,
-	0x6b, 0x7c, 0xa7, 0x3d, 0xde, 0x88, 0x3d, 0x78, 0xec, 0xb8, 0x11, 0x0b, 0xf4, 0xd2, 0x66, 0x31,
-	0xf6, 0x20, 0x5f, 0x91, 0x27, 0xb0, 0xc6, 0x2e, 0x23, 0xe6, 0xd9, 0xa1, 0xe9, 0xbf, 0x65, 0x41,
-	0xe0, 0xd8, 0x2c, 0xd4, 0x57, 0x11, 0x45, 0x4b, 0x36, 0xfa, 0x29, 0x9d, 0x6c, 0x82, 0x6a, 0x3b,
-	0xd6, 0x89, 0xe7, 0x87, 0x91, 0x33, 0x0a, 0x75, 0x05, 0xd9, 0x44, 0x12, 0xf9, 0x19, 0x28, 0xa1,
-	0xe7, 0x9c, 0x9f, 0xb3, 0x28, 0xd4, 0x2b, 0x68, 0xbf, 0xbb, 0x39, 0xfb, 0x0d, 0x92, 0xcd, 0xc4,
-	0x60, 0xc9, 0xca, 0x78, 0x0a, 0x4a, 0x6a, 0x46, 0xd2, 0x00, 0xf5, 0xf7, 0xdd, 0xe1, 0x6f, 0xba,
-	0x3d, 0x13, 0x5d, 0xbd, 0x12, 0x13, 0x76, 0x68, 0xff, 0x45, 0xaf, 0xcd, 0x09, 0x92, 0xf1, 0x4f,
-	0x00, 0x2d, 0xe7, 0x88, 0x73, 0xf7, 0xea, 0x36, 0xde, 0x9e, 0xf0, 0x01, 0xf7, 0x92, 0xe8, 0x83,
-	0x26, 0x28, 0xcc, 0x1b, 0xf9, 0xb6, 0xe3, 0x9d, 0xa0, 0x87, 0x2a, 0x34, 0x5b, 0xc7, 0xa1, 0x92,
-	0x79, 0x43, 0x97, 0x37, 0x8b, 0x5b, 0x6a, 0xeb, 0xe1, 0xfc, 0x50, 0x39, 0x77, 0xaf, 0xb6, 0x69,
-	0xca, 0x4e, 0xc7, 0x92, 0xe4, 0x0b, 0x80, 0xb1, 0xfd, 0xd0, 0x37, 0x6a, 0xeb, 0xc3, 0x59, 0x79,
-	0xd1, 0xce, 0xb8, 0xa8, 0x20, 0x41, 0xbe, 0x80, 0x92, 0xe7, 0xc7, 0x3e, 0x6b, 0xa0, 0xe8, 0xd6,
-	0xf5, 0x47, 0xe8, 0xc5, 0xac, 0x1d, 0x2f, 0x0a, 0xae, 0x28, 0x17, 0x23, 0x0e, 0xac, 0x8f, 0xe3,
-	0xc7, 0x4c, 0x4d, 0x13, 0xea, 0x1a, 0xc2, 0xfd, 0xfc, 0x7a, 0xb8, 0x71, 0x80, 0xa5, 0xd6, 0x4d,
-	0xc0, 0xef, 0xd8, 0xd3, 0x3b, 0xe4, 0x8f, 0xb3, 0x42, 0x6d, 0x0d, 0xf5, 0x3c, 0xbf, 0x5e, 0x4f,
-	0x67, 0x22, 0x10, 0xb9, 0x92, 0xa9, 0xf8, 0x6c, 0xfe, 0x5d, 0x82, 0x4a, 0x66, 0x65, 0xf2, 0x00,
-	0x6a, 0x49, 0x86, 0x24, 0xb5, 0xab, 0x80, 0x2e, 0xac, 0x72, 0xe2, 0x90, 0x57, 0x30, 0x92, 0x54,
-	0x30, 0xee, 0x5e, 0x5e, 0xa5, 0x9e, 0xc0, 0xda, 0x54, 0x6a, 0x61, 0x06, 0x56, 0xa8, 0x36, 0x99,
-	0x59, 0x37, 0x2b, 0x69, 0x07, 0xb2, 0x22, 0x69, 0x85, 0x03, 0x59, 0x01, 0x4d, 0x3d, 0x90, 0x15,
-	0x55, 0xab, 0x36, 0xff, 0x5c, 0x00, 0x25, 0xbd, 0x01, 0xd6, 0x59, 0x54, 0x90, 0xd5, 0x59, 0x5c,
-	0xcd, 0x3e, 0x51, 0x69, 0xce, 0x89, 0x7e, 0x95, 0x2b, 0xca, 0x4f, 0xae, 0x37, 0x6d, 0xaa, 0x5a,
-	0xac, 0xd2, 0x1d, 0xa8, 0x9d, 0x59, 0xc1, 0x29, 0xb3, 0x4d, 0x9e, 0x0b, 0x78, 0x77, 0xb5, 0xb5,
-	0x39, 0xeb, 0x6e, 0x5f, 0x21, 0xe3, 0x00, 0xf9, 0x68, 0xf5, 0x4c, 0x58, 0x19, 0x46, 0x52, 0xbe,
-	0x6b, 0x50, 0xe9, 0x7f, 0xdd, 0xa1, 0xb4, 0xdb, 0xee, 0x0c, 0xb4, 0x15, 0xa2, 0xc2, 0x6a, 0xe7,
-	0xe5, 0xb0, 0xd3, 0x6b, 0x0f, 0xd2, 0x32, 0xde, 0xec, 0x43, 0x65, 0x5c, 0x64, 0x76, 0x41, 0x49,
-	0xc3, 0x43, 0x97, 0x30, 0x3a, 0x3e, 0x5a, 0xee, 0x0a, 0x34, 0x93, 0x6b, 0x7e, 0x0d, 0x30, 0x0e,
-	0x75, 0xa2, 0x41, 0xf1, 0x94, 0x5d, 0x25, 0x26, 0x8d, 0x3f, 0x49, 0x0b, 0x4a, 0x6f, 0x2d, 0xf7,
-	0x82, 0xa1, 0x8d, 0xd4, 0xd6, 0x8f, 0x67, 0xdd, 0x2c, 0x06, 0xe8, 0x7a, 0xc7, 0x3e, 0xe5, 0xac,
-	0x9f, 0x15, 0x3e, 0x95, 0x9a, 0xaf, 0x40, 0x9f, 0x17, 0xf3, 0x33, 0xb4, 0x3c, 0xca, 0x6b, 0xb9,
-	0x93, 0xd3, 0xb2, 0xe3, 0x8d, 0xde, 0xf8, 0x81, 0x08, 0xee, 0xc2, 0x7b, 0x33, 0x03, 0x7d, 0x06,
-	0xf2, 0xe7, 0x79, 0xe4, 0x87, 0xcb, 0x19, 0x28, 0x14, 0xb4, 0x19, 0xdf, 0xad, 0xc2, 0xc6, 0x5e,
-	0xe0, 0x87, 0x61, 0x96, 0x30, 0x59, 0xe3, 0x14, 0xbb, 0x7d, 0x51, 0xe8, 0xf6, 0xaf, 0xa0, 0x21,
-	0xd4, 0x0a, 0x21, 0xc6, 0x5a, 0x39, 0xfd, 0xb3, 0x51, 0x85, 0x62, 0x81, 0xa1, 0x56, 0xb7, 0x73,
-	0x6b, 0xf2, 0x07, 0xd0, 0x6c, 0x36, 0x72, 0x2d, 0x7e, 0x72, 0x8e, 0xbe, 0x8a, 0xe8, 0xcf, 0x97,
-	0x43, 0xcf, 0x64, 0x11, 0xbe, 0x61, 0xe7, 0x09, 0xe4, 0x25, 0xd4, 0xb3, 0xaa, 0x6b, 0x66, 0x29,
-	0x5f, 0x6f, 0x7d, 0xb2, 0x0c, 0x7a, 0x46, 0x41, 0xec, 0x5a, 0x20, 0x2e, 0x49, 0x1f, 0xd4, 0x91,
-	0xe5, 0xba, 0x2c, 0xe0, 0xb0, 0x55, 0x84, 0xdd, 0x5e, 0x06, 0x76, 0x0f, 0xc5, 0x10, 0x13, 0x46,
-	0xd9, 0xf7, 0xdc, 0x5e, 0xfd, 0x18, 0xd6, 0x02, 0xe6, 0x5a, 0x11, 0xb3, 0xcd, 0xb8, 0x78, 0x73,
-	0x75, 0x75, 0x64, 0x69, 0x24, 0x1b, 0x71, 0xd0, 0x22, 0xc6, 0x3d, 0x50, 0x2d, 0x8c, 0x30, 0xde,
-	0xdb, 0xf8, 0x5c, 0x00, 0x9c, 0x84, 0xbd, 0xed, 0x11, 0x68, 0x08, 0x22, 0x4e, 0x0f, 0xbc, 0xa1,
-	0x37, 0x62, 0xba, 0x38, 0x3b, 0xbc, 0x0f, 0x95, 0x73, 0xeb, 0x84, 0x99, 0xa1, 0xf3, 0x2d, 0xc3,
-	0xa9, 0xa8, 0x44, 0x95, 0x98, 0x30, 0x70, 0xbe, 0x65, 0xe4, 0x03, 0x00, 0xdc, 0x8c, 0xfc, 0x53,
-	0xe6, 0xe9, 0x2a, 0xc6, 0x28, 0xb2, 0x0f, 0x63, 0x42, 0x6e, 0x20, 0xa8, 0x2d, 0x3f, 0x10, 0xbc,
-	0x81, 0x7a, 0x3e, 0x5e, 0x08, 0x81, 0x7a, 0xaf, 0x6f, 0xb6, 0x3b, 0xfb, 0xdd, 0x5e, 0x77, 0xd8,
-	0xed, 0xf7, 0xe2, 0x52, 0x72, 0x07, 0x1a, 0x3b, 0x87, 0x87, 0x39, 0xa2, 0x44, 0xd6, 0x41, 0xdb,
-	0x7f, 0x31, 0x41, 0x2d, 0x90, 0x1f, 0xc1, 0x9d, 0xdd, 0x6e, 0xaf, 0xdd, 0xed, 0x7d, 0x99, 0xdb,
-	0x28, 0x1a, 0xbf, 0x84, 0xc6, 0x44, 0xec, 0xc4, 0xb0, 0xa8, 0x6a, 0xef, 0x70, 0x87, 0xee, 0xa4,
-	0xba, 0xd6, 0x41, 0xe3, 0xba, 0x04, 0xaa, 0x64, 0xd8, 0x50, 0xcb, 0xc5, 0x06, 0x59, 0x83, 0x5a,
-	0xaf, 0x6f, 0xd2, 0xce, 0x7e, 0x87, 0x76, 0x7a, 0x7b, 0x9d, 0xe4, 0x94, 0x7b, 0xb1, 0xa8, 0x40,
-	0x94, 0xe2, 0xf3, 0xf4, 0xfa, 0x3d, 0x73, 0x72, 0xa3, 0x10, 0xdf, 0x73, 0x82, 0x56, 0x34, 0xf6,
-	0x01, 0xc6, 0xa1, 0x42, 0xea, 0x00, 0xbd, 0x3e, 0x4a, 0x76, 0x68, 0x8c, 0x4f, 0xa0, 0xde, 0xee,
-	0xd2, 0xce, 0xde, 0x30, 0xa3, 0xa1, 0x11, 0xd2, 0x9a, 0x9b, 0x51, 0x0b, 0x7c, 0x5c, 0x3e, 0x90,
-	0x15, 0x5b, 0x63, 0xc6, 0x77, 0x12, 0x94, 0x79, 0x0d, 0x9a, 0x3b, 0xdd, 0x13, 0xa1, 0x91, 0xa4,
-	0xbd, 0x71, 0x03, 0xca, 0xe7, 0x56, 0xc0, 0xbc, 0x28, 0xe9, 0x98, 0xc9, 0x2a, 0x6b, 0x83, 0xb0,
-	0x4c, 0x1b, 0x8c, 0x91, 0xb3, 0xb0, 0xac, 0x50, 0xfc, 0x26, 0x3a, 0xac, 0x26, 0xee, 0xc7, 0xbc,
-	0xaf, 0xd0, 0x74, 0x49, 0x7e, 0x01, 0xd5, 0xe4, 0xd3, 0x44, 0x1d, 0xea, 0x02, 0x1d, 0x6a, 0xc2,
-	0x3d, 0xe0, 0x1d, 0x97, 0xdf, 0xb9, 0xa4, 0x95, 0x0f, 0x64, 0x45, 0xd1, 0x2a, 0x07, 0xb2, 0x52,
-	0xd1, 0xc0, 0x18, 0x42, 0xe5, 0x28, 0x70, 0xbc, 0xc8, 0x7a, 0xed, 0x32, 0x72, 0x17, 0x94, 0xc0,
-	0x7a, 0xc7, 0x53, 0x85, 0xdb, 0x60, 0x35, 0xb0, 0xde, 0x61, 0x9e, 0x3c, 0x05, 0xd9, 0x75, 0xbc,
-	0x53, 0xbd, 0x80, 0xad, 0x68, 0xa6, 0xd2, 0x43, 0xc7, 0x3b, 0xa5, 0xc8, 0x65, 0xfc, 0xaf, 0x0e,
-	0xeb, 0x53, 0xc9, 0x1e, 0x8f, 0xa7, 0x9f, 0x43, 0x29, 0xf2, 0x23, 0xcb, 0x4d, 0xe6, 0x84, 0x87,
-	0xd7, 0x97, 0x87, 0xb8, 0x6a, 0x0f, 0x63, 0x76, 0xca, 0xa5, 0x88, 0x05, 0xda, 0x28, 0x66, 0x32,
-	0x85, 0x37, 0x81, 0x34, 0x63, 0x44, 0x9b, 0x89, 0x34, 0x41, 0xe4, 0xd3, 0x53, 0x63, 0x94, 0xa7,
-	0x92, 0xdd, 0x74, 0x92, 0xe4, 0x37, 0x7d, 0xba, 0x18, 0x77, 0x7a, 0x9a, 0x3c, 0x9b, 0x33, 0x4d,
-	0x16, 0x11, 0xf2, 0xb3, 0xc5, 0x90, 0x37, 0x9b, 0x28, 0x3f, 0x82, 0x86, 0xc7, 0x2e, 0x23, 0x53,
-	0x28, 0x40, 0x80, 0xde, 0xab, 0xc5, 0xe4, 0xa3, 0xb4, 0x08, 0x35, 0x6d, 0x50, 0xe9, 0xb8, 0x3e,
-	0xce, 0x8d, 0xf7, 0x07, 0x50, 0xc3, 0x32, 0x9a, 0xeb, 0x6e, 0x15, 0x5a, 0x4d, 0x89, 0x98, 0x7d,
-	0x3a, 0xac, 0xfa, 0x81, 0xed, 0x78, 0x96, 0x8b, 0x19, 0x50, 0xa2, 0xe9, 0xb2, 0xf9, 0x2f, 0x29,
-	0x2e, 0x06, 0xa8, 0x26, 0x49, 0xac, 0x27, 0x50, 0xe6, 0x15, 0x37, 0x79, 0x91, 0xcc, 0x9c, 0x00,
-	0x12, 0x96, 0xe9, 0xa9, 0xab, 0x74, 0x9b, 0xa9, 0x8b, 0x3c, 0x04, 0x39, 0x74, 0x22, 0x96, 0x98,
-	0x7c, 0xa6, 0x46, 0x64, 0x10, 0xac, 0x20, 0x8b, 0x56, 0x38, 0x90, 0x95, 0x82, 0x56, 0x6c, 0xfe,
-	0x45, 0x86, 0xb5, 0xbc, 0x87, 0x06, 0x2c, 0x9a, 0x6b, 0xb9, 0xa9, 0xb3, 0x2b, 0xb7, 0x3a, 0x7b,
-	0x1f, 0x40, 0x98, 0x6f, 0x79, 0x1c, 0x3e, 0x5b, 0x1c, 0x34, 0x39, 0xa3, 0x53, 0x01, 0x82, 0xfc,
-	0x0e, 0x54, 0x61, 0x0e, 0x48, 0x9e, 0x57, 0x37, 0x46, 0x14, 0x31, 0xc8, 0x57, 0xe2, 0xbb, 0xaf,
-	0x78, 0x3b, 0x40, 0xe1, 0xfd, 0xf7, 0x25, 0x94, 0x79, 0xe7, 0xd7, 0xcb, 0xb7, 0xc3, 0x4a, 0xc4,
-	0xc9, 0x11, 0x54, 0xc5, 0xe1, 0x40, 0x07, 0x84, 0xfb, 0x78, 0x69, 0xb8, 0x38, 0x33, 0xa8, 0x2a,
-	0x8c, 0x11, 0x59, 0xe5, 0x5c, 0xd5, 0x94, 0xe6, 0x7f, 0x0a, 0x50, 0xc2, 0x82, 0x84, 0xff, 0x00,
-	0x84, 0x91, 0x21, 0x8e, 0x83, 0x22, 0x15, 0x49, 0xc4, 0x80, 0xaa, 0x60, 0xb0, 0x10, 0xb3, 0xa8,
-	0x48, 0x73, 0xb4, 0x89, 0xbf, 0x1b, 0x45, 0xe4, 0x10, 0xff, 0x6e, 0xfc, 0x04, 0x6a, 0xb6, 0x3f,
-	0xba, 0x38, 0x63, 0x5e, 0x64, 0x65, 0xcf, 0xaf, 0x22, 0xcd, 0x13, 0xe3, 0x5c, 0xe4, 0xb7, 0x0f,
-	0x31, 0x59, 0x8a, 0x34, 0x5d, 0x92, 0x3f, 0xc1, 0x5d, 0xd1, 0x1a, 0xa1, 0xf9, 0xfa, 0xca, 0x4c,
-	0xd3, 0x38, 0xb1, 0xf4, 0xde, 0x92, 0x25, 0x58, 0x34, 0x50, 0xb8, 0x7b, 0x45, 0x13, 0x14, 0x5e,
-	0x96, 0x36, 0x82, 0x99, 0x9b, 0xcd, 0x2e, 0xbc, 0x7f, 0x8d, 0xd8, 0x8c, 0x89, 0x7e, 0x5d, 0x9c,
-	0xe8, 0x8b, 0xe2, 0xb3, 0xe0, 0xdd, 0x54, 0x47, 0x99, 0x87, 0xd1, 0xcd, 0xbf, 0x0a, 0x9e, 0xdf,
-	0xb4, 0x33, 0x0c, 0x58, 0x24, 0x2a, 0xfe, 0x21, 0x3e, 0xa2, 0x8c, 0x6f, 0x60, 0xbd, 0x2d, 0xc6,
-	0xc8, 0xa2, 0x37, 0xcd, 0x78, 0xd6, 0x2e, 0xe4, 0x66, 0xed, 0x47, 0xa0, 0x39, 0xde, 0xc8, 0xbd,
-	0xb0, 0x99, 0x39, 0x7a, 0xe3, 0xb8, 0x76, 0xc0, 0xbc, 0xe4, 0x27, 0x5d, 0x23, 0xa1, 0xef, 0x25,
-	0x64, 0xe3, 0x1f, 0x25, 0x20, 0x13, 0x3a, 0xe3, 0x8e, 0xdf, 0x06, 0x25, 0x8d, 0xd6, 0xa4, 0x55,
-	0x4f, 0xfc, 0x9c, 0x99, 0x12, 0xc9, 0x48, 0x34, 0x93, 0x24, 0xbf, 0xce, 0x77, 0xe5, 0xc7, 0x8b,
-	0x20, 0xa6, 0x7b, 0xf2, 0xe9, 0xb5, 0x3d, 0xf9, 0xd3, 0x85, 0x67, 0xba, 0x49, 0x47, 0x6e, 0xfe,
-	0x57, 0x02, 0x25, 0x05, 0x99, 0xdb, 0x2d, 0x1e, 0x27, 0xd3, 0x1f, 0xf7, 0xe8, 0x46, 0xee, 0x04,
-	0xd9, 0x4c, 0x96, 0x4c, 0x85, 0xdf, 0x53, 0x67, 0x69, 0x83, 0x92, 0xb9, 0xb1, 0x72, 0x53, 0x67,
-	0xa4, 0x92, 0xe2, 0x4f, 0xe7, 0x6c, 0xa2, 0x2c, 0x6b, 0xab, 0x49, 0x8d, 0xfc, 0x21, 0xe6, 0xcb,
-	0xe3, 0x9f, 0x42, 0x55, 0x7c, 0x82, 0x11, 0x05, 0xe4, 0x5e, 0xbf, 0xd7, 0xe1, 0xff, 0x69, 0xda,
-	0x9d, 0xfd, 0x9d, 0x17, 0x87, 0x43, 0x4d, 0x6a, 0xfd, 0xb5, 0x00, 0xea, 0x4b, 0xca, 0x8e, 0x07,
-	0x2c, 0x78, 0xeb, 0x60, 0xa7, 0x56, 0x85, 0xdf, 0x0c, 0xe4, 0xde, 0x82, 0x9f, 0xe4, 0xcd, 0x0f,
-	0xae, 0xfd, 0x43, 0x61, 0xac, 0x90, 0x57, 0xd0, 0x98, 0xa8, 0x50, 0xe4, 0xc1, 0x12, 0x4f, 0xe8,
-	0xe6, 0xfd, 0x85, 0x45, 0xce, 0x58, 0x21, 0x2f, 0xa0, 0x96, 0x73, 0x30, 0xb9, 0x7f, 0x9d, 0xf3,
-	0x39, 0xf0, 0xbd, 0x05, 0xf1, 0x61, 0xac, 0xec, 0x3e, 0x80, 0x7b, 0x23, 0xff, 0x6c, 0xfb, 0xc4,
-	0xf7, 0x4f, 0x5c, 0xb6, 0x6d, 0xb3, 0xb7, 0x91, 0xef, 0xbb, 0xa1, 0x28, 0x77, 0x24, 0xbd, 0x2e,
-	0xe3, 0xc7, 0xf3, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0x11, 0xf1, 0xc7, 0x41, 0x8f, 0x19, 0x00,
-	0x00,
+func init() { proto.RegisterFile("kythe/proto/xref.proto", fileDescriptor_xref_1767d5d2fe8e7743) }
+
+var fileDescriptor_xref_1767d5d2fe8e7743 = []byte{
+	// 2041 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xcd, 0x73, 0xe3, 0x48,
+	0x15, 0x8f, 0x6c, 0x39, 0x91, 0x9f, 0xfc, 0xa1, 0xf4, 0x64, 0x83, 0xd6, 0xcb, 0xee, 0x64, 0x34,
+	0xb0, 0x93, 0xf9, 0x58, 0x4f, 0xad, 0x07, 0xa8, 0xad, 0x85, 0x5d, 0x48, 0x62, 0x67, 0x71, 0xc8,
+	0xda, 0xa1, 0xed, 0x59, 0xa6, 0x6a, 0xab, 0x10, 0x1a, 0xa9, 0x93, 0xa8, 0xe2, 0x48, 0x5e, 0x49,
+	0x99, 0x99, 0xec, 0x81, 0x2a, 0xee, 0x9c, 0xf8, 0x2b, 0xb8, 0x72, 0xe0, 0xc2, 0x91, 0x23, 0xff,
+	0x0b, 0x77, 0x38, 0x40, 0x51, 0x7a, 0x2d, 0xc9, 0x2d, 0x7f, 0xc4, 0x9e, 0x14, 0x97, 0xbd, 0xa9,
+	0x5f, 0xbf, 0xf7, 0x7b, 0xdd, 0xef, 0xbb, 0x6d, 0xd8, 0xbe, 0xb8, 0x8e, 0xce, 0xd9, 0xd3, 0x71,
+	0xe0, 0x47, 0xfe, 0xd3, 0x37, 0x01, 0x3b, 0x6d, 0xe2, 0x27, 0x51, 0x91, 0xce, 0x17, 0x0d, 0x5d,
+	0x64, 0xb2, 0xfd, 0xcb, 0x4b, 0xdf, 0xe3, 0x3b, 0xc6, 0x9f, 0x25, 0x50, 0x8e, 0x7d, 0xdb, 0x8a,
+	0x5c, 0xdf, 0x23, 0xdb, 0xb0, 0x1e, 0xb9, 0xf6, 0x05, 0x8b, 0x74, 0x69, 0x47, 0xda, 0x2d, 0xd3,
+	0x64, 0x45, 0x9a, 0x20, 0x5f, 0xb8, 0x9e, 0xa3, 0x17, 0x76, 0xa4, 0xdd, 0x5a, 0xab, 0xd1, 0x14,
+	0xa0, 0x9b, 0xa9, 0x70, 0xf3, 0x57, 0xae, 0xe7, 0x50, 0xe4, 0x23, 0x4f, 0x40, 0x0e, 0xc7, 0x96,
+	0xa7, 0x97, 0x76, 0xa4, 0x5d, 0xb5, 0xa5, 0xe7, 0xf8, 0x13, 0xed, 0x83, 0xb1, 0xe5, 0x51, 0xe4,
+	0x32, 0x1a, 0x20, 0xc7, 0xb2, 0x44, 0x01, 0xf9, 0xb0, 0x7b, 0xdc, 0xd1, 0xd6, 0xe2, 0xaf, 0xc1,
+	0xc9, 0x5e, 0x4f, 0x93, 0x8e, 0x64, 0xa5, 0xa8, 0xc9, 0x47, 0xb2, 0x22, 0x6b, 0x25, 0xe3, 0x9f,
+	0x45, 0x20, 0x6d, 0x66, 0xfb, 0x01, 0xea, 0x0b, 0x29, 0xfb, 0xe6, 0x8a, 0x85, 0x11, 0xf9, 0x18,
+	0x94, 0x51, 0x72, 0x06, 0x3c, 0xb6, 0xda, 0x7a, 0x67, 0xee, 0x01, 0x69, 0xc6, 0x46, 0x3a, 0x50,
+	0x8e, 0x35, 0x9b, 0x78, 0x29, 0xc0, 0x4b, 0xed, 0xe6, 0x64, 0x66, 0xd5, 0xe0, 0x81, 0xf1, 0x8a,
+	0x4a, 0x98, 0x7c, 0x91, 0x7b, 0x50, 0x71, 0xdc, 0x20, 0xba, 0x36, 0x5f, 0x5e, 0x9d, 0x9e, 0xb2,
+	0x00, 0xcd, 0x53, 0xa1, 0x2a, 0xd2, 0xf6, 0x91, 0x44, 0xee, 0x82, 0x1a, 0xfa, 0x57, 0x81, 0xcd,
+	0xcc, 0x88, 0xbd, 0x89, 0xf4, 0xe2, 0x8e, 0xb4, 0xab, 0x50, 0xe0, 0xa4, 0x21, 0x7b, 0x13, 0x91,
+	0x0f, 0x00, 0x02, 0x76, 0xca, 0x02, 0xe6, 0xd9, 0x2c, 0xd4, 0x65, 0xbe, 0x3f, 0xa1, 0x90, 0x8f,
+	0x80, 0x44, 0x56, 0x70, 0xc6, 0x22, 0xd3, 0x61, 0xa7, 0xae, 0xe7, 0xe2, 0x99, 0xf4, 0x75, 0xe4,
+	0xdb, 0xe4, 0x3b, 0xed, 0xc9, 0x46, 0xec, 0xc1, 0x53, 0x77, 0x14, 0xb1, 0x40, 0x2f, 0xed, 0x14,
+	0x63, 0x0f, 0xf2, 0x15, 0x79, 0x0c, 0x9b, 0xec, 0x4d, 0xc4, 0x3c, 0x27, 0x34, 0xfd, 0x57, 0x2c,
+	0x08, 0x5c, 0x87, 0x85, 0xfa, 0x06, 0xa2, 0x68, 0xc9, 0x46, 0x3f, 0xa5, 0x93, 0x1d, 0x50, 0x1d,
+	0xd7, 0x3a, 0xf3, 0xfc, 0x30, 0x72, 0xed, 0x50, 0x57, 0x90, 0x4d, 0x24, 0x91, 0x1f, 0x83, 0x12,
+	0x7a, 0xee, 0x78, 0xcc, 0xa2, 0x50, 0x2f, 0xa3, 0xfd, 0xde, 0xcd, 0xd9, 0x6f, 0x90, 0x6c, 0x26,
+	0x06, 0x4b, 0x56, 0xc6, 0x13, 0x50, 0x52, 0x33, 0x92, 0x3a, 0xa8, 0xbf, 0xe9, 0x0e, 0x7f, 0xd9,
+	0xed, 0x99, 0xe8, 0xea, 0xb5, 0x98, 0xb0, 0x47, 0xfb, 0xcf, 0x7b, 0x6d, 0x4e, 0x90, 0x8c, 0xbf,
+	0x03, 0x68, 0x39, 0x47, 0x8c, 0x47, 0xd7, 0xb7, 0xf1, 0xf6, 0x94, 0x0f, 0xb8, 0x97, 0x44, 0x1f,
+	0x34, 0x40, 0x61, 0x9e, 0xed, 0x3b, 0xae, 0x77, 0x86, 0x1e, 0x2a, 0xd3, 0x6c, 0x1d, 0x87, 0x4a,
+	0xe6, 0x0d, 0x5d, 0xde, 0x29, 0xee, 0xaa, 0xad, 0x07, 0x8b, 0x43, 0x65, 0x3c, 0xba, 0x6e, 0xd2,
+	0x94, 0x9d, 0x4e, 0x24, 0xc9, 0xe7, 0x00, 0x13, 0xfb, 0xa1, 0x6f, 0xd4, 0xd6, 0x07, 0xf3, 0xf2,
+	0xa2, 0x9d, 0x71, 0x51, 0x41, 0x82, 0x7c, 0x0e, 0x25, 0xcf, 0x8f, 0x7d, 0x56, 0x47, 0xd1, 0xdd,
+	0x9b, 0x8f, 0xd0, 0x8b, 0x59, 0x3b, 0x5e, 0x14, 0x5c, 0x53, 0x2e, 0x46, 0x5c, 0xd8, 0x9a, 0xc4,
+	0x8f, 0x99, 0x9a, 0x26, 0xd4, 0x35, 0x84, 0xfb, 0xc9, 0xcd, 0x70, 0x93, 0x00, 0x4b, 0xad, 0x9b,
+	0x80, 0xdf, 0x71, 0x66, 0x77, 0xc8, 0xef, 0xe6, 0x85, 0xda, 0x26, 0xea, 0x79, 0x76, 0xb3, 0x9e,
+	0xce, 0x54, 0x20, 0x72, 0x25, 0x33, 0xf1, 0xd9, 0xf8, 0x8b, 0x04, 0xe5, 0xcc, 0xca, 0xe4, 0x3e,
+	0x54, 0x93, 0x0c, 0x49, 0x6a, 0x57, 0x01, 0x5d, 0x58, 0xe1, 0xc4, 0x21, 0xd2, 0x08, 0x49, 0x2a,
+	0x18, 0x77, 0x2f, 0x7e, 0xc7, 0x39, 0x31, 0x93, 0x5a, 0x98, 0x81, 0x65, 0xaa, 0x4d, 0x67, 0xd6,
+	0xdb, 0x95, 0xb4, 0x23, 0x59, 0x91, 0xb4, 0xc2, 0x91, 0xac, 0x80, 0xa6, 0x1e, 0xc9, 0x8a, 0xaa,
+	0x55, 0x1a, 0x7f, 0x28, 0x80, 0x92, 0xde, 0x00, 0xeb, 0x2c, 0x2a, 0xc8, 0xea, 0x2c, 0xae, 0xe6,
+	0x9f, 0xa8, 0xb4, 0xe0, 0x44, 0x3f, 0xcf, 0x15, 0xe5, 0xc7, 0x37, 0x9b, 0x36, 0x55, 0x2d, 0x56,
+	0xe9, 0x0e, 0x54, 0x2f, 0xad, 0xe0, 0x82, 0x39, 0x26, 0xcf, 0x05, 0xbc, 0xbb, 0xda, 0xda, 0x99,
+	0x77, 0xb7, 0x2f, 0x91, 0x71, 0x80, 0x7c, 0xb4, 0x72, 0x29, 0xac, 0x0c, 0x23, 0x29, 0xdf, 0x55,
+	0x28, 0xf7, 0xbf, 0xea, 0x50, 0xda, 0x6d, 0x77, 0x06, 0xda, 0x1a, 0x51, 0x61, 0xa3, 0xf3, 0x62,
+	0xd8, 0xe9, 0xb5, 0x07, 0x69, 0x19, 0x6f, 0xf4, 0xa1, 0x3c, 0x29, 0x32, 0xfb, 0xa0, 0xa4, 0xe1,
+	0xa1, 0x4b, 0x18, 0x1d, 0x1f, 0xae, 0x76, 0x05, 0x9a, 0xc9, 0x35, 0xbe, 0x02, 0x98, 0x84, 0x3a,
+	0xd1, 0xa0, 0x78, 0xc1, 0xae, 0x13, 0x93, 0xc6, 0x9f, 0xa4, 0x05, 0xa5, 0x57, 0xd6, 0xe8, 0x8a,
+	0xa1, 0x8d, 0xd4, 0xd6, 0xf7, 0xe7, 0xdd, 0x2c, 0x06, 0xe8, 0x7a, 0xa7, 0x3e, 0xe5, 0xac, 0x9f,
+	0x16, 0x3e, 0x91, 0x1a, 0x5f, 0x83, 0xbe, 0x28, 0xe6, 0xe7, 0x68, 0x79, 0x98, 0xd7, 0x72, 0x27,
+	0xa7, 0x65, 0xcf, 0xb3, 0xcf, 0xfd, 0x40, 0x04, 0x1f, 0xc1, 0x3b, 0x73, 0x03, 0x7d, 0x0e, 0xf2,
+	0x67, 0x79, 0xe4, 0x07, 0xab, 0x19, 0x28, 0x14, 0xb4, 0x19, 0x7f, 0x2d, 0xc3, 0xf6, 0x41, 0xe0,
+	0x87, 0x61, 0x96, 0x30, 0x59, 0xe3, 0x14, 0xbb, 0x7d, 0x51, 0xe8, 0xf6, 0x5f, 0x43, 0x5d, 0xa8,
+	0x15, 0x42, 0x8c, 0xb5, 0x72, 0xfa, 0xe7, 0xa3, 0x0a, 0xc5, 0x02, 0x43, 0xad, 0xe6, 0xe4, 0xd6,
+	0xe4, 0xb7, 0xa0, 0x39, 0xcc, 0x1e, 0x59, 0x81, 0x35, 0x41, 0xdf, 0x40, 0xf4, 0x67, 0xab, 0xa1,
+	0x67, 0xb2, 0x08, 0x5f, 0x77, 0xf2, 0x04, 0xf2, 0x02, 0x6a, 0x59, 0xd5, 0x35, 0xb3, 0x94, 0xaf,
+	0xb5, 0x3e, 0x5e, 0x05, 0x3d, 0xa3, 0x20, 0x76, 0x35, 0x10, 0x97, 0xa4, 0x0f, 0xaa, 0x6d, 0x8d,
+	0x46, 0x2c, 0xe0, 0xb0, 0x15, 0x84, 0x6d, 0xae, 0x02, 0x7b, 0x80, 0x62, 0x88, 0x09, 0x76, 0xf6,
+	0xbd, 0xb0, 0x57, 0x3f, 0x82, 0xcd, 0x80, 0x8d, 0xac, 0x88, 0x39, 0xa6, 0xe7, 0x3b, 0xc9, 0x2d,
+	0x6a, 0xc8, 0x52, 0x4f, 0x36, 0xe2, 0xa0, 0x45, 0x8c, 0xbb, 0xa0, 0x5a, 0x18, 0x61, 0xbc, 0xb7,
+	0xf1, 0xb9, 0x00, 0x38, 0x09, 0x7b, 0xdb, 0x43, 0xd0, 0x10, 0x44, 0x9c, 0x1e, 0x78, 0x43, 0xaf,
+	0xc7, 0x74, 0x71, 0x76, 0x78, 0x0f, 0xca, 0x63, 0xeb, 0x8c, 0x99, 0xa1, 0xfb, 0x2d, 0xc3, 0xa9,
+	0xa8, 0x44, 0x95, 0x98, 0x30, 0x70, 0xbf, 0x65, 0xe4, 0x7d, 0x00, 0xdc, 0x8c, 0xfc, 0x0b, 0xe6,
+	0xe9, 0x2a, 0xc6, 0x28, 0xb2, 0x0f, 0x63, 0x42, 0x6e, 0x20, 0xa8, 0xae, 0x3c, 0x10, 0x90, 0x97,
+	0x40, 0x6c, 0x3f, 0x18, 0x5f, 0x85, 0xe6, 0xd8, 0x8a, 0xce, 0xcd, 0xc4, 0x1c, 0x75, 0x8c, 0xf6,
+	0x1f, 0xad, 0x64, 0x5a, 0x94, 0x3e, 0xb1, 0xa2, 0xf3, 0x43, 0x94, 0xa5, 0x9a, 0x3d, 0x45, 0x69,
+	0x50, 0xd0, 0xa6, 0xb9, 0x62, 0xd3, 0x73, 0xbe, 0xb4, 0x00, 0xf3, 0x55, 0xdc, 0x26, 0x02, 0xdf,
+	0x4f, 0x5b, 0x08, 0x7e, 0xc7, 0xb4, 0xf8, 0x70, 0x69, 0xeb, 0x88, 0xbf, 0x8d, 0x73, 0xa8, 0xe5,
+	0xe3, 0x9c, 0x10, 0xa8, 0xf5, 0xfa, 0x66, 0xbb, 0x73, 0xd8, 0xed, 0x75, 0x87, 0xdd, 0x7e, 0x2f,
+	0x2e, 0x81, 0x77, 0xa0, 0xbe, 0x77, 0x7c, 0x9c, 0x23, 0x4a, 0x64, 0x0b, 0xb4, 0xc3, 0xe7, 0x53,
+	0xd4, 0x02, 0xf9, 0x1e, 0xdc, 0xd9, 0xef, 0xf6, 0xda, 0xdd, 0xde, 0x17, 0xb9, 0x8d, 0xa2, 0xf1,
+	0x33, 0xa8, 0x4f, 0xc5, 0x7c, 0x0c, 0x8b, 0xaa, 0x0e, 0x8e, 0xf7, 0xe8, 0x5e, 0xaa, 0x6b, 0x0b,
+	0x34, 0xae, 0x4b, 0xa0, 0x4a, 0x86, 0x03, 0xd5, 0x5c, 0x4c, 0x93, 0x4d, 0xa8, 0xf6, 0xfa, 0x26,
+	0xed, 0x1c, 0x76, 0x68, 0xa7, 0x77, 0xd0, 0x49, 0x4e, 0x79, 0x10, 0x8b, 0x0a, 0x44, 0x29, 0x3e,
+	0x4f, 0xaf, 0xdf, 0x33, 0xa7, 0x37, 0x0a, 0xf1, 0x3d, 0xa7, 0x68, 0x45, 0xe3, 0x10, 0x60, 0x12,
+	0xe2, 0xa4, 0x06, 0xd0, 0xeb, 0xa3, 0x64, 0x87, 0xc6, 0xf8, 0x04, 0x6a, 0xed, 0x2e, 0xed, 0x1c,
+	0x0c, 0x33, 0x1a, 0x1a, 0x21, 0xed, 0x15, 0x19, 0xb5, 0xc0, 0xc7, 0xfc, 0x23, 0x59, 0x71, 0x34,
+	0x66, 0xfc, 0x47, 0x82, 0x75, 0x5e, 0x3b, 0x17, 0xbe, 0x4a, 0x88, 0xd0, 0x00, 0xd3, 0x9e, 0xbe,
+	0x0d, 0xeb, 0x63, 0x2b, 0x60, 0x5e, 0x94, 0xb8, 0x2b, 0x59, 0x65, 0xed, 0x1b, 0x56, 0x69, 0xdf,
+	0x31, 0x72, 0x96, 0x4e, 0x65, 0x8a, 0xdf, 0x44, 0x87, 0x8d, 0x24, 0x6c, 0xb1, 0x5e, 0x95, 0x69,
+	0xba, 0x24, 0x3f, 0x85, 0x4a, 0xf2, 0x69, 0xa2, 0x0e, 0x75, 0x89, 0x0e, 0x35, 0xe1, 0x1e, 0xf0,
+	0x49, 0x81, 0xdf, 0xb9, 0xa4, 0xad, 0x1f, 0xc9, 0x8a, 0xa2, 0x95, 0x8f, 0x64, 0xa5, 0xac, 0x81,
+	0x31, 0x84, 0xf2, 0x49, 0xe0, 0x7a, 0x91, 0xf5, 0x72, 0xc4, 0xc8, 0xbb, 0xa0, 0x04, 0xd6, 0x6b,
+	0x9e, 0xe2, 0xdc, 0x06, 0x1b, 0x81, 0xf5, 0x1a, 0xf3, 0xfb, 0x09, 0xc8, 0x23, 0xd7, 0xbb, 0xd0,
+	0x0b, 0x3b, 0xc5, 0x45, 0x4a, 0x8f, 0x5d, 0xef, 0x82, 0x22, 0x97, 0xf1, 0xdf, 0x1a, 0x6c, 0xcd,
+	0x64, 0x52, 0x3c, 0x56, 0x7f, 0x06, 0xa5, 0xc8, 0x8f, 0xac, 0x91, 0x5e, 0x9a, 0xd3, 0x69, 0xe6,
+	0x49, 0x34, 0x87, 0x31, 0x3b, 0xe5, 0x52, 0xc4, 0x02, 0xcd, 0x8e, 0x99, 0x4c, 0xe1, 0x2d, 0x23,
+	0xcd, 0x19, 0x2d, 0xe7, 0x22, 0x4d, 0x11, 0xf9, 0xd4, 0x57, 0xb7, 0xf3, 0x54, 0xb2, 0x9f, 0x4e,
+	0xc0, 0xfc, 0xa6, 0x4f, 0x96, 0xe3, 0xce, 0x4e, 0xc1, 0x97, 0x0b, 0xa6, 0xe0, 0x22, 0x42, 0x7e,
+	0xba, 0x1c, 0xf2, 0xed, 0x26, 0xe1, 0x0f, 0xa1, 0xee, 0xb1, 0x37, 0x91, 0x29, 0x14, 0x4e, 0x40,
+	0xef, 0x55, 0x63, 0xf2, 0x49, 0x5a, 0x3c, 0x1b, 0x0e, 0xa8, 0x74, 0x52, 0xd7, 0x17, 0xc6, 0xfb,
+	0x7d, 0xa8, 0x62, 0xf9, 0xcf, 0x75, 0xe5, 0x32, 0xad, 0xa4, 0x44, 0xcc, 0x3e, 0x1d, 0x36, 0xfc,
+	0xc0, 0x71, 0x3d, 0x6b, 0x84, 0x19, 0x50, 0xa2, 0xe9, 0xb2, 0xf1, 0x0f, 0x09, 0xaa, 0x89, 0x9a,
+	0x24, 0xb1, 0x1e, 0xc3, 0x3a, 0xef, 0x14, 0xba, 0xb4, 0x78, 0x72, 0x49, 0x58, 0x66, 0xa7, 0xc5,
+	0xd2, 0x6d, 0xa6, 0x45, 0xf2, 0x00, 0xe4, 0xd0, 0x8d, 0x58, 0x62, 0xf2, 0xb9, 0x1a, 0x91, 0x41,
+	0xb0, 0x82, 0x2c, 0x5a, 0xe1, 0x48, 0x56, 0x0a, 0x5a, 0xb1, 0xf1, 0x47, 0x19, 0x36, 0xf3, 0x1e,
+	0x1a, 0xb0, 0x68, 0xa1, 0xe5, 0x66, 0xce, 0xae, 0xdc, 0xea, 0xec, 0x7d, 0x00, 0x61, 0x2e, 0xe7,
+	0x71, 0xf8, 0x74, 0x79, 0xd0, 0xe4, 0x8c, 0x4e, 0x05, 0x08, 0xf2, 0x6b, 0x50, 0x85, 0xf9, 0x45,
+	0x2f, 0xdd, 0x0e, 0x51, 0xc4, 0x20, 0x5f, 0x8a, 0xef, 0xd5, 0xe2, 0xed, 0x00, 0x27, 0x08, 0xe4,
+	0x0b, 0x58, 0xe7, 0x13, 0x8b, 0xbe, 0x7e, 0x3b, 0xac, 0x44, 0x9c, 0x9c, 0x40, 0x45, 0x1c, 0x6a,
+	0x74, 0x40, 0xb8, 0x8f, 0x56, 0x86, 0x8b, 0x33, 0x83, 0xaa, 0xc2, 0xf8, 0x93, 0x55, 0xce, 0x0d,
+	0x4d, 0x69, 0xfc, 0xab, 0x00, 0x25, 0x2c, 0x48, 0xf8, 0xdb, 0x85, 0x30, 0xea, 0xc4, 0x71, 0x50,
+	0xa4, 0x22, 0x89, 0x18, 0x50, 0x11, 0x0c, 0x16, 0x62, 0x16, 0x15, 0x69, 0x8e, 0x36, 0xf5, 0xab,
+	0x4c, 0x11, 0x39, 0x04, 0x0a, 0xf9, 0x01, 0x54, 0x1d, 0xdf, 0xbe, 0xba, 0x64, 0x5e, 0x64, 0x65,
+	0xcf, 0xc6, 0x22, 0xcd, 0x13, 0xe3, 0x5c, 0xe4, 0xb7, 0x0f, 0x31, 0x59, 0x8a, 0x34, 0x5d, 0x92,
+	0xdf, 0xc3, 0xbb, 0xa2, 0x35, 0x42, 0xf3, 0xe5, 0xb5, 0x99, 0xa6, 0x71, 0x62, 0xe9, 0x83, 0x15,
+	0x4b, 0xb0, 0x68, 0xa0, 0x70, 0xff, 0x9a, 0x26, 0x28, 0xbc, 0x2c, 0x6d, 0x07, 0x73, 0x37, 0x1b,
+	0x5d, 0x78, 0xef, 0x06, 0xb1, 0x39, 0x2f, 0x91, 0x2d, 0xf1, 0x25, 0x52, 0x14, 0x9f, 0x33, 0xaf,
+	0x67, 0x3a, 0xca, 0x22, 0x8c, 0x6e, 0xfe, 0x35, 0xf3, 0xec, 0x6d, 0x3b, 0xc3, 0x80, 0x45, 0xa2,
+	0xe2, 0xef, 0xe2, 0xe3, 0xcf, 0xf8, 0x06, 0xb6, 0xda, 0x62, 0x8c, 0x2c, 0x7b, 0x8b, 0x4d, 0xde,
+	0x08, 0x85, 0xdc, 0x1b, 0xe1, 0x21, 0x68, 0xae, 0x67, 0x8f, 0xae, 0x1c, 0x66, 0xda, 0xe7, 0xee,
+	0xc8, 0x09, 0x98, 0x97, 0xfc, 0xb8, 0x58, 0x4f, 0xe8, 0x07, 0x09, 0xd9, 0xf8, 0x5b, 0x09, 0xc8,
+	0x94, 0xce, 0xb8, 0xe3, 0xb7, 0x41, 0x49, 0xa3, 0x55, 0x97, 0xe6, 0xfd, 0xa8, 0x34, 0x23, 0x92,
+	0x91, 0x68, 0x26, 0x49, 0x7e, 0x91, 0xef, 0xca, 0x8f, 0x96, 0x41, 0xcc, 0xf6, 0xe4, 0x8b, 0x1b,
+	0x7b, 0xf2, 0x27, 0x4b, 0xcf, 0xf4, 0x36, 0x1d, 0xb9, 0xf1, 0x6f, 0x09, 0x94, 0x14, 0x64, 0x61,
+	0xb7, 0x78, 0x94, 0x4c, 0x7f, 0xdc, 0xa3, 0xdb, 0xb9, 0x13, 0x64, 0x33, 0x59, 0x32, 0x15, 0xfe,
+	0x9f, 0x3a, 0x4b, 0x1b, 0x94, 0xcc, 0x8d, 0xe5, 0xb7, 0x75, 0x46, 0x2a, 0x29, 0xfe, 0x58, 0x9e,
+	0x4d, 0x94, 0xeb, 0xda, 0x46, 0x52, 0x23, 0xbf, 0x8b, 0xf9, 0xf2, 0xe8, 0x87, 0x50, 0x11, 0x9f,
+	0x8e, 0xf1, 0x3f, 0x03, 0xbd, 0x7e, 0xaf, 0xc3, 0x7f, 0x5f, 0x6a, 0x77, 0x0e, 0xf7, 0x9e, 0x1f,
+	0x0f, 0x35, 0xa9, 0xf5, 0xa7, 0x02, 0xa8, 0x2f, 0x28, 0x3b, 0x1d, 0xb0, 0xe0, 0x95, 0x8b, 0x9d,
+	0x5a, 0x15, 0x7e, 0x1e, 0x21, 0x77, 0x97, 0xfc, 0xb8, 0xdf, 0x78, 0xff, 0xc6, 0x5f, 0x56, 0x8c,
+	0xb5, 0xf8, 0x37, 0x91, 0xa9, 0x0a, 0x45, 0xee, 0xaf, 0xf0, 0x3e, 0x6d, 0xdc, 0x5b, 0x5a, 0xe4,
+	0x8c, 0x35, 0xf2, 0x1c, 0xaa, 0x39, 0x07, 0x93, 0x7b, 0x37, 0x39, 0x9f, 0x03, 0xdf, 0x5d, 0x12,
+	0x1f, 0xc6, 0xda, 0xfe, 0x7d, 0xb8, 0x6b, 0xfb, 0x97, 0xcd, 0x33, 0xdf, 0x3f, 0x1b, 0xb1, 0xa6,
+	0xc3, 0x5e, 0x45, 0xbe, 0x3f, 0x0a, 0x45, 0xb9, 0x13, 0xe9, 0xe5, 0x3a, 0x7e, 0x3c, 0xfb, 0xdf,
+	0x00, 0xb2, 0x14, 0xa4, 0xeb, 0x47, 0x1a, 0x00, 0x00,
 }